@@ -0,0 +1,190 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+// Alerts
+//
+// The edgegrid client has no Alerts package, so the Alerts v2 endpoints are
+// called directly with the alerts_section credentials. An alert definition
+// instantiates an alert template, e.g. an origin error rate, for CP codes, and
+// notifies the emails subscribed to it.
+//
+// https://developer.akamai.com/api/core_features/alerts/v2.html
+
+var alertsConfig edgegrid.Config
+
+// alertsRequest calls the Alerts API, decoding the response into result if it
+// is not nil. Errors are client.APIError, see isNotFound.
+func alertsRequest(method string, path string, body interface{}, result interface{}) error {
+	var req *http.Request
+	var err error
+	if body != nil {
+		req, err = client.NewJSONRequest(alertsConfig, method, "/alerts/v2"+path, body)
+	} else {
+		req, err = client.NewRequest(alertsConfig, method, "/alerts/v2"+path, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Do(alertsConfig, req)
+	if err != nil {
+		return err
+	}
+
+	if client.IsError(res) {
+		return client.NewAPIError(res)
+	}
+
+	if result == nil || res.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	return client.BodyJSON(res, result)
+}
+
+type alertDefinition struct {
+	DefinitionID int               `json:"definitionId,omitempty"`
+	TemplateID   string            `json:"templateId"`
+	Name         string            `json:"name"`
+	CPCodes      []int             `json:"cpcodes"`
+	Fields       map[string]string `json:"fields,omitempty"`
+	Emails       []string          `json:"emails"`
+}
+
+// getAlertDefinition returns the alert definition, or nil if it does not exist
+//
+// Endpoint: GET /alerts/v2/alert-definitions/{definitionId}
+func getAlertDefinition(definitionID int) (*alertDefinition, error) {
+	result := &alertDefinition{}
+	if err := alertsRequest("GET", fmt.Sprintf("/alert-definitions/%d", definitionID), nil, result); err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// saveAlertDefinition creates the alert definition if it has no ID, or
+// updates it
+//
+// Endpoint: POST /alerts/v2/alert-definitions
+// Endpoint: PUT /alerts/v2/alert-definitions/{definitionId}
+func saveAlertDefinition(definition *alertDefinition) (*alertDefinition, error) {
+	result := &alertDefinition{}
+	if definition.DefinitionID == 0 {
+		log.Printf("[DEBUG] Creating alert definition %q from template %s\n", definition.Name, definition.TemplateID)
+		if err := alertsRequest("POST", "/alert-definitions", definition, result); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	log.Printf("[DEBUG] Updating alert definition %d\n", definition.DefinitionID)
+	path := fmt.Sprintf("/alert-definitions/%d", definition.DefinitionID)
+	if err := alertsRequest("PUT", path, definition, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// deleteAlertDefinition deletes the alert definition
+//
+// Endpoint: DELETE /alerts/v2/alert-definitions/{definitionId}
+func deleteAlertDefinition(definitionID int) error {
+	log.Printf("[DEBUG] Deleting alert definition %d\n", definitionID)
+
+	err := alertsRequest("DELETE", fmt.Sprintf("/alert-definitions/%d", definitionID), nil, nil)
+	if isNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+// The subscriptions of a definition are its emails, which are read, changed
+// and written back, so each change holds the lock.
+var alertSubscriptionLock sync.Mutex
+
+// updateAlertSubscriptions adds or removes the email from the subscriptions
+// of the alert definition. Unsubscribing from a deleted definition does
+// nothing.
+func updateAlertSubscriptions(definitionID int, email string, subscribe bool) error {
+	alertSubscriptionLock.Lock()
+	defer alertSubscriptionLock.Unlock()
+
+	definition, err := getAlertDefinition(definitionID)
+	if err != nil {
+		return err
+	}
+	if definition == nil {
+		if subscribe {
+			return fmt.Errorf("alert definition %d not found", definitionID)
+		}
+		return nil
+	}
+
+	if subscribe == hasEmail(definition.Emails, email) {
+		return nil
+	}
+
+	if subscribe {
+		log.Printf("[DEBUG] Subscribing %s to alert definition %d\n", email, definitionID)
+		definition.Emails = append(definition.Emails, email)
+	} else {
+		log.Printf("[DEBUG] Unsubscribing %s from alert definition %d\n", email, definitionID)
+		definition.Emails = withoutEmail(definition.Emails, email)
+	}
+	_, err = saveAlertDefinition(definition)
+	return err
+}
+
+func hasEmail(emails []string, email string) bool {
+	for _, e := range emails {
+		if strings.EqualFold(e, email) {
+			return true
+		}
+	}
+	return false
+}
+
+func withoutEmail(emails []string, email string) []string {
+	result := []string{}
+	for _, e := range emails {
+		if !strings.EqualFold(e, email) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// alertSubscriptionID returns the ID of a subscription, <definition_id>:<email>
+func alertSubscriptionID(definitionID int, email string) string {
+	return strconv.Itoa(definitionID) + ":" + email
+}
+
+func parseAlertSubscriptionID(id string) (int, string, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return 0, "", fmt.Errorf("invalid ID %q, expected <definition_id>:<email>", id)
+	}
+
+	definitionID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid definition ID %q", parts[0])
+	}
+
+	return definitionID, parts[1], nil
+}
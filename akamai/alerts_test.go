@@ -0,0 +1,84 @@
+package akamai
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// testAlertsServer serves the responses to alertsRequest until the returned
+// func is called
+func testAlertsServer(t *testing.T, responses map[string]string) func() {
+	server, config := testAPIServer(t, responses)
+
+	httpClient, previousConfig := client.Client, alertsConfig
+	client.Client = server.Client()
+	alertsConfig = config
+
+	return func() {
+		server.Close()
+		client.Client, alertsConfig = httpClient, previousConfig
+	}
+}
+
+func TestExpandAlertDefinition(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceAlertDefinition().Schema, map[string]interface{}{
+		"template_id": "origin-error-rate",
+		"name":        "example.com origin errors",
+		"cp_codes":    []interface{}{12345},
+		"parameters":  map[string]interface{}{"errorRate": "10"},
+	})
+
+	expected := &alertDefinition{
+		TemplateID: "origin-error-rate",
+		Name:       "example.com origin errors",
+		CPCodes:    []int{12345},
+		Fields:     map[string]string{"errorRate": "10"},
+		Emails:     []string{},
+	}
+	if definition := expandAlertDefinition(d); !reflect.DeepEqual(definition, expected) {
+		t.Errorf("expected %+v, got %+v", expected, definition)
+	}
+}
+
+func TestParseAlertSubscriptionID(t *testing.T) {
+	definitionID, email, err := parseAlertSubscriptionID(alertSubscriptionID(12345, "ops@example.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if definitionID != 12345 || email != "ops@example.com" {
+		t.Errorf("unexpected ID parts %d, %s", definitionID, email)
+	}
+
+	for _, id := range []string{"12345", "12345:", "origin:ops@example.com"} {
+		if _, _, err := parseAlertSubscriptionID(id); err == nil {
+			t.Errorf("%s: expected an error", id)
+		}
+	}
+}
+
+func TestUpdateAlertSubscriptions(t *testing.T) {
+	defer testAlertsServer(t, map[string]string{
+		"GET /alerts/v2/alert-definitions/12345": `{"definitionId": 12345, "templateId": "origin-error-rate", "emails": ["ops@example.com"]}`,
+		"GET /alerts/v2/alert-definitions/678":   `{"definitionId": 678, "templateId": "origin-error-rate", "emails": []}`,
+	})()
+
+	// Already subscribed, and not subscribed, so neither makes a PUT request
+	if err := updateAlertSubscriptions(12345, "OPS@example.com", true); err != nil {
+		t.Error(err)
+	}
+	if err := updateAlertSubscriptions(678, "ops@example.com", false); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAlertEmails(t *testing.T) {
+	if !hasEmail([]string{"ops@example.com"}, "Ops@Example.com") {
+		t.Error("expected emails to match case-insensitively")
+	}
+	if emails := withoutEmail([]string{"ops@example.com", "dev@example.com"}, "OPS@example.com"); !reflect.DeepEqual(emails, []string{"dev@example.com"}) {
+		t.Errorf("unexpected emails %v", emails)
+	}
+}
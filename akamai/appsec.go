@@ -0,0 +1,258 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+// Application Security
+//
+// The edgegrid client has no AppSec package, so the AppSec v1 endpoints are
+// called directly with the appsec_section credentials. Like property versions,
+// only the versions of a security configuration that were never activated can
+// be changed.
+//
+// https://developer.akamai.com/api/cloud_security/application_security/v1.html
+
+var appsecConfig edgegrid.Config
+
+// appsecRequest calls the AppSec API, decoding the response into result if it
+// is not nil. Errors are client.APIError, see isNotFound.
+func appsecRequest(method string, path string, body interface{}, result interface{}) error {
+	var req *http.Request
+	var err error
+	if body != nil {
+		req, err = client.NewJSONRequest(appsecConfig, method, "/appsec/v1"+path, body)
+	} else {
+		req, err = client.NewRequest(appsecConfig, method, "/appsec/v1"+path, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Do(appsecConfig, req)
+	if err != nil {
+		return err
+	}
+
+	if client.IsError(res) {
+		return client.NewAPIError(res)
+	}
+
+	if result == nil || res.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	return client.BodyJSON(res, result)
+}
+
+// appsecID returns the ID of a security configuration version, or of an object
+// in it, <config_id>:<version>[:<name>...]
+func appsecID(configID int, version int, names ...string) string {
+	return strings.Join(append([]string{strconv.Itoa(configID), strconv.Itoa(version)}, names...), ":")
+}
+
+// parseAppSecID parses an ID returned by appsecID with the number of names
+func parseAppSecID(id string, names int) (int, int, []string, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 2+names {
+		expected := "<config_id>:<version>" + strings.Repeat(":<id>", names)
+		return 0, 0, nil, fmt.Errorf("invalid ID %q, expected %s", id, expected)
+	}
+
+	configID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("invalid config ID %q", parts[0])
+	}
+	version, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("invalid version %q", parts[1])
+	}
+
+	return configID, version, parts[2:], nil
+}
+
+type appsecConfiguration struct {
+	ID                int    `json:"id"`
+	Name              string `json:"name"`
+	LatestVersion     int    `json:"latestVersion"`
+	StagingVersion    int    `json:"stagingVersion"`
+	ProductionVersion int    `json:"productionVersion"`
+}
+
+// getAppSecConfigurations returns the security configurations
+//
+// Endpoint: GET /appsec/v1/configs
+func getAppSecConfigurations() ([]*appsecConfiguration, error) {
+	result := &struct {
+		Configurations []*appsecConfiguration `json:"configurations"`
+	}{}
+	if err := appsecRequest("GET", "/configs", nil, result); err != nil {
+		return nil, err
+	}
+
+	return result.Configurations, nil
+}
+
+// getAppSecConfiguration returns the security configuration with the ID
+func getAppSecConfiguration(configID int) (*appsecConfiguration, error) {
+	configurations, err := getAppSecConfigurations()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, configuration := range configurations {
+		if configuration.ID == configID {
+			return configuration, nil
+		}
+	}
+	return nil, fmt.Errorf("security configuration %d not found", configID)
+}
+
+type appsecActivationStatus struct {
+	Status string `json:"status"`
+}
+
+type appsecVersion struct {
+	ConfigID     int                    `json:"configId"`
+	ConfigName   string                 `json:"configName"`
+	Version      int                    `json:"version"`
+	VersionNotes string                 `json:"versionNotes"`
+	BasedOn      int                    `json:"basedOn"`
+	Staging      appsecActivationStatus `json:"staging"`
+	Production   appsecActivationStatus `json:"production"`
+}
+
+// editable returns whether the version was never activated, so it can be changed
+func (version *appsecVersion) editable() bool {
+	return version.Staging.Status == "Inactive" && version.Production.Status == "Inactive"
+}
+
+// getAppSecVersion returns the version, or nil if it does not exist
+//
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}
+func getAppSecVersion(configID int, version int) (*appsecVersion, error) {
+	result := &appsecVersion{}
+	if err := appsecRequest("GET", fmt.Sprintf("/configs/%d/versions/%d", configID, version), nil, result); err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// createAppSecVersion creates a version from the base version
+//
+// Endpoint: POST /appsec/v1/configs/{configId}/versions
+func createAppSecVersion(configID int, baseVersion int) (*appsecVersion, error) {
+	log.Printf("[DEBUG] Creating security configuration %d version from version %d\n", configID, baseVersion)
+
+	result := &appsecVersion{}
+	body := map[string]interface{}{
+		"createFromVersion": baseVersion,
+		"ruleUpdate":        false,
+	}
+	if err := appsecRequest("POST", fmt.Sprintf("/configs/%d/versions", configID), body, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// updateAppSecVersionNotes sets the notes of the version
+//
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/version-notes
+func updateAppSecVersionNotes(configID int, version int, notes string) error {
+	path := fmt.Sprintf("/configs/%d/versions/%d/version-notes", configID, version)
+	return appsecRequest("PUT", path, map[string]string{"notes": notes}, nil)
+}
+
+// deleteAppSecVersion deletes the version
+//
+// Endpoint: DELETE /appsec/v1/configs/{configId}/versions/{versionNumber}
+func deleteAppSecVersion(configID int, version int) error {
+	log.Printf("[DEBUG] Deleting security configuration %d version %d\n", configID, version)
+
+	err := appsecRequest("DELETE", fmt.Sprintf("/configs/%d/versions/%d", configID, version), nil, nil)
+	if isNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+// appsecVersionEditable returns whether the version exists and was never
+// activated. Objects in other versions are only removed from the state on delete.
+func appsecVersionEditable(configID int, versionNumber int) (bool, error) {
+	version, err := getAppSecVersion(configID, versionNumber)
+	if err != nil || version == nil {
+		return false, err
+	}
+
+	return version.editable(), nil
+}
+
+// checkAppSecVersionEditable returns an error if the version was activated, as
+// it can't be changed any more
+func checkAppSecVersionEditable(configID int, versionNumber int) error {
+	version, err := getAppSecVersion(configID, versionNumber)
+	if err != nil {
+		return err
+	}
+	if version == nil {
+		return fmt.Errorf("security configuration %d version %d not found", configID, versionNumber)
+	}
+	if !version.editable() {
+		return fmt.Errorf("security configuration %d version %d was activated and can't be changed, create a new version with akamai_appsec_configuration_version", configID, versionNumber)
+	}
+
+	return nil
+}
+
+type appsecHostnames struct {
+	HostnameList []appsecHostname `json:"hostnameList"`
+}
+
+type appsecHostname struct {
+	Hostname string `json:"hostname"`
+}
+
+// getAppSecSelectedHostnames returns the hostnames protected by the version
+//
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/selected-hostnames
+func getAppSecSelectedHostnames(configID int, version int) ([]string, error) {
+	result := &appsecHostnames{}
+	path := fmt.Sprintf("/configs/%d/versions/%d/selected-hostnames", configID, version)
+	if err := appsecRequest("GET", path, nil, result); err != nil {
+		return nil, err
+	}
+
+	var hostnames []string
+	for _, hostname := range result.HostnameList {
+		hostnames = append(hostnames, hostname.Hostname)
+	}
+	return hostnames, nil
+}
+
+// updateAppSecSelectedHostnames replaces the hostnames protected by the version
+//
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/selected-hostnames
+func updateAppSecSelectedHostnames(configID int, version int, hostnames []string) error {
+	log.Printf("[DEBUG] Setting the hostnames of security configuration %d version %d to %v\n", configID, version, hostnames)
+
+	body := &appsecHostnames{HostnameList: []appsecHostname{}}
+	for _, hostname := range hostnames {
+		body.HostnameList = append(body.HostnameList, appsecHostname{Hostname: hostname})
+	}
+
+	path := fmt.Sprintf("/configs/%d/versions/%d/selected-hostnames", configID, version)
+	return appsecRequest("PUT", path, body, nil)
+}
@@ -0,0 +1,158 @@
+package akamai
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+)
+
+// Custom rules belong to the security configuration, not to a version. Only
+// the actions of the security policies for them are versioned.
+
+func appsecCustomRulesPath(configID int) string {
+	return fmt.Sprintf("/configs/%d/custom-rules", configID)
+}
+
+// getAppSecCustomRule returns the custom rule, or nil if it does not exist
+//
+// Endpoint: GET /appsec/v1/configs/{configId}/custom-rules/{ruleId}
+func getAppSecCustomRule(configID int, ruleID int) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	path := fmt.Sprintf("%s/%d", appsecCustomRulesPath(configID), ruleID)
+	if err := appsecRequest("GET", path, nil, &result); err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// createAppSecCustomRule creates the custom rule and returns its ID
+//
+// Endpoint: POST /appsec/v1/configs/{configId}/custom-rules
+func createAppSecCustomRule(configID int, rule map[string]interface{}) (int, error) {
+	log.Printf("[DEBUG] Creating custom rule %v in security configuration %d\n", rule["name"], configID)
+
+	result := &struct {
+		ID int `json:"id"`
+	}{}
+	if err := appsecRequest("POST", appsecCustomRulesPath(configID), rule, result); err != nil {
+		return 0, err
+	}
+
+	return result.ID, nil
+}
+
+// updateAppSecCustomRule updates the custom rule
+//
+// Endpoint: PUT /appsec/v1/configs/{configId}/custom-rules/{ruleId}
+func updateAppSecCustomRule(configID int, ruleID int, rule map[string]interface{}) error {
+	log.Printf("[DEBUG] Updating custom rule %d in security configuration %d\n", ruleID, configID)
+
+	path := fmt.Sprintf("%s/%d", appsecCustomRulesPath(configID), ruleID)
+	return appsecRequest("PUT", path, rule, nil)
+}
+
+// deleteAppSecCustomRule deletes the custom rule. Rules that are used by a
+// security policy can't be deleted.
+//
+// Endpoint: DELETE /appsec/v1/configs/{configId}/custom-rules/{ruleId}
+func deleteAppSecCustomRule(configID int, ruleID int) error {
+	log.Printf("[DEBUG] Deleting custom rule %d in security configuration %d\n", ruleID, configID)
+
+	path := fmt.Sprintf("%s/%d", appsecCustomRulesPath(configID), ruleID)
+	err := appsecRequest("DELETE", path, nil, nil)
+	if isNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+// parseAppSecCustomRule parses the JSON of a custom rule, without its ID
+func parseAppSecCustomRule(rule string) (map[string]interface{}, error) {
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(rule), &parsed); err != nil {
+		return nil, fmt.Errorf("invalid custom rule JSON: %s", err)
+	}
+
+	delete(parsed, "id")
+	return parsed, nil
+}
+
+// appsecJSONSubset returns whether the configured JSON value equals the one
+// returned by the API, ignoring the object fields the API added
+func appsecJSONSubset(configured interface{}, returned interface{}) bool {
+	switch configured := configured.(type) {
+	case map[string]interface{}:
+		returned, ok := returned.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range configured {
+			if !appsecJSONSubset(value, returned[key]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		returned, ok := returned.([]interface{})
+		if !ok || len(configured) != len(returned) {
+			return false
+		}
+		for i := range configured {
+			if !appsecJSONSubset(configured[i], returned[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return reflect.DeepEqual(configured, returned)
+}
+
+type appsecCustomRuleAction struct {
+	ID     int    `json:"id"`
+	Action string `json:"action"`
+}
+
+func appsecCustomRuleActionsPath(configID int, version int, policyID string) string {
+	return fmt.Sprintf("/configs/%d/versions/%d/security-policies/%s/custom-rules", configID, version, policyID)
+}
+
+// getAppSecCustomRuleAction returns the action of the security policy for the
+// custom rule, or "" if the custom rule does not exist
+//
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/custom-rules
+func getAppSecCustomRuleAction(configID int, version int, policyID string, ruleID int) (string, error) {
+	result := &struct {
+		CustomRules []*appsecCustomRuleAction `json:"customRules"`
+	}{}
+	if err := appsecRequest("GET", appsecCustomRuleActionsPath(configID, version, policyID), nil, result); err != nil {
+		if isNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	for _, rule := range result.CustomRules {
+		if rule.ID == ruleID {
+			return rule.Action, nil
+		}
+	}
+	return "", nil
+}
+
+// updateAppSecCustomRuleAction sets the action of the security policy for the
+// custom rule
+//
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/custom-rules/{ruleId}
+func updateAppSecCustomRuleAction(configID int, version int, policyID string, ruleID int, action string) error {
+	log.Printf("[DEBUG] Setting the action of security policy %s for custom rule %d to %s\n", policyID, ruleID, action)
+
+	path := fmt.Sprintf("%s/%d", appsecCustomRuleActionsPath(configID, version, policyID), ruleID)
+	return appsecRequest("PUT", path, map[string]string{"action": action}, nil)
+}
@@ -0,0 +1,38 @@
+package akamai
+
+import "testing"
+
+func TestAppSecJSONSubset(t *testing.T) {
+	returned, err := parseAppSecCustomRule(`{
+		"id": 661699,
+		"name": "Block /admin",
+		"tag": [],
+		"structured": true,
+		"conditions": [{"type": "pathMatch", "positiveMatch": true, "value": ["/admin"], "valueCase": false}]
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := returned["id"]; ok {
+		t.Error("expected the ID to be removed")
+	}
+
+	for rule, expected := range map[string]bool{
+		`{"name": "Block /admin", "conditions": [{"type": "pathMatch", "positiveMatch": true, "value": ["/admin"]}]}`:  true,
+		`{"name": "Block /admin", "conditions": [{"type": "pathMatch", "positiveMatch": false, "value": ["/admin"]}]}`: false,
+		`{"name": "Block /admin", "conditions": [{"type": "pathMatch", "value": ["/admin", "/login"]}]}`:               false,
+		`{"name": "Block /admin", "description": "Virtual patch"}`:                                                     false,
+	} {
+		configured, err := parseAppSecCustomRule(rule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if subset := appsecJSONSubset(configured, returned); subset != expected {
+			t.Errorf("%s: expected %t, got %t", rule, expected, subset)
+		}
+	}
+
+	if _, err := parseAppSecCustomRule(`{"name": `); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
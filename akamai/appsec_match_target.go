@@ -0,0 +1,164 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+	"sort"
+)
+
+type appsecMatchTarget struct {
+	TargetID                     int                     `json:"targetId,omitempty"`
+	Type                         string                  `json:"type"`
+	Sequence                     int                     `json:"sequence,omitempty"`
+	Hostnames                    []string                `json:"hostnames,omitempty"`
+	FilePaths                    []string                `json:"filePaths,omitempty"`
+	FileExtensions               []string                `json:"fileExtensions,omitempty"`
+	IsNegativePathMatch          bool                    `json:"isNegativePathMatch"`
+	IsNegativeFileExtensionMatch bool                    `json:"isNegativeFileExtensionMatch"`
+	DefaultFile                  string                  `json:"defaultFile,omitempty"`
+	SecurityPolicy               appsecSecurityPolicyRef `json:"securityPolicy"`
+	BypassNetworkLists           []appsecNetworkListRef  `json:"bypassNetworkLists,omitempty"`
+	APIs                         []appsecAPIEndpointRef  `json:"apis,omitempty"`
+}
+
+type appsecSecurityPolicyRef struct {
+	PolicyID string `json:"policyId"`
+}
+
+type appsecNetworkListRef struct {
+	ID string `json:"id"`
+}
+
+type appsecAPIEndpointRef struct {
+	ID int `json:"id"`
+}
+
+func appsecMatchTargetsPath(configID int, version int) string {
+	return fmt.Sprintf("/configs/%d/versions/%d/match-targets", configID, version)
+}
+
+// getAppSecMatchTargets returns the match targets of the type, website or api,
+// in evaluation order
+//
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/match-targets
+func getAppSecMatchTargets(configID int, version int, targetType string) ([]*appsecMatchTarget, error) {
+	result := &struct {
+		MatchTargets struct {
+			WebsiteTargets []*appsecMatchTarget `json:"websiteTargets"`
+			APITargets     []*appsecMatchTarget `json:"apiTargets"`
+		} `json:"matchTargets"`
+	}{}
+	if err := appsecRequest("GET", appsecMatchTargetsPath(configID, version), nil, result); err != nil {
+		return nil, err
+	}
+
+	targets := result.MatchTargets.WebsiteTargets
+	if targetType == "api" {
+		targets = result.MatchTargets.APITargets
+	}
+	sort.SliceStable(targets, func(i, j int) bool {
+		return targets[i].Sequence < targets[j].Sequence
+	})
+	return targets, nil
+}
+
+// getAppSecMatchTarget returns the match target, or nil if it does not exist
+//
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/match-targets/{targetId}
+func getAppSecMatchTarget(configID int, version int, targetID int) (*appsecMatchTarget, error) {
+	result := &appsecMatchTarget{}
+	path := fmt.Sprintf("%s/%d", appsecMatchTargetsPath(configID, version), targetID)
+	if err := appsecRequest("GET", path, nil, result); err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// saveAppSecMatchTarget creates the match target if it has no ID, or updates it
+//
+// Endpoint: POST /appsec/v1/configs/{configId}/versions/{versionNumber}/match-targets
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/match-targets/{targetId}
+func saveAppSecMatchTarget(configID int, version int, target *appsecMatchTarget) (*appsecMatchTarget, error) {
+	result := &appsecMatchTarget{}
+	if target.TargetID == 0 {
+		log.Printf("[DEBUG] Creating %s match target in security configuration %d version %d\n", target.Type, configID, version)
+		if err := appsecRequest("POST", appsecMatchTargetsPath(configID, version), target, result); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	log.Printf("[DEBUG] Updating match target %d in security configuration %d version %d\n", target.TargetID, configID, version)
+	path := fmt.Sprintf("%s/%d", appsecMatchTargetsPath(configID, version), target.TargetID)
+	if err := appsecRequest("PUT", path, target, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// deleteAppSecMatchTarget deletes the match target
+//
+// Endpoint: DELETE /appsec/v1/configs/{configId}/versions/{versionNumber}/match-targets/{targetId}
+func deleteAppSecMatchTarget(configID int, version int, targetID int) error {
+	log.Printf("[DEBUG] Deleting match target %d in security configuration %d version %d\n", targetID, configID, version)
+
+	path := fmt.Sprintf("%s/%d", appsecMatchTargetsPath(configID, version), targetID)
+	err := appsecRequest("DELETE", path, nil, nil)
+	if isNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+type appsecTargetSequence struct {
+	TargetID int `json:"targetId"`
+	Sequence int `json:"sequence"`
+}
+
+// sequenceAppSecMatchTargets returns the sequence of the match targets, in
+// evaluation order, with the target moved to the position, starting at 1
+func sequenceAppSecMatchTargets(targets []*appsecMatchTarget, targetID int, position int) []appsecTargetSequence {
+	var others []int
+	for _, target := range targets {
+		if target.TargetID != targetID {
+			others = append(others, target.TargetID)
+		}
+	}
+
+	if position < 1 {
+		position = 1
+	}
+	if position > len(others)+1 {
+		position = len(others) + 1
+	}
+	ids := append(append(append([]int{}, others[:position-1]...), targetID), others[position-1:]...)
+
+	sequence := make([]appsecTargetSequence, len(ids))
+	for i, id := range ids {
+		sequence[i] = appsecTargetSequence{TargetID: id, Sequence: i + 1}
+	}
+	return sequence
+}
+
+// updateAppSecMatchTargetSequence moves the match target to the position in the
+// evaluation order of the targets of its type
+//
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/match-targets/sequence
+func updateAppSecMatchTargetSequence(configID int, version int, targetType string, targetID int, position int) error {
+	targets, err := getAppSecMatchTargets(configID, version, targetType)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Moving match target %d in security configuration %d version %d to position %d\n", targetID, configID, version, position)
+	body := map[string]interface{}{
+		"type":           targetType,
+		"targetSequence": sequenceAppSecMatchTargets(targets, targetID, position),
+	}
+	return appsecRequest("PUT", appsecMatchTargetsPath(configID, version)+"/sequence", body, nil)
+}
@@ -0,0 +1,34 @@
+package akamai
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSequenceAppSecMatchTargets(t *testing.T) {
+	targets := []*appsecMatchTarget{{TargetID: 10}, {TargetID: 20}, {TargetID: 30}}
+
+	for position, expected := range map[int][]int{
+		1: {30, 10, 20},
+		2: {10, 30, 20},
+		3: {10, 20, 30},
+		9: {10, 20, 30},
+	} {
+		var ids []int
+		for i, sequence := range sequenceAppSecMatchTargets(targets, 30, position) {
+			if sequence.Sequence != i+1 {
+				t.Errorf("position %d: expected sequence %d, got %d", position, i+1, sequence.Sequence)
+			}
+			ids = append(ids, sequence.TargetID)
+		}
+		if !reflect.DeepEqual(ids, expected) {
+			t.Errorf("position %d: expected %v, got %v", position, expected, ids)
+		}
+	}
+
+	// A target that isn't listed yet is inserted
+	sequence := sequenceAppSecMatchTargets(targets, 40, 2)
+	if len(sequence) != 4 || sequence[1].TargetID != 40 {
+		t.Errorf("expected target 40 second, got %v", sequence)
+	}
+}
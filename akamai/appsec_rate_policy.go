@@ -0,0 +1,142 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+)
+
+type appsecRatePolicy struct {
+	ID                     int                     `json:"id,omitempty"`
+	Type                   string                  `json:"type"`
+	Name                   string                  `json:"name"`
+	Description            string                  `json:"description,omitempty"`
+	MatchType              string                  `json:"matchType"`
+	RequestType            string                  `json:"requestType"`
+	PathMatchType          string                  `json:"pathMatchType"`
+	PathURIPositiveMatch   bool                    `json:"pathUriPositiveMatch"`
+	Path                   *appsecMatchValues      `json:"path,omitempty"`
+	FileExtensions         *appsecMatchValues      `json:"fileExtensions,omitempty"`
+	Hostnames              []string                `json:"hostnames,omitempty"`
+	AverageThreshold       int                     `json:"averageThreshold"`
+	BurstThreshold         int                     `json:"burstThreshold"`
+	ClientIdentifier       string                  `json:"clientIdentifier"`
+	SameActionOnIPv6       bool                    `json:"sameActionOnIpv6"`
+	UseXForwardForHeaders  bool                    `json:"useXForwardForHeaders"`
+	AdditionalMatchOptions []*appsecMatchCondition `json:"additionalMatchOptions,omitempty"`
+}
+
+// appsecMatchValues matches the values, or with PositiveMatch false, anything else
+type appsecMatchValues struct {
+	PositiveMatch bool     `json:"positiveMatch"`
+	Values        []string `json:"values"`
+}
+
+type appsecMatchCondition struct {
+	Type          string   `json:"type"`
+	PositiveMatch bool     `json:"positiveMatch"`
+	Values        []string `json:"values"`
+}
+
+func appsecRatePoliciesPath(configID int, version int) string {
+	return fmt.Sprintf("/configs/%d/versions/%d/rate-policies", configID, version)
+}
+
+// getAppSecRatePolicy returns the rate policy, or nil if it does not exist
+//
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/rate-policies/{ratePolicyId}
+func getAppSecRatePolicy(configID int, version int, ratePolicyID int) (*appsecRatePolicy, error) {
+	result := &appsecRatePolicy{}
+	path := fmt.Sprintf("%s/%d", appsecRatePoliciesPath(configID, version), ratePolicyID)
+	if err := appsecRequest("GET", path, nil, result); err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// saveAppSecRatePolicy creates the rate policy if it has no ID, or updates it
+//
+// Endpoint: POST /appsec/v1/configs/{configId}/versions/{versionNumber}/rate-policies
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/rate-policies/{ratePolicyId}
+func saveAppSecRatePolicy(configID int, version int, policy *appsecRatePolicy) (*appsecRatePolicy, error) {
+	result := &appsecRatePolicy{}
+	if policy.ID == 0 {
+		log.Printf("[DEBUG] Creating rate policy %q in security configuration %d version %d\n", policy.Name, configID, version)
+		if err := appsecRequest("POST", appsecRatePoliciesPath(configID, version), policy, result); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	log.Printf("[DEBUG] Updating rate policy %d in security configuration %d version %d\n", policy.ID, configID, version)
+	path := fmt.Sprintf("%s/%d", appsecRatePoliciesPath(configID, version), policy.ID)
+	if err := appsecRequest("PUT", path, policy, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// deleteAppSecRatePolicy deletes the rate policy
+//
+// Endpoint: DELETE /appsec/v1/configs/{configId}/versions/{versionNumber}/rate-policies/{ratePolicyId}
+func deleteAppSecRatePolicy(configID int, version int, ratePolicyID int) error {
+	log.Printf("[DEBUG] Deleting rate policy %d in security configuration %d version %d\n", ratePolicyID, configID, version)
+
+	path := fmt.Sprintf("%s/%d", appsecRatePoliciesPath(configID, version), ratePolicyID)
+	err := appsecRequest("DELETE", path, nil, nil)
+	if isNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+type appsecRatePolicyAction struct {
+	ID         int    `json:"id,omitempty"`
+	IPv4Action string `json:"ipv4Action"`
+	IPv6Action string `json:"ipv6Action"`
+}
+
+func appsecRatePolicyActionsPath(configID int, version int, policyID string) string {
+	return fmt.Sprintf("/configs/%d/versions/%d/security-policies/%s/rate-policies", configID, version, policyID)
+}
+
+// getAppSecRatePolicyAction returns the action of the security policy for the
+// rate policy, or nil if the rate policy does not exist
+//
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/rate-policies
+func getAppSecRatePolicyAction(configID int, version int, policyID string, ratePolicyID int) (*appsecRatePolicyAction, error) {
+	result := &struct {
+		RatePolicyActions []*appsecRatePolicyAction `json:"ratePolicyActions"`
+	}{}
+	if err := appsecRequest("GET", appsecRatePolicyActionsPath(configID, version, policyID), nil, result); err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	for _, action := range result.RatePolicyActions {
+		if action.ID == ratePolicyID {
+			return action, nil
+		}
+	}
+	return nil, nil
+}
+
+// updateAppSecRatePolicyAction sets the action of the security policy for the
+// rate policy
+//
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/rate-policies/{ratePolicyId}
+func updateAppSecRatePolicyAction(configID int, version int, policyID string, action *appsecRatePolicyAction) error {
+	log.Printf("[DEBUG] Setting the action of security policy %s for rate policy %d to %s/%s\n", policyID, action.ID, action.IPv4Action, action.IPv6Action)
+
+	path := fmt.Sprintf("%s/%d", appsecRatePolicyActionsPath(configID, version, policyID), action.ID)
+	return appsecRequest("PUT", path, map[string]string{
+		"ipv4Action": action.IPv4Action,
+		"ipv6Action": action.IPv6Action,
+	}, nil)
+}
@@ -0,0 +1,37 @@
+package akamai
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAppSecID(t *testing.T) {
+	configID, version, names, err := parseAppSecID(appsecID(12345, 3, "2028"), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if configID != 12345 || version != 3 || !reflect.DeepEqual(names, []string{"2028"}) {
+		t.Errorf("unexpected ID parts %d, %d, %v", configID, version, names)
+	}
+
+	for _, id := range []string{"12345", "12345:3:2028", "config:3", "12345:latest"} {
+		if _, _, _, err := parseAppSecID(id, 0); err == nil {
+			t.Errorf("%s: expected an error", id)
+		}
+	}
+}
+
+func TestAppSecVersionEditable(t *testing.T) {
+	version := &appsecVersion{
+		Staging:    appsecActivationStatus{Status: "Inactive"},
+		Production: appsecActivationStatus{Status: "Inactive"},
+	}
+	if !version.editable() {
+		t.Error("expected a version that was never activated to be editable")
+	}
+
+	version.Staging.Status = "Deactivated"
+	if version.editable() {
+		t.Error("expected a version that was activated not to be editable")
+	}
+}
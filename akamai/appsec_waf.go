@@ -0,0 +1,74 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+)
+
+// The WAF modes of a security policy. With KRS and ASE_MANUAL the Kona rule set
+// is upgraded manually, with ASE_AUTO the Adaptive Security Engine upgrades it.
+var appsecWAFModes = []string{"KRS", "AAG", "ASE_MANUAL", "ASE_AUTO"}
+
+type appsecWAFMode struct {
+	Mode    string `json:"mode"`
+	Current string `json:"current,omitempty"`
+}
+
+func appsecSecurityPolicyPath(configID int, version int, policyID string) string {
+	return fmt.Sprintf("/configs/%d/versions/%d/security-policies/%s", configID, version, policyID)
+}
+
+// getAppSecWAFMode returns the WAF mode of the security policy, or nil if it
+// does not exist
+//
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/mode
+func getAppSecWAFMode(configID int, version int, policyID string) (*appsecWAFMode, error) {
+	result := &appsecWAFMode{}
+	if err := appsecRequest("GET", appsecSecurityPolicyPath(configID, version, policyID)+"/mode", nil, result); err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// updateAppSecWAFMode sets the WAF mode of the security policy
+//
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/mode
+func updateAppSecWAFMode(configID int, version int, policyID string, mode string) error {
+	log.Printf("[DEBUG] Setting the WAF mode of security policy %s to %s\n", policyID, mode)
+
+	path := appsecSecurityPolicyPath(configID, version, policyID) + "/mode"
+	return appsecRequest("PUT", path, &appsecWAFMode{Mode: mode}, nil)
+}
+
+type appsecRuleUpgradeDetails struct {
+	Current string `json:"current"`
+	Latest  string `json:"latest"`
+}
+
+// getAppSecRuleUpgradeDetails returns the current and latest Kona rule set
+// versions of the security policy
+//
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/rules/upgrade-details
+func getAppSecRuleUpgradeDetails(configID int, version int, policyID string) (*appsecRuleUpgradeDetails, error) {
+	result := &appsecRuleUpgradeDetails{}
+	path := appsecSecurityPolicyPath(configID, version, policyID) + "/rules/upgrade-details"
+	if err := appsecRequest("GET", path, nil, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// upgradeAppSecRules upgrades the security policy to the latest Kona rule set
+//
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/rules/upgrade
+func upgradeAppSecRules(configID int, version int, policyID string) error {
+	log.Printf("[DEBUG] Upgrading the rules of security policy %s\n", policyID)
+
+	path := appsecSecurityPolicyPath(configID, version, policyID) + "/rules/upgrade"
+	return appsecRequest("PUT", path, map[string]interface{}{}, nil)
+}
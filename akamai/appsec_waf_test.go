@@ -0,0 +1,26 @@
+package akamai
+
+import "testing"
+
+func TestAppSecRuleUpgrade(t *testing.T) {
+	tests := []struct {
+		upgradeRules bool
+		mode         string
+		current      string
+		latest       string
+		expected     string
+	}{
+		{true, "KRS", "KRS 2019/03/01", "KRS 2019/07/01", "KRS 2019/07/01"},
+		{true, "ASE_MANUAL", "ASE 2019/03/01", "ASE 2019/07/01", "ASE 2019/07/01"},
+		{false, "KRS", "KRS 2019/03/01", "KRS 2019/07/01", ""},
+		{true, "ASE_AUTO", "ASE 2019/03/01", "ASE 2019/07/01", ""},
+		{true, "KRS", "KRS 2019/07/01", "KRS 2019/07/01", ""},
+		{true, "KRS", "KRS 2019/07/01", "", ""},
+	}
+
+	for _, test := range tests {
+		if upgrade := appsecRuleUpgrade(test.upgradeRules, test.mode, test.current, test.latest); upgrade != test.expected {
+			t.Errorf("%+v: expected %q, got %q", test, test.expected, upgrade)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+// Certificate Provisioning System
+//
+// The edgegrid client has no CPS package, so the CPS v2 endpoints are called
+// directly with the cps_section credentials. CPS versions each response with
+// its own media type, which must be accepted explicitly.
+//
+// https://developer.akamai.com/api/core_features/certificate_provisioning_system/v2.html
+
+var cpsConfig edgegrid.Config
+
+const (
+	cpsEnrollmentMediaType      = "application/vnd.akamai.cps.enrollment.v11+json"
+	cpsChangeMediaType          = "application/vnd.akamai.cps.change.v2+json"
+	cpsAcknowledgementMediaType = "application/vnd.akamai.cps.acknowledgement.v1+json"
+	cpsChangeIDMediaType        = "application/vnd.akamai.cps.change-id.v1+json"
+	cpsDVChallengesMediaType    = "application/vnd.akamai.cps.dv-challenges.v2+json"
+)
+
+// cpsPollInterval is how often CPS changes are polled
+var cpsPollInterval = 30 * time.Second
+
+// cpsRequest calls the CPS API with the media types of the request and
+// response, decoding the response into result if it is not nil. Errors are
+// client.APIError, see isNotFound.
+func cpsRequest(method string, path string, contentType string, accept string, body interface{}, result interface{}) error {
+	var req *http.Request
+	var err error
+	if body != nil {
+		req, err = client.NewJSONRequest(cpsConfig, method, path, body)
+	} else {
+		req, err = client.NewRequest(cpsConfig, method, path, nil)
+	}
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Accept", accept)
+
+	res, err := client.Do(cpsConfig, req)
+	if err != nil {
+		return err
+	}
+
+	if client.IsError(res) {
+		return client.NewAPIError(res)
+	}
+
+	if result == nil || res.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	return client.BodyJSON(res, result)
+}
+
+// The input a change with change management waits for after the staging deployment
+const cpsChangeManagementInput = "change-management-info"
+
+type cpsAllowedInput struct {
+	Type              string `json:"type"`
+	Info              string `json:"info"`
+	Update            string `json:"update"`
+	RequiredToProceed bool   `json:"requiredToProceed"`
+}
+
+type cpsChangeStatus struct {
+	AllowedInput []*cpsAllowedInput `json:"allowedInput"`
+	StatusInfo   struct {
+		Status      string `json:"status"`
+		State       string `json:"state"`
+		Description string `json:"description"`
+	} `json:"statusInfo"`
+}
+
+func (status *cpsChangeStatus) input(inputType string) *cpsAllowedInput {
+	for _, input := range status.AllowedInput {
+		if input.Type == inputType {
+			return input
+		}
+	}
+	return nil
+}
+
+func (status *cpsChangeStatus) complete() bool {
+	return status.StatusInfo.Status == "complete" || status.StatusInfo.State == "complete"
+}
+
+func (status *cpsChangeStatus) failed() bool {
+	return status.StatusInfo.State == "error"
+}
+
+// getCPSPendingChange returns the path of the enrollment's pending change, or
+// "" if there is none
+func getCPSPendingChange(enrollmentID int) (string, error) {
+	enrollment, err := getCPSEnrollment(enrollmentID)
+	if err != nil {
+		return "", err
+	}
+	if enrollment == nil {
+		return "", fmt.Errorf("enrollment %d not found", enrollmentID)
+	}
+
+	return enrollment.pendingChange(), nil
+}
+
+// getCPSChangeStatus returns the status of the change at the path
+//
+// Endpoint: GET /cps/v2/enrollments/{enrollmentId}/changes/{changeId}
+func getCPSChangeStatus(change string) (*cpsChangeStatus, error) {
+	result := &cpsChangeStatus{}
+	if err := cpsRequest("GET", change, "", cpsChangeMediaType, nil, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// acknowledgeCPSInput acknowledges the input a change is waiting for
+//
+// Endpoint: POST /cps/v2/enrollments/{enrollmentId}/changes/{changeId}/input/update/{allowedInputTypeParam}
+func acknowledgeCPSInput(input *cpsAllowedInput) error {
+	log.Printf("[DEBUG] Acknowledging %s\n", input.Update)
+	return cpsRequest("POST", input.Update, cpsAcknowledgementMediaType, cpsChangeIDMediaType, map[string]string{
+		"acknowledgement": "acknowledge",
+	}, nil)
+}
+
+// cpsChangeID returns the ID of the change at the path
+func cpsChangeID(change string) int {
+	id, _ := strconv.Atoi(change[strings.LastIndex(change, "/")+1:])
+	return id
+}
+
+type cpsDVChallenge struct {
+	Type             string `json:"type"`
+	Status           string `json:"status"`
+	FullPath         string `json:"fullPath"`
+	ResponseBody     string `json:"responseBody"`
+	RedirectFullPath string `json:"redirectFullPath"`
+}
+
+type cpsDomainValidation struct {
+	Domain           string            `json:"domain"`
+	ValidationStatus string            `json:"validationStatus"`
+	Challenges       []*cpsDVChallenge `json:"challenges"`
+}
+
+// getCPSDVChallenges returns the DV challenges of the enrollment's pending
+// change, or none if it isn't waiting for them
+//
+// Endpoint: GET /cps/v2/enrollments/{enrollmentId}/changes/{changeId}/input/info/lets-encrypt-challenges
+func getCPSDVChallenges(enrollmentID int) ([]*cpsDomainValidation, error) {
+	change, err := getCPSPendingChange(enrollmentID)
+	if err != nil || change == "" {
+		return nil, err
+	}
+
+	status, err := getCPSChangeStatus(change)
+	if err != nil {
+		return nil, err
+	}
+
+	input := status.input("lets-encrypt-challenges")
+	if input == nil {
+		return nil, nil
+	}
+
+	result := &struct {
+		DV []*cpsDomainValidation `json:"dv"`
+	}{}
+	if err := cpsRequest("GET", input.Info, "", cpsDVChallengesMediaType, nil, result); err != nil {
+		return nil, err
+	}
+
+	return result.DV, nil
+}
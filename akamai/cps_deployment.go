@@ -0,0 +1,60 @@
+package akamai
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+)
+
+const cpsDeploymentsMediaType = "application/vnd.akamai.cps.deployments.v3+json"
+
+type cpsDeployedCertificate struct {
+	Certificate  string `json:"certificate"`
+	TrustChain   string `json:"trustChain"`
+	KeyAlgorithm string `json:"keyAlgorithm"`
+}
+
+type cpsDeployment struct {
+	PrimaryCertificate       *cpsDeployedCertificate   `json:"primaryCertificate"`
+	MultiStackedCertificates []*cpsDeployedCertificate `json:"multiStackedCertificates"`
+}
+
+// certificates returns the deployed certificates, the primary one first
+func (deployment *cpsDeployment) certificates() []*cpsDeployedCertificate {
+	if deployment == nil || deployment.PrimaryCertificate == nil {
+		return nil
+	}
+
+	return append([]*cpsDeployedCertificate{deployment.PrimaryCertificate}, deployment.MultiStackedCertificates...)
+}
+
+type cpsDeployments struct {
+	Production *cpsDeployment `json:"production"`
+	Staging    *cpsDeployment `json:"staging"`
+}
+
+// getCPSDeployments returns the certificates deployed to staging and production
+//
+// Endpoint: GET /cps/v2/enrollments/{enrollmentId}/deployments
+func getCPSDeployments(enrollmentID int) (*cpsDeployments, error) {
+	result := &cpsDeployments{}
+	path := fmt.Sprintf("/cps/v2/enrollments/%d/deployments", enrollmentID)
+	if err := cpsRequest("GET", path, "", cpsDeploymentsMediaType, nil, result); err != nil {
+		if isNotFound(err) {
+			return result, nil
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// parseCPSCertificate parses the first PEM-encoded certificate
+func parseCPSCertificate(certificate string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(certificate))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("no PEM-encoded certificate found")
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
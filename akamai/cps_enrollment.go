@@ -0,0 +1,233 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	cpsEnrollmentStatusMediaType = "application/vnd.akamai.cps.enrollment-status.v1+json"
+	cpsCSRMediaType              = "application/vnd.akamai.cps.csr.v2+json"
+	cpsEnrollmentsMediaType      = "application/vnd.akamai.cps.enrollments.v11+json"
+)
+
+type cpsEnrollment struct {
+	ID                             int                      `json:"id,omitempty"`
+	Location                       string                   `json:"location,omitempty"`
+	RA                             string                   `json:"ra"`
+	ValidationType                 string                   `json:"validationType"`
+	CertificateType                string                   `json:"certificateType"`
+	ChangeManagement               bool                     `json:"changeManagement"`
+	EnableMultiStackedCertificates bool                     `json:"enableMultiStackedCertificates"`
+	SignatureAlgorithm             string                   `json:"signatureAlgorithm,omitempty"`
+	CSR                            *cpsCSR                  `json:"csr"`
+	NetworkConfiguration           *cpsNetworkConfiguration `json:"networkConfiguration"`
+	Org                            *cpsOrganization         `json:"org"`
+	AdminContact                   *cpsContact              `json:"adminContact"`
+	TechContact                    *cpsContact              `json:"techContact"`
+	PendingChanges                 []cpsPendingChange       `json:"pendingChanges,omitempty"`
+}
+
+type cpsPendingChange struct {
+	Location   string `json:"location"`
+	ChangeType string `json:"changeType"`
+}
+
+// pendingChange returns the path of the latest pending change, or "" if there
+// is none. Changes are processed in order, the latest is the one waiting for input.
+func (enrollment *cpsEnrollment) pendingChange() string {
+	if len(enrollment.PendingChanges) == 0 {
+		return ""
+	}
+	return enrollment.PendingChanges[len(enrollment.PendingChanges)-1].Location
+}
+
+type cpsCSR struct {
+	CN   string   `json:"cn"`
+	SANs []string `json:"sans"`
+	C    string   `json:"c,omitempty"`
+	ST   string   `json:"st,omitempty"`
+	L    string   `json:"l,omitempty"`
+	O    string   `json:"o,omitempty"`
+	OU   string   `json:"ou,omitempty"`
+}
+
+type cpsNetworkConfiguration struct {
+	Geography             string              `json:"geography"`
+	SecureNetwork         string              `json:"secureNetwork"`
+	SNIOnly               bool                `json:"sniOnly"`
+	DisallowedTLSVersions []string            `json:"disallowedTlsVersions"`
+	MustHaveCiphers       string              `json:"mustHaveCiphers,omitempty"`
+	PreferredCiphers      string              `json:"preferredCiphers,omitempty"`
+	OCSPStapling          string              `json:"ocspStapling,omitempty"`
+	QUICEnabled           bool                `json:"quicEnabled"`
+	DNSNameSettings       *cpsDNSNameSettings `json:"dnsNameSettings,omitempty"`
+}
+
+// cpsDNSNameSettings sets the hostnames the certificate is served for. With
+// CloneDNSNames, these are the SANs.
+type cpsDNSNameSettings struct {
+	CloneDNSNames bool     `json:"cloneDnsNames"`
+	DNSNames      []string `json:"dnsNames,omitempty"`
+}
+
+type cpsOrganization struct {
+	Name           string `json:"name"`
+	Phone          string `json:"phone"`
+	AddressLineOne string `json:"addressLineOne"`
+	AddressLineTwo string `json:"addressLineTwo,omitempty"`
+	City           string `json:"city"`
+	Region         string `json:"region"`
+	PostalCode     string `json:"postalCode"`
+	Country        string `json:"country"`
+}
+
+type cpsContact struct {
+	FirstName        string `json:"firstName"`
+	LastName         string `json:"lastName"`
+	Email            string `json:"email"`
+	Phone            string `json:"phone"`
+	Title            string `json:"title,omitempty"`
+	OrganizationName string `json:"organizationName,omitempty"`
+}
+
+// getCPSEnrollment returns the enrollment, or nil if it does not exist
+//
+// Endpoint: GET /cps/v2/enrollments/{enrollmentId}
+func getCPSEnrollment(enrollmentID int) (*cpsEnrollment, error) {
+	result := &cpsEnrollment{}
+	if err := cpsRequest("GET", fmt.Sprintf("/cps/v2/enrollments/%d", enrollmentID), "", cpsEnrollmentMediaType, nil, result); err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// enrollmentID returns the ID of an enrollment listed by getCPSEnrollments,
+// which is only part of its location
+func (enrollment *cpsEnrollment) enrollmentID() int {
+	if enrollment.ID != 0 {
+		return enrollment.ID
+	}
+
+	id, _ := strconv.Atoi(enrollment.Location[strings.LastIndex(enrollment.Location, "/")+1:])
+	return id
+}
+
+// getCPSEnrollments returns the enrollments of the contract
+//
+// Endpoint: GET /cps/v2/enrollments{?contractId}
+func getCPSEnrollments(contractID string) ([]*cpsEnrollment, error) {
+	result := &struct {
+		Enrollments []*cpsEnrollment `json:"enrollments"`
+	}{}
+	path := "/cps/v2/enrollments?contractId=" + strings.TrimPrefix(contractID, "ctr_")
+	if err := cpsRequest("GET", path, "", cpsEnrollmentsMediaType, nil, result); err != nil {
+		return nil, err
+	}
+
+	return result.Enrollments, nil
+}
+
+type cpsEnrollmentStatus struct {
+	Enrollment string   `json:"enrollment"`
+	Changes    []string `json:"changes"`
+}
+
+// createCPSEnrollment creates the enrollment and returns its ID
+//
+// Endpoint: POST /cps/v2/enrollments{?contractId}
+func createCPSEnrollment(enrollment *cpsEnrollment, contractID string) (int, error) {
+	log.Printf("[DEBUG] Creating %s enrollment for %s\n", enrollment.ValidationType, enrollment.CSR.CN)
+
+	result := &cpsEnrollmentStatus{}
+	path := "/cps/v2/enrollments?contractId=" + strings.TrimPrefix(contractID, "ctr_")
+	if err := cpsRequest("POST", path, cpsEnrollmentMediaType, cpsEnrollmentStatusMediaType, enrollment, result); err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(result.Enrollment[strings.LastIndex(result.Enrollment, "/")+1:])
+}
+
+// updateCPSEnrollment updates the enrollment, replacing any pending change
+//
+// Endpoint: PUT /cps/v2/enrollments/{enrollmentId}{?allow-cancel-pending-changes}
+func updateCPSEnrollment(enrollmentID int, enrollment *cpsEnrollment) error {
+	log.Printf("[DEBUG] Updating enrollment %d\n", enrollmentID)
+
+	path := fmt.Sprintf("/cps/v2/enrollments/%d?allow-cancel-pending-changes=true", enrollmentID)
+	return cpsRequest("PUT", path, cpsEnrollmentMediaType, cpsEnrollmentStatusMediaType, enrollment, nil)
+}
+
+// deleteCPSEnrollment deletes the enrollment, cancelling any pending change
+//
+// Endpoint: DELETE /cps/v2/enrollments/{enrollmentId}{?allow-cancel-pending-changes}
+func deleteCPSEnrollment(enrollmentID int) error {
+	log.Printf("[DEBUG] Deleting enrollment %d\n", enrollmentID)
+
+	path := fmt.Sprintf("/cps/v2/enrollments/%d?allow-cancel-pending-changes=true", enrollmentID)
+	err := cpsRequest("DELETE", path, "", cpsEnrollmentStatusMediaType, nil, nil)
+	if isNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+// getCPSThirdPartyCSRs returns the CSRs of the change keyed by key algorithm,
+// e.g. RSA, or nil if the change isn't waiting for the signed certificates
+//
+// Endpoint: GET /cps/v2/enrollments/{enrollmentId}/changes/{changeId}/input/info/third-party-csr
+func getCPSThirdPartyCSRs(status *cpsChangeStatus) (map[string]string, error) {
+	input := status.input("third-party-certificate")
+	if input == nil {
+		return nil, nil
+	}
+
+	result := &struct {
+		CSRs []struct {
+			CSR          string `json:"csr"`
+			KeyAlgorithm string `json:"keyAlgorithm"`
+		} `json:"csrs"`
+	}{}
+	if err := cpsRequest("GET", input.Info, "", cpsCSRMediaType, nil, result); err != nil {
+		return nil, err
+	}
+
+	csrs := make(map[string]string)
+	for _, csr := range result.CSRs {
+		csrs[csr.KeyAlgorithm] = csr.CSR
+	}
+	return csrs, nil
+}
+
+// waitForCPSThirdPartyCSRs waits for CPS to generate the CSRs of the change
+func waitForCPSThirdPartyCSRs(enrollmentID int, change string, timeout time.Duration) (map[string]string, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		status, err := getCPSChangeStatus(change)
+		if err != nil {
+			return nil, err
+		}
+		if status.failed() {
+			return nil, fmt.Errorf("enrollment %d change failed: %s", enrollmentID, status.StatusInfo.Description)
+		}
+
+		csrs, err := getCPSThirdPartyCSRs(status)
+		if err != nil || csrs != nil {
+			return csrs, err
+		}
+
+		log.Printf("[DEBUG] Waiting for the CSRs of enrollment %d (status: %s)\n", enrollmentID, status.StatusInfo.Status)
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timeout after %s waiting for the CSRs of enrollment %d (status: %s)", timeout, enrollmentID, status.StatusInfo.Status)
+		}
+		time.Sleep(cpsPollInterval)
+	}
+}
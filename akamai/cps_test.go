@@ -0,0 +1,37 @@
+package akamai
+
+import (
+	"testing"
+)
+
+func TestCPSChangeStatus(t *testing.T) {
+	status := &cpsChangeStatus{AllowedInput: []*cpsAllowedInput{
+		{Type: "lets-encrypt-challenges", Update: "/cps/v2/enrollments/10002/changes/10003/input/update/lets-encrypt-challenges-completed", RequiredToProceed: true},
+	}}
+	status.StatusInfo.Status = "wait-dv-challenges"
+	status.StatusInfo.State = "awaiting-input"
+
+	if input := status.input("lets-encrypt-challenges"); input == nil || input.Update == "" {
+		t.Error("expected the lets-encrypt-challenges input")
+	}
+	if status.input("third-party-csr") != nil {
+		t.Error("expected no third-party-csr input")
+	}
+	if input := cpsRequiredInput(status); input == nil || input.Type != "lets-encrypt-challenges" {
+		t.Error("expected lets-encrypt-challenges to be required")
+	}
+	if status.complete() || status.failed() {
+		t.Error("expected the change to be in progress")
+	}
+
+	status.StatusInfo.State = "error"
+	if !status.failed() {
+		t.Error("expected the change to have failed")
+	}
+}
+
+func TestCPSChangeID(t *testing.T) {
+	if id := cpsChangeID("/cps/v2/enrollments/10002/changes/10003"); id != 10003 {
+		t.Errorf("expected 10003, got %d", id)
+	}
+}
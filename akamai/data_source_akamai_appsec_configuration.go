@@ -0,0 +1,60 @@
+package akamai
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceAppSecConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAppSecConfigurationRead,
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"config_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"latest_version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"staging_version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"production_version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAppSecConfigurationRead(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+
+	configurations, err := getAppSecConfigurations()
+	if err != nil {
+		return err
+	}
+
+	for _, configuration := range configurations {
+		if configuration.Name != name {
+			continue
+		}
+
+		d.SetId(strconv.Itoa(configuration.ID))
+		d.Set("config_id", configuration.ID)
+		d.Set("latest_version", configuration.LatestVersion)
+		d.Set("staging_version", configuration.StagingVersion)
+		d.Set("production_version", configuration.ProductionVersion)
+		return nil
+	}
+
+	return fmt.Errorf("security configuration %q not found", name)
+}
@@ -0,0 +1,34 @@
+package akamai
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceAuthoritiesSet() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAuthoritiesSetRead,
+		Schema: map[string]*schema.Schema{
+			"contract_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"authorities": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceAuthoritiesSetRead(d *schema.ResourceData, meta interface{}) error {
+	contractID := d.Get("contract_id").(string)
+
+	authorities, err := getEdgeDNSAuthorities(contractID)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(edgeDNSContractID(contractID))
+	return d.Set("authorities", authorities)
+}
@@ -0,0 +1,91 @@
+package akamai
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceContract() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceContractRead,
+		Schema: map[string]*schema.Schema{
+			// The name or ID of a group, defaults to the only contract of the account
+			"group": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"contract_type_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceContractRead(d *schema.ResourceData, meta interface{}) error {
+	contracts := papi.NewContracts()
+	if err := contracts.GetContracts(); err != nil {
+		return err
+	}
+
+	var groups *papi.Groups
+	if d.Get("group").(string) != "" {
+		groups = papi.NewGroups()
+		if err := groups.GetGroups(); err != nil {
+			return err
+		}
+	}
+
+	contract, err := findContractForGroup(contracts, groups, d.Get("group").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(contract.ContractID)
+	d.Set("contract_type_name", contract.ContractTypeName)
+
+	return nil
+}
+
+// findContractForGroup returns the contract of the group with the given name or
+// ID, or the only contract if no group is given
+func findContractForGroup(contracts *papi.Contracts, groups *papi.Groups, group string) (*papi.Contract, error) {
+	var contractIDs []string
+	if group == "" {
+		for _, contract := range contracts.Contracts.Items {
+			contractIDs = append(contractIDs, contract.ContractID)
+		}
+		if len(contractIDs) != 1 {
+			return nil, fmt.Errorf("the credentials have access to %d contracts, set group to choose one: %s", len(contractIDs), strings.Join(contractIDs, ", "))
+		}
+	} else {
+		var found []*papi.Group
+		for _, g := range groups.Groups.Items {
+			if g.GroupName == group || samePAPIID(g.GroupID, group, "grp_") {
+				found = append(found, g)
+			}
+		}
+
+		switch {
+		case len(found) == 0:
+			return nil, fmt.Errorf("group %s not found", group)
+		case len(found) > 1:
+			return nil, fmt.Errorf("more than one group is named \"%s\", use its ID instead", group)
+		case len(found[0].ContractIDs) != 1:
+			return nil, fmt.Errorf("group %s belongs to %d contracts: %s", group, len(found[0].ContractIDs), strings.Join(found[0].ContractIDs, ", "))
+		}
+		contractIDs = found[0].ContractIDs
+	}
+
+	for _, contract := range contracts.Contracts.Items {
+		if samePAPIID(contract.ContractID, contractIDs[0], "ctr_") {
+			return contract, nil
+		}
+	}
+
+	// The group's contract may not be listed for the credentials
+	return &papi.Contract{ContractID: contractIDs[0]}, nil
+}
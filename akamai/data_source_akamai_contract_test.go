@@ -0,0 +1,39 @@
+package akamai
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+)
+
+func TestFindContractForGroup(t *testing.T) {
+	contracts := papi.NewContracts()
+	contracts.Contracts.Items = []*papi.Contract{
+		{ContractID: "ctr_1", ContractTypeName: "DIRECT_CUSTOMER"},
+		{ContractID: "ctr_2", ContractTypeName: "INDIRECT_CUSTOMER"},
+	}
+	groups := papi.NewGroups()
+	groups.Groups.Items = []*papi.Group{
+		{GroupID: "grp_1", GroupName: "Example", ContractIDs: []string{"ctr_1"}},
+		{GroupID: "grp_2", GroupName: "Shared", ContractIDs: []string{"ctr_1", "ctr_2"}},
+	}
+
+	if _, err := findContractForGroup(contracts, groups, ""); err == nil {
+		t.Fatal("expected an error without a group for more than one contract")
+	}
+	if _, err := findContractForGroup(contracts, groups, "Shared"); err == nil {
+		t.Fatal("expected an error for a group in more than one contract")
+	}
+
+	for _, group := range []string{"Example", "grp_1", "1"} {
+		contract, err := findContractForGroup(contracts, groups, group)
+		if err != nil || contract.ContractID != "ctr_1" || contract.ContractTypeName != "DIRECT_CUSTOMER" {
+			t.Fatalf("expected ctr_1 for group %q, got %v (%v)", group, contract, err)
+		}
+	}
+
+	contracts.Contracts.Items = contracts.Contracts.Items[1:]
+	if contract, err := findContractForGroup(contracts, nil, ""); err != nil || contract.ContractID != "ctr_2" {
+		t.Fatalf("expected the only contract ctr_2, got %v (%v)", contract, err)
+	}
+}
@@ -0,0 +1,88 @@
+package akamai
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceCPCode() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCPCodeRead,
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"contract_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// The numeric CP code, e.g. for the cpCode behavior
+			"cp_code": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"product_ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceCPCodeRead(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+	cpCodes := papi.NewCpCodes(
+		&papi.Contract{ContractID: d.Get("contract_id").(string)},
+		&papi.Group{GroupID: d.Get("group_id").(string)},
+	)
+	if err := cpCodes.GetCpCodes(); err != nil {
+		return err
+	}
+
+	cpCode, err := findCPCode(cpCodes, name)
+	if err != nil {
+		return err
+	}
+	if cpCode == nil {
+		return fmt.Errorf("CP code \"%s\" not found in contract %s, group %s", name, d.Get("contract_id").(string), d.Get("group_id").(string))
+	}
+
+	d.SetId(cpCode.CpcodeID)
+	d.Set("cp_code", cpCode.ID())
+	d.Set("product_ids", cpCode.ProductIDs)
+
+	return nil
+}
+
+// findCPCode returns the CP code with the given name, or nil if there is none.
+// Names are not unique, so more than one match is an error.
+func findCPCode(cpCodes *papi.CpCodes, name string) (*papi.CpCode, error) {
+	var found []*papi.CpCode
+	for _, cpCode := range cpCodes.CpCodes.Items {
+		if cpCode.CpcodeName == name {
+			found = append(found, cpCode)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, nil
+	case 1:
+		return found[0], nil
+	}
+
+	var ids []string
+	for _, cpCode := range found {
+		ids = append(ids, cpCode.CpcodeID)
+	}
+	return nil, fmt.Errorf("more than one CP code is named \"%s\": %s", name, strings.Join(ids, ", "))
+}
@@ -0,0 +1,30 @@
+package akamai
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+)
+
+func TestFindCPCode(t *testing.T) {
+	cpCodes := papi.NewCpCodes(nil, nil)
+	cpCodes.CpCodes.Items = []*papi.CpCode{
+		{CpcodeID: "cpc_1", CpcodeName: "www"},
+		{CpcodeID: "cpc_2", CpcodeName: "images"},
+		{CpcodeID: "cpc_3", CpcodeName: "images"},
+	}
+
+	cpCode, err := findCPCode(cpCodes, "www")
+	if err != nil || cpCode == nil || cpCode.CpcodeID != "cpc_1" {
+		t.Fatalf("expected cpc_1, got %+v, %v", cpCode, err)
+	}
+
+	cpCode, err = findCPCode(cpCodes, "video")
+	if err != nil || cpCode != nil {
+		t.Fatalf("expected no CP code, got %+v, %v", cpCode, err)
+	}
+
+	if _, err = findCPCode(cpCodes, "images"); err == nil {
+		t.Fatal("expected an error for an ambiguous name")
+	}
+}
@@ -0,0 +1,124 @@
+package akamai
+
+import (
+	"crypto/x509"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceCPSDeployments() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCPSDeploymentsRead,
+		Schema: map[string]*schema.Schema{
+			"enrollment_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"staging":    cpsDeployedCertificatesSchema(),
+			"production": cpsDeployedCertificatesSchema(),
+		},
+	}
+}
+
+func cpsDeployedCertificatesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"serial_number": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"common_name": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"sans": &schema.Schema{
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"key_algorithm": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"not_before": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"expiry": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"certificate": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCPSDeploymentsRead(d *schema.ResourceData, meta interface{}) error {
+	enrollmentID := d.Get("enrollment_id").(int)
+
+	deployments, err := getCPSDeployments(enrollmentID)
+	if err != nil {
+		return err
+	}
+
+	staging, err := flattenCPSDeployment(deployments.Staging)
+	if err != nil {
+		return fmt.Errorf("enrollment %d staging deployment: %s", enrollmentID, err)
+	}
+	production, err := flattenCPSDeployment(deployments.Production)
+	if err != nil {
+		return fmt.Errorf("enrollment %d production deployment: %s", enrollmentID, err)
+	}
+
+	d.SetId(strconv.Itoa(enrollmentID))
+	d.Set("staging", staging)
+	d.Set("production", production)
+	return nil
+}
+
+// flattenCPSDeployment returns the deployed certificates, the primary one first
+func flattenCPSDeployment(deployment *cpsDeployment) ([]interface{}, error) {
+	var flattened []interface{}
+	for _, deployed := range deployment.certificates() {
+		certificate, err := parseCPSCertificate(deployed.Certificate)
+		if err != nil {
+			return nil, err
+		}
+
+		keyAlgorithm := deployed.KeyAlgorithm
+		if keyAlgorithm == "" {
+			keyAlgorithm = cpsKeyAlgorithm(certificate.PublicKeyAlgorithm)
+		}
+
+		flattened = append(flattened, map[string]interface{}{
+			"serial_number": fmt.Sprintf("%x", certificate.SerialNumber),
+			"common_name":   certificate.Subject.CommonName,
+			"sans":          certificate.DNSNames,
+			"key_algorithm": keyAlgorithm,
+			"not_before":    certificate.NotBefore.UTC().Format(time.RFC3339),
+			"expiry":        certificate.NotAfter.UTC().Format(time.RFC3339),
+			"certificate":   deployed.Certificate,
+		})
+	}
+	return flattened, nil
+}
+
+func cpsKeyAlgorithm(algorithm x509.PublicKeyAlgorithm) string {
+	switch algorithm {
+	case x509.RSA:
+		return "RSA"
+	case x509.ECDSA:
+		return "ECDSA"
+	}
+	return ""
+}
@@ -0,0 +1,57 @@
+package akamai
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFlattenCPSDeployment(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(0xabc123),
+		Subject:      pkix.Name{CommonName: "www.example.com"},
+		DNSNames:     []string{"www.example.com", "static.example.com"},
+		NotBefore:    time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:     time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+
+	flattened, err := flattenCPSDeployment(&cpsDeployment{
+		PrimaryCertificate: &cpsDeployedCertificate{Certificate: certificate},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []interface{}{map[string]interface{}{
+		"serial_number": "abc123",
+		"common_name":   "www.example.com",
+		"sans":          []string{"www.example.com", "static.example.com"},
+		"key_algorithm": "ECDSA",
+		"not_before":    "2019-01-01T00:00:00Z",
+		"expiry":        "2020-01-01T00:00:00Z",
+		"certificate":   certificate,
+	}}
+	if !reflect.DeepEqual(flattened, expected) {
+		t.Errorf("expected %v, got %v", expected, flattened)
+	}
+
+	if flattened, err := flattenCPSDeployment(nil); err != nil || flattened != nil {
+		t.Errorf("expected no certificates, got %v, %v", flattened, err)
+	}
+}
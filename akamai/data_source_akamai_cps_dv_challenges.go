@@ -0,0 +1,121 @@
+package akamai
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceCPSDVChallenges() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCPSDVChallengesRead,
+		Schema: map[string]*schema.Schema{
+			"enrollment_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"dns_challenges": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"domain": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"record_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"record_type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"target": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"http_challenges": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"domain": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"url": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"body": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						// The URL may redirect here instead of serving the body
+						"redirect_url": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCPSDVChallengesRead(d *schema.ResourceData, meta interface{}) error {
+	enrollmentID := d.Get("enrollment_id").(int)
+
+	validations, err := getCPSDVChallenges(enrollmentID)
+	if err != nil {
+		return err
+	}
+
+	dnsChallenges, httpChallenges := flattenCPSDVChallenges(validations)
+
+	d.SetId(strconv.Itoa(enrollmentID))
+	d.Set("dns_challenges", dnsChallenges)
+	return d.Set("http_challenges", httpChallenges)
+}
+
+// flattenCPSDVChallenges returns the pending DNS and HTTP challenges, sorted by domain
+func flattenCPSDVChallenges(validations []*cpsDomainValidation) ([]interface{}, []interface{}) {
+	sort.Slice(validations, func(i, j int) bool {
+		return validations[i].Domain < validations[j].Domain
+	})
+
+	dnsChallenges := []interface{}{}
+	httpChallenges := []interface{}{}
+	for _, validation := range validations {
+		for _, challenge := range validation.Challenges {
+			if challenge.Status == "valid" {
+				continue
+			}
+
+			switch challenge.Type {
+			case "dns-01":
+				dnsChallenges = append(dnsChallenges, map[string]interface{}{
+					"domain":      validation.Domain,
+					"record_name": strings.TrimSuffix(challenge.FullPath, "."),
+					"record_type": "TXT",
+					"target":      challenge.ResponseBody,
+				})
+			case "http-01":
+				httpChallenges = append(httpChallenges, map[string]interface{}{
+					"domain":       validation.Domain,
+					"url":          challenge.FullPath,
+					"body":         challenge.ResponseBody,
+					"redirect_url": challenge.RedirectFullPath,
+				})
+			}
+		}
+	}
+
+	return dnsChallenges, httpChallenges
+}
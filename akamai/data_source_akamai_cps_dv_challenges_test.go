@@ -0,0 +1,38 @@
+package akamai
+
+import (
+	"testing"
+)
+
+func TestFlattenCPSDVChallenges(t *testing.T) {
+	validations := []*cpsDomainValidation{
+		{
+			Domain: "www.example.com",
+			Challenges: []*cpsDVChallenge{
+				{Type: "dns-01", Status: "pending", FullPath: "_acme-challenge.www.example.com.", ResponseBody: "dns-token"},
+				{Type: "http-01", Status: "pending", FullPath: "http://www.example.com/.well-known/acme-challenge/abc", ResponseBody: "http-token", RedirectFullPath: "http://dcv.akamai.com/.well-known/acme-challenge/abc"},
+			},
+		},
+		{
+			Domain: "api.example.com",
+			Challenges: []*cpsDVChallenge{
+				{Type: "dns-01", Status: "valid", FullPath: "_acme-challenge.api.example.com.", ResponseBody: "done"},
+			},
+		},
+	}
+
+	dnsChallenges, httpChallenges := flattenCPSDVChallenges(validations)
+	if len(dnsChallenges) != 1 || len(httpChallenges) != 1 {
+		t.Fatalf("expected 1 DNS and 1 HTTP challenge, got %v and %v", dnsChallenges, httpChallenges)
+	}
+
+	dns := dnsChallenges[0].(map[string]interface{})
+	if dns["record_name"] != "_acme-challenge.www.example.com" || dns["record_type"] != "TXT" || dns["target"] != "dns-token" {
+		t.Errorf("unexpected DNS challenge %v", dns)
+	}
+
+	http := httpChallenges[0].(map[string]interface{})
+	if http["domain"] != "www.example.com" || http["body"] != "http-token" || http["redirect_url"] == "" {
+		t.Errorf("unexpected HTTP challenge %v", http)
+	}
+}
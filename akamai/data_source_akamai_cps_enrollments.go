@@ -0,0 +1,127 @@
+package akamai
+
+import (
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceCPSEnrollments() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCPSEnrollmentsRead,
+		Schema: map[string]*schema.Schema{
+			"contract_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// Only list the enrollments whose certificate covers the hostname
+			"hostname": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"enrollments": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enrollment_id": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"common_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"sans": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"validation_type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						// active, or pending while the enrollment has a pending change
+						"status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						// The expiry of the production certificate, empty if not deployed
+						"expiry": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCPSEnrollmentsRead(d *schema.ResourceData, meta interface{}) error {
+	contractID := d.Get("contract_id").(string)
+	hostname := d.Get("hostname").(string)
+
+	enrollments, err := getCPSEnrollments(contractID)
+	if err != nil {
+		return err
+	}
+
+	var flattened []interface{}
+	for _, enrollment := range enrollments {
+		if enrollment.CSR == nil || (hostname != "" && !cpsCoversHostname(enrollment.CSR, hostname)) {
+			continue
+		}
+
+		enrollmentID := enrollment.enrollmentID()
+		expiry := ""
+		deployments, err := getCPSDeployments(enrollmentID)
+		if err != nil {
+			return err
+		}
+		if deployment := deployments.Production; deployment != nil && deployment.PrimaryCertificate != nil {
+			certificate, err := parseCPSCertificate(deployment.PrimaryCertificate.Certificate)
+			if err != nil {
+				return err
+			}
+			expiry = certificate.NotAfter.UTC().Format(time.RFC3339)
+		}
+
+		status := "active"
+		if enrollment.pendingChange() != "" {
+			status = "pending"
+		}
+
+		flattened = append(flattened, map[string]interface{}{
+			"enrollment_id":   enrollmentID,
+			"common_name":     enrollment.CSR.CN,
+			"sans":            enrollment.CSR.SANs,
+			"validation_type": enrollment.ValidationType,
+			"status":          status,
+			"expiry":          expiry,
+		})
+	}
+
+	d.SetId(contractID + ":" + hostname)
+	d.Set("enrollments", flattened)
+	return nil
+}
+
+// cpsCoversHostname returns whether the common name or a SAN of the CSR,
+// possibly a wildcard, covers the hostname
+func cpsCoversHostname(csr *cpsCSR, hostname string) bool {
+	hostname = strings.ToLower(strings.TrimSuffix(hostname, "."))
+	for _, name := range append([]string{csr.CN}, csr.SANs...) {
+		name = strings.ToLower(strings.TrimSuffix(name, "."))
+		if name == hostname {
+			return true
+		}
+		if strings.HasPrefix(name, "*.") {
+			if i := strings.Index(hostname, "."); i > 0 && hostname[i:] == name[1:] {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,20 @@
+package akamai
+
+import "testing"
+
+func TestCPSCoversHostname(t *testing.T) {
+	csr := &cpsCSR{CN: "www.example.com", SANs: []string{"www.example.com", "*.static.example.com"}}
+
+	for hostname, expected := range map[string]bool{
+		"www.example.com":          true,
+		"WWW.example.com.":         true,
+		"img.static.example.com":   true,
+		"static.example.com":       false,
+		"a.img.static.example.com": false,
+		"api.example.com":          false,
+	} {
+		if covered := cpsCoversHostname(csr, hostname); covered != expected {
+			t.Errorf("%s: expected %t, got %t", hostname, expected, covered)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package akamai
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceCPSPendingChange() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCPSPendingChangeRead,
+		Schema: map[string]*schema.Schema{
+			"enrollment_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"change_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"change_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			// Whether the change waits for its staging deployment to be acknowledged
+			"awaiting_acknowledgement": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"allowed_inputs": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"required_to_proceed": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCPSPendingChangeRead(d *schema.ResourceData, meta interface{}) error {
+	enrollmentID := d.Get("enrollment_id").(int)
+
+	enrollment, err := getCPSEnrollment(enrollmentID)
+	if err != nil {
+		return err
+	}
+	if enrollment == nil {
+		return fmt.Errorf("enrollment %d not found", enrollmentID)
+	}
+
+	d.SetId(strconv.Itoa(enrollmentID))
+	change := enrollment.pendingChange()
+	if change == "" {
+		d.Set("change_id", 0)
+		d.Set("change_type", "")
+		d.Set("status", "")
+		d.Set("state", "")
+		d.Set("description", "")
+		d.Set("awaiting_acknowledgement", false)
+		d.Set("allowed_inputs", nil)
+		return nil
+	}
+
+	status, err := getCPSChangeStatus(change)
+	if err != nil {
+		return err
+	}
+
+	var inputs []interface{}
+	for _, input := range status.AllowedInput {
+		inputs = append(inputs, map[string]interface{}{
+			"type":                input.Type,
+			"required_to_proceed": input.RequiredToProceed,
+		})
+	}
+
+	d.Set("change_id", cpsChangeID(change))
+	d.Set("change_type", enrollment.PendingChanges[len(enrollment.PendingChanges)-1].ChangeType)
+	d.Set("status", status.StatusInfo.Status)
+	d.Set("state", status.StatusInfo.State)
+	d.Set("description", status.StatusInfo.Description)
+	d.Set("awaiting_acknowledgement", status.input(cpsChangeManagementInput) != nil)
+	d.Set("allowed_inputs", inputs)
+	return nil
+}
@@ -0,0 +1,117 @@
+package akamai
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceCustomBehavior() *schema.Resource {
+	return dataSourceCustomExtension(customBehaviorKind, "behavior_id", "custom_behaviors")
+}
+
+// dataSourceCustomExtension looks up a custom behavior or override by ID or name,
+// and lists all of them. Without an ID or name only the list is read.
+func dataSourceCustomExtension(kind customExtensionKind, idKey string, listKey string) *schema.Resource {
+	return &schema.Resource{
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			return dataSourceCustomExtensionRead(d, kind, idKey, listKey)
+		},
+		Schema: map[string]*schema.Schema{
+			idKey: &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"name"},
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"display_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"xml": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"updated_date": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			listKey: &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						idKey: &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"display_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCustomExtensionRead(d *schema.ResourceData, kind customExtensionKind, idKey string, listKey string) error {
+	extensions, err := getCustomExtensions(kind, "")
+	if err != nil {
+		return err
+	}
+
+	var items []interface{}
+	for _, extension := range extensions {
+		items = append(items, map[string]interface{}{
+			idKey:          extension.ID(),
+			"name":         extension.Name,
+			"display_name": extension.DisplayName,
+			"status":       extension.Status,
+		})
+	}
+	d.Set(listKey, items)
+
+	id := d.Get(idKey).(string)
+	name := d.Get("name").(string)
+	if id == "" && name == "" {
+		d.SetId(listKey)
+		return nil
+	}
+
+	extension, err := getCustomExtension(kind, id, name)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(extension.ID())
+	d.Set(idKey, extension.ID())
+	d.Set("name", extension.Name)
+	d.Set("display_name", extension.DisplayName)
+	d.Set("status", extension.Status)
+	d.Set("description", extension.Description)
+	d.Set("xml", extension.XML)
+	d.Set("updated_date", extension.UpdatedDate)
+
+	return nil
+}
@@ -0,0 +1,9 @@
+package akamai
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceCustomOverride() *schema.Resource {
+	return dataSourceCustomExtension(customOverrideKind, "override_id", "custom_overrides")
+}
@@ -0,0 +1,55 @@
+package akamai
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceDNSRecordSet() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDNSRecordSetRead,
+		Schema: map[string]*schema.Schema{
+			"zone": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"record_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"ttl": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"rdata": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceDNSRecordSetRead(d *schema.ResourceData, meta interface{}) error {
+	zone := d.Get("zone").(string)
+	name := strings.TrimSuffix(d.Get("name").(string), ".")
+	recordType := strings.ToUpper(d.Get("record_type").(string))
+
+	recordSet, err := getEdgeDNSRecordSet(zone, name, recordType)
+	if err != nil {
+		return err
+	}
+	if recordSet == nil {
+		return fmt.Errorf("%s record set %s not found in zone %s", recordType, name, zone)
+	}
+
+	d.SetId(strings.Join([]string{zone, recordSet.Name, recordSet.Type}, "/"))
+	d.Set("ttl", recordSet.TTL)
+	return d.Set("rdata", recordSet.Rdata)
+}
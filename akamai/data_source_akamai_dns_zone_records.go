@@ -0,0 +1,199 @@
+package akamai
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// akamai_dns_zone_records lists the record sets in a zone, along with the
+// akamai_dns_record configuration and import commands to adopt them
+func dataSourceDNSZoneRecords() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDNSZoneRecordsRead,
+		Schema: map[string]*schema.Schema{
+			"zone": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"record_types": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"record_sets": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"record_type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ttl": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"target": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						// Empty for record types akamai_dns_record doesn't support, e.g. SOA
+						"import_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"import_commands": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// akamai_dns_record resources for the record sets, to write to a .tf file
+			"config": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceDNSZoneRecordsRead(d *schema.ResourceData, meta interface{}) error {
+	zone := d.Get("zone").(string)
+	var recordTypes []string
+	for _, recordType := range d.Get("record_types").(*schema.Set).List() {
+		recordTypes = append(recordTypes, strings.ToUpper(recordType.(string)))
+	}
+
+	recordSets, err := getEdgeDNSRecordSets(zone)
+	if err != nil {
+		return err
+	}
+	recordSets = filterDNSRecordSets(recordSets, recordTypes)
+
+	var items []interface{}
+	var commands []string
+	var config []string
+	for _, recordSet := range recordSets {
+		importID := ""
+		if dnsRecordTypeSupported(recordSet.Type) {
+			importID = strings.Join([]string{zone, recordSet.Name, recordSet.Type}, "/")
+			commands = append(commands, fmt.Sprintf("terraform import akamai_dns_record.%s %s", dnsRecordResourceName(recordSet), importID))
+
+			recordConfig, err := dnsRecordConfig(zone, recordSet)
+			if err != nil {
+				return err
+			}
+			config = append(config, recordConfig)
+		}
+
+		items = append(items, map[string]interface{}{
+			"name":        recordSet.Name,
+			"record_type": recordSet.Type,
+			"ttl":         recordSet.TTL,
+			"target":      recordSet.Rdata,
+			"import_id":   importID,
+		})
+	}
+
+	sort.Strings(recordTypes)
+	d.SetId(fmt.Sprintf("%d", hashcode.String(zone+":"+strings.Join(recordTypes, ","))))
+	d.Set("import_commands", commands)
+	d.Set("config", strings.Join(config, "\n"))
+	return d.Set("record_sets", items)
+}
+
+// filterDNSRecordSets returns the record sets of the types, or all if there are
+// none, sorted by name and type
+func filterDNSRecordSets(recordSets []*edgeDNSRecordSet, recordTypes []string) []*edgeDNSRecordSet {
+	var found []*edgeDNSRecordSet
+	for _, recordSet := range recordSets {
+		if len(recordTypes) == 0 || stringInSlice(recordSet.Type, recordTypes) {
+			found = append(found, recordSet)
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].Name != found[j].Name {
+			return found[i].Name < found[j].Name
+		}
+		return found[i].Type < found[j].Type
+	})
+
+	return found
+}
+
+func dnsRecordTypeSupported(recordType string) bool {
+	return stringInSlice(recordType, dnsRecordTypes)
+}
+
+var dnsResourceNameInvalid = regexp.MustCompile("[^a-z0-9_-]+")
+
+// dnsRecordResourceName returns a resource name for the record set, e.g.
+// www_example_com_cname for the CNAME record set of www.example.com
+func dnsRecordResourceName(recordSet *edgeDNSRecordSet) string {
+	name := strings.ToLower(strings.TrimSuffix(recordSet.Name, "."))
+	name = strings.Replace(name, "*", "wildcard", -1)
+	name = dnsResourceNameInvalid.ReplaceAllString(name, "_")
+	return name + "_" + strings.ToLower(recordSet.Type)
+}
+
+// dnsRecordConfig returns the akamai_dns_record resource for the record set
+func dnsRecordConfig(zone string, recordSet *edgeDNSRecordSet) (string, error) {
+	lines := []string{
+		fmt.Sprintf("resource \"akamai_dns_record\" %q {", dnsRecordResourceName(recordSet)),
+		fmt.Sprintf("  zone        = %s", hclString(zone)),
+		fmt.Sprintf("  name        = %s", hclString(recordSet.Name)),
+		fmt.Sprintf("  record_type = %s", hclString(recordSet.Type)),
+		fmt.Sprintf("  ttl         = %d", recordSet.TTL),
+	}
+
+	fields, extended := dnsExtendedRecordFields[recordSet.Type]
+	if !extended {
+		lines = append(lines, "  target      = [")
+		for _, rdata := range recordSet.Rdata {
+			lines = append(lines, "    "+hclString(rdata)+",")
+		}
+		lines = append(lines, "  ]", "}", "")
+		return strings.Join(lines, "\n"), nil
+	}
+
+	for _, rdata := range recordSet.Rdata {
+		values, err := parseDNSRdata(recordSet.Type, rdata)
+		if err != nil {
+			return "", err
+		}
+
+		lines = append(lines, "", fmt.Sprintf("  %s {", dnsRecordBlock(recordSet.Type)))
+		for _, field := range fields {
+			switch value := values[field.name].(type) {
+			case int:
+				lines = append(lines, fmt.Sprintf("    %s = %d", field.name, value))
+			case string:
+				if value != "" {
+					lines = append(lines, fmt.Sprintf("    %s = %s", field.name, hclString(value)))
+				}
+			}
+		}
+		lines = append(lines, "  }")
+	}
+
+	return strings.Join(append(lines, "}", ""), "\n"), nil
+}
+
+// hclString quotes the value as an HCL string, escaping interpolation
+func hclString(value string) string {
+	return strings.Replace(strconv.Quote(value), "${", "$${", -1)
+}
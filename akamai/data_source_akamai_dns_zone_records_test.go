@@ -0,0 +1,81 @@
+package akamai
+
+import (
+	"testing"
+)
+
+func TestFilterDNSRecordSets(t *testing.T) {
+	recordSets := []*edgeDNSRecordSet{
+		{Name: "www.example.com", Type: "CNAME"},
+		{Name: "example.com", Type: "SOA"},
+		{Name: "example.com", Type: "NS"},
+	}
+
+	all := filterDNSRecordSets(recordSets, nil)
+	if len(all) != 3 || all[0].Type != "NS" || all[1].Type != "SOA" || all[2].Type != "CNAME" {
+		t.Errorf("expected all record sets sorted by name and type, got %v", all)
+	}
+
+	found := filterDNSRecordSets(recordSets, []string{"CNAME"})
+	if len(found) != 1 || found[0].Name != "www.example.com" {
+		t.Errorf("expected only the CNAME record set, got %v", found)
+	}
+}
+
+func TestDNSRecordResourceName(t *testing.T) {
+	tests := map[string]*edgeDNSRecordSet{
+		"www_example_com_cname":          {Name: "www.example.com", Type: "CNAME"},
+		"wildcard_example_com_a":         {Name: "*.example.com", Type: "A"},
+		"_sip__tcp_example_com_srv":      {Name: "_sip._tcp.example.com", Type: "SRV"},
+		"mail-1_example_com_aaaa":        {Name: "Mail-1.example.com.", Type: "AAAA"},
+		"_443__tcp_www_example_com_tlsa": {Name: "_443._tcp.www.example.com", Type: "TLSA"},
+	}
+
+	for expected, recordSet := range tests {
+		if name := dnsRecordResourceName(recordSet); name != expected {
+			t.Errorf("expected %q for %s, got %q", expected, recordSet.Name, name)
+		}
+	}
+}
+
+func TestDNSRecordConfig(t *testing.T) {
+	config, err := dnsRecordConfig("example.com", &edgeDNSRecordSet{Name: "example.com", Type: "TXT", TTL: 300, Rdata: []string{`"v=spf1 -all"`}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `resource "akamai_dns_record" "example_com_txt" {
+  zone        = "example.com"
+  name        = "example.com"
+  record_type = "TXT"
+  ttl         = 300
+  target      = [
+    "\"v=spf1 -all\"",
+  ]
+}
+`
+	if config != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, config)
+	}
+
+	config, err = dnsRecordConfig("example.com", &edgeDNSRecordSet{Name: "example.com", Type: "HINFO", TTL: 300, Rdata: []string{`"${x}" "Linux"`}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected = `resource "akamai_dns_record" "example_com_hinfo" {
+  zone        = "example.com"
+  name        = "example.com"
+  record_type = "HINFO"
+  ttl         = 300
+
+  hinfo {
+    hardware = "$${x}"
+    software = "Linux"
+  }
+}
+`
+	if config != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, config)
+	}
+}
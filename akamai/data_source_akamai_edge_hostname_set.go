@@ -0,0 +1,271 @@
+package akamai
+
+import (
+	"encoding/csv"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// Edge hostname sets
+//
+// akamai_edge_hostname_set validates a list of hostnames to onboard, given as
+// hostname blocks or as CSV, and returns them in a canonical, sorted form
+// along with the edge hostname domain each one maps to. Nothing is created;
+// the output is meant to be passed on to the edge hostname resources.
+
+var edgeHostnameDomainSuffixes = []string{"edgesuite.net", "edgekey.net", "akamaized.net"}
+
+var edgeHostnameIPBehaviors = []string{"IPV4", "IPV6_COMPLIANCE", "IPV6_PERFORMANCE"}
+
+var edgeHostnameRegexp = regexp.MustCompile(`^(\*\.)?([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+
+func dataSourceEdgeHostnameSet() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceEdgeHostnameSetRead,
+		Schema: map[string]*schema.Schema{
+			// hostname,domain_suffix,ip_behavior,certificate rows, the header row is optional
+			"csv": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"hostname": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"domain_suffix": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "edgesuite.net",
+							ValidateFunc: validation.StringInSlice(edgeHostnameDomainSuffixes, false),
+						},
+						"ip_behavior": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "IPV4",
+							ValidateFunc: validation.StringInSlice(edgeHostnameIPBehaviors, false),
+						},
+						// CPS enrollment ID, edgekey.net only
+						"certificate": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"hostnames": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// Keyed like akamai_property's edge_hostname
+			"edge_hostnames": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"entries": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"domain_suffix": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ip_behavior": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"certificate": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"edge_hostname": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type edgeHostnameEntry struct {
+	Name         string
+	DomainSuffix string
+	IPBehavior   string
+	Certificate  int
+}
+
+// EdgeHostname returns the edge hostname domain for the entry, see createEdgehostname
+func (e edgeHostnameEntry) EdgeHostname() string {
+	return strings.TrimSuffix(e.Name, "."+e.DomainSuffix) + "." + e.DomainSuffix
+}
+
+func dataSourceEdgeHostnameSetRead(d *schema.ResourceData, meta interface{}) error {
+	entries, err := parseEdgeHostnamesCSV(d.Get("csv").(string))
+	if err != nil {
+		return err
+	}
+
+	for _, h := range d.Get("hostname").([]interface{}) {
+		hostname := h.(map[string]interface{})
+		entries = append(entries, edgeHostnameEntry{
+			Name:         hostname["name"].(string),
+			DomainSuffix: hostname["domain_suffix"].(string),
+			IPBehavior:   hostname["ip_behavior"].(string),
+			Certificate:  hostname["certificate"].(int),
+		})
+	}
+
+	entries, err = canonicalEdgeHostnames(entries)
+	if err != nil {
+		return err
+	}
+
+	hostnames := make([]interface{}, 0, len(entries))
+	edgeHostnames := make(map[string]interface{})
+	flattened := make([]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		hostnames = append(hostnames, entry.Name)
+		edgeHostnames[strings.Replace(entry.Name, ".", "-", -1)] = entry.EdgeHostname()
+		flattened = append(flattened, map[string]interface{}{
+			"name":          entry.Name,
+			"domain_suffix": entry.DomainSuffix,
+			"ip_behavior":   entry.IPBehavior,
+			"certificate":   entry.Certificate,
+			"edge_hostname": entry.EdgeHostname(),
+		})
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name)
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(names, ","))))
+	d.Set("hostnames", hostnames)
+	d.Set("edge_hostnames", edgeHostnames)
+	d.Set("entries", flattened)
+
+	return nil
+}
+
+// parseEdgeHostnamesCSV parses hostname,domain_suffix,ip_behavior,certificate rows,
+// where all but the hostname may be left empty for the defaults
+func parseEdgeHostnamesCSV(input string) ([]edgeHostnameEntry, error) {
+	reader := csv.NewReader(strings.NewReader(input))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("csv: %s", err)
+	}
+
+	var entries []edgeHostnameEntry
+	for i, record := range records {
+		if i == 0 && strings.TrimSpace(record[0]) == "hostname" {
+			continue
+		}
+		if len(record) > 4 {
+			return nil, fmt.Errorf("csv line %d: expected at most 4 fields, got %d", i+1, len(record))
+		}
+
+		fields := make([]string, 4)
+		for j, field := range record {
+			fields[j] = strings.TrimSpace(field)
+		}
+
+		entry := edgeHostnameEntry{
+			Name:         fields[0],
+			DomainSuffix: fields[1],
+			IPBehavior:   fields[2],
+		}
+		if entry.DomainSuffix == "" {
+			entry.DomainSuffix = "edgesuite.net"
+		}
+		if entry.IPBehavior == "" {
+			entry.IPBehavior = "IPV4"
+		}
+		if fields[3] != "" {
+			entry.Certificate, err = strconv.Atoi(fields[3])
+			if err != nil {
+				return nil, fmt.Errorf("csv line %d: certificate must be an enrollment ID, got \"%s\"", i+1, fields[3])
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// canonicalEdgeHostnames validates the entries and returns them lowercased and sorted by hostname
+func canonicalEdgeHostnames(entries []edgeHostnameEntry) ([]edgeHostnameEntry, error) {
+	var errs []string
+	seen := make(map[string]bool)
+	canonical := make([]edgeHostnameEntry, 0, len(entries))
+	for _, entry := range entries {
+		entry.Name = strings.TrimSuffix(strings.ToLower(entry.Name), ".")
+		entry.IPBehavior = strings.ToUpper(entry.IPBehavior)
+
+		if !edgeHostnameRegexp.MatchString(entry.Name) {
+			errs = append(errs, fmt.Sprintf("\"%s\" is not a valid hostname", entry.Name))
+			continue
+		}
+		if seen[entry.Name] {
+			errs = append(errs, fmt.Sprintf("\"%s\" is listed more than once", entry.Name))
+			continue
+		}
+		seen[entry.Name] = true
+
+		if !stringInSlice(entry.DomainSuffix, edgeHostnameDomainSuffixes) {
+			errs = append(errs, fmt.Sprintf("\"%s\": domain suffix must be one of %s", entry.Name, strings.Join(edgeHostnameDomainSuffixes, ", ")))
+		}
+		if !stringInSlice(entry.IPBehavior, edgeHostnameIPBehaviors) {
+			errs = append(errs, fmt.Sprintf("\"%s\": ip behavior must be one of %s", entry.Name, strings.Join(edgeHostnameIPBehaviors, ", ")))
+		}
+		if entry.Certificate != 0 && entry.DomainSuffix != "edgekey.net" {
+			errs = append(errs, fmt.Sprintf("\"%s\": a certificate can only be used with edgekey.net", entry.Name))
+		}
+
+		canonical = append(canonical, entry)
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid edge hostnames:\n\t%s", strings.Join(errs, "\n\t"))
+	}
+
+	sort.Slice(canonical, func(i, j int) bool { return canonical[i].Name < canonical[j].Name })
+
+	return canonical, nil
+}
+
+func stringInSlice(value string, values []string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
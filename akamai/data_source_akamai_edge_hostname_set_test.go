@@ -0,0 +1,51 @@
+package akamai
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseEdgeHostnamesCSV(t *testing.T) {
+	input := `hostname,domain_suffix,ip_behavior,certificate
+www.Example.com,edgekey.net,ipv6_compliance,1234
+# images
+images.example.com
+`
+	entries, err := parseEdgeHostnamesCSV(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err = canonicalEdgeHostnames(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Name != "images.example.com" || entries[0].EdgeHostname() != "images.example.com.edgesuite.net" || entries[0].IPBehavior != "IPV4" {
+		t.Fatalf("unexpected entry %+v", entries[0])
+	}
+	if entries[1].Name != "www.example.com" || entries[1].EdgeHostname() != "www.example.com.edgekey.net" || entries[1].IPBehavior != "IPV6_COMPLIANCE" || entries[1].Certificate != 1234 {
+		t.Fatalf("unexpected entry %+v", entries[1])
+	}
+}
+
+func TestCanonicalEdgeHostnamesErrors(t *testing.T) {
+	_, err := canonicalEdgeHostnames([]edgeHostnameEntry{
+		{Name: "example.com", DomainSuffix: "edgesuite.net", IPBehavior: "IPV4"},
+		{Name: "EXAMPLE.com", DomainSuffix: "edgesuite.net", IPBehavior: "IPV4"},
+		{Name: "not a hostname", DomainSuffix: "edgesuite.net", IPBehavior: "IPV4"},
+		{Name: "cert.example.com", DomainSuffix: "edgesuite.net", IPBehavior: "IPV4", Certificate: 1},
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	for _, expected := range []string{"listed more than once", "not a valid hostname", "only be used with edgekey.net"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error to contain %q, got %s", expected, err)
+		}
+	}
+}
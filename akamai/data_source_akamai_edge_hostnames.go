@@ -0,0 +1,147 @@
+package akamai
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// Edge hostnames
+//
+// akamai_edge_hostnames lists the existing edge hostnames of a contract and
+// group, so properties can map their hostnames to centrally managed ones.
+
+func dataSourceEdgeHostnames() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceEdgeHostnamesRead,
+		Schema: map[string]*schema.Schema{
+			"contract_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"domain_suffix": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(edgeHostnameDomainSuffixes, false),
+			},
+			"product_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"edge_hostnames": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"edge_hostname": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"domain_prefix": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"domain_suffix": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"product_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ip_behavior": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"secure": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						// Enhanced TLS (edgekey.net) only
+						"certificate_status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceEdgeHostnamesRead(d *schema.ResourceData, meta interface{}) error {
+	contractID := d.Get("contract_id").(string)
+	groupID := d.Get("group_id").(string)
+	domainSuffix := d.Get("domain_suffix").(string)
+	productID := d.Get("product_id").(string)
+
+	edgeHostnames := papi.NewEdgeHostnames()
+	err := edgeHostnames.GetEdgeHostnames(&papi.Contract{ContractID: contractID}, &papi.Group{GroupID: groupID}, "")
+	if err != nil {
+		return err
+	}
+
+	found := filterEdgeHostnames(edgeHostnames.EdgeHostnames.Items, domainSuffix, productID)
+
+	var items []interface{}
+	for _, edgeHostname := range found {
+		item := map[string]interface{}{
+			"id":            edgeHostname.EdgeHostnameID,
+			"edge_hostname": edgeHostname.EdgeHostnameDomain,
+			"domain_prefix": edgeHostname.DomainPrefix,
+			"domain_suffix": edgeHostname.DomainSuffix,
+			"product_id":    edgeHostname.ProductID,
+			"ip_behavior":   edgeHostname.IPVersionBehavior,
+			"secure":        edgeHostname.Secure || edgeHostname.DomainSuffix == "edgekey.net",
+		}
+
+		if edgeHostname.DomainSuffix == "edgekey.net" {
+			certificate, err := getEdgeHostnameCertificate(edgeHostname.DomainPrefix, edgeHostname.DomainSuffix)
+			if err != nil {
+				return err
+			}
+			if certificate != nil {
+				item["certificate_status"] = certificate.Status
+			}
+		}
+
+		items = append(items, item)
+	}
+
+	d.SetId(fmt.Sprintf("%d", hashcode.String(strings.Join([]string{contractID, groupID, domainSuffix, productID}, ","))))
+	return d.Set("edge_hostnames", items)
+}
+
+// filterEdgeHostnames returns the edge hostnames matching the domain suffix and
+// product, if given, sorted by domain
+func filterEdgeHostnames(edgeHostnames []*papi.EdgeHostname, domainSuffix string, productID string) []*papi.EdgeHostname {
+	var found []*papi.EdgeHostname
+	for _, edgeHostname := range edgeHostnames {
+		if domainSuffix != "" && edgeHostname.DomainSuffix != domainSuffix {
+			continue
+		}
+		if productID != "" && !samePAPIID(edgeHostname.ProductID, productID, "prd_") {
+			continue
+		}
+		found = append(found, edgeHostname)
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].EdgeHostnameDomain < found[j].EdgeHostnameDomain
+	})
+
+	return found
+}
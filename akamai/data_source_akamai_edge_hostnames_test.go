@@ -0,0 +1,24 @@
+package akamai
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+)
+
+func TestFilterEdgeHostnames(t *testing.T) {
+	edgeHostnames := []*papi.EdgeHostname{
+		{EdgeHostnameDomain: "www.example.com.edgesuite.net", DomainSuffix: "edgesuite.net", ProductID: "prd_SPM"},
+		{EdgeHostnameDomain: "api.example.com.edgekey.net", DomainSuffix: "edgekey.net", ProductID: "prd_SPM"},
+		{EdgeHostnameDomain: "images.example.com.edgesuite.net", DomainSuffix: "edgesuite.net", ProductID: "prd_Download_Delivery"},
+	}
+
+	if found := filterEdgeHostnames(edgeHostnames, "", ""); len(found) != 3 || found[0].EdgeHostnameDomain != "api.example.com.edgekey.net" {
+		t.Fatalf("expected all edge hostnames sorted by domain, got %v", found)
+	}
+
+	found := filterEdgeHostnames(edgeHostnames, "edgesuite.net", "SPM")
+	if len(found) != 1 || found[0].EdgeHostnameDomain != "www.example.com.edgesuite.net" {
+		t.Fatalf("expected www.example.com.edgesuite.net, got %v", found)
+	}
+}
@@ -0,0 +1,110 @@
+package akamai
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceGroup() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGroupRead,
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// The name or ID of the parent group, for names used in more than one place
+			"parent": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"contract_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"parent_group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"contract_ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceGroupRead(d *schema.ResourceData, meta interface{}) error {
+	groups := papi.NewGroups()
+	if err := groups.GetGroups(); err != nil {
+		return err
+	}
+
+	group, err := findGroupByName(groups, d.Get("name").(string), d.Get("parent").(string), d.Get("contract_id").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(group.GroupID)
+	d.Set("parent_group_id", group.ParentGroupID)
+	d.Set("contract_ids", group.ContractIDs)
+
+	return nil
+}
+
+// findGroupByName returns the group with the given name, optionally under the
+// parent group with the given name or ID and in the given contract
+func findGroupByName(groups *papi.Groups, name string, parent string, contractID string) (*papi.Group, error) {
+	var parentIDs []string
+	if parent != "" {
+		for _, group := range groups.Groups.Items {
+			if group.GroupName == parent || samePAPIID(group.GroupID, parent, "grp_") {
+				parentIDs = append(parentIDs, group.GroupID)
+			}
+		}
+		if len(parentIDs) == 0 {
+			return nil, fmt.Errorf("parent group %s not found", parent)
+		}
+	}
+
+	var found []*papi.Group
+	for _, group := range groups.Groups.Items {
+		if group.GroupName != name {
+			continue
+		}
+		if parent != "" && !stringInSlice(group.ParentGroupID, parentIDs) {
+			continue
+		}
+		if contractID != "" && !groupInContract(group, contractID) {
+			continue
+		}
+		found = append(found, group)
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("group \"%s\" not found", name)
+	case 1:
+		return found[0], nil
+	}
+
+	var ids []string
+	for _, group := range found {
+		ids = append(ids, group.GroupID)
+	}
+	return nil, fmt.Errorf("more than one group is named \"%s\": %s; set parent or contract_id", name, strings.Join(ids, ", "))
+}
+
+func groupInContract(group *papi.Group, contractID string) bool {
+	for _, id := range group.ContractIDs {
+		if samePAPIID(id, contractID, "ctr_") {
+			return true
+		}
+	}
+
+	return false
+}
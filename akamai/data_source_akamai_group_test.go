@@ -0,0 +1,32 @@
+package akamai
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+)
+
+func TestFindGroupByName(t *testing.T) {
+	groups := papi.NewGroups()
+	groups.Groups.Items = []*papi.Group{
+		{GroupID: "grp_1", GroupName: "Example", ContractIDs: []string{"ctr_1"}},
+		{GroupID: "grp_2", GroupName: "Web", ParentGroupID: "grp_1", ContractIDs: []string{"ctr_1"}},
+		{GroupID: "grp_3", GroupName: "Other", ContractIDs: []string{"ctr_2"}},
+		{GroupID: "grp_4", GroupName: "Web", ParentGroupID: "grp_3", ContractIDs: []string{"ctr_2"}},
+	}
+
+	if _, err := findGroupByName(groups, "Web", "", ""); err == nil {
+		t.Fatal("expected an error for an ambiguous name")
+	}
+
+	for _, scope := range [][2]string{{"Example", ""}, {"grp_1", ""}, {"1", ""}, {"", "ctr_1"}} {
+		group, err := findGroupByName(groups, "Web", scope[0], scope[1])
+		if err != nil || group.GroupID != "grp_2" {
+			t.Fatalf("expected grp_2 with parent %q and contract %q, got %v (%v)", scope[0], scope[1], group, err)
+		}
+	}
+
+	if _, err := findGroupByName(groups, "Web", "Missing", ""); err == nil {
+		t.Fatal("expected an error for a missing parent")
+	}
+}
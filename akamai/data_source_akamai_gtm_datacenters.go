@@ -0,0 +1,104 @@
+package akamai
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceGTMDatacenters() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGTMDatacentersRead,
+		Schema: map[string]*schema.Schema{
+			"domain": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"datacenters": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datacenter_id": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"nickname": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"city": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state_or_province": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"country": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"continent": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"latitude": &schema.Schema{
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"longitude": &schema.Schema{
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"virtual": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+			// Datacenter IDs keyed by nickname
+			"datacenter_ids": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceGTMDatacentersRead(d *schema.ResourceData, meta interface{}) error {
+	domain := d.Get("domain").(string)
+
+	datacenters, err := getGTMDatacenters(domain)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(datacenters, func(i, j int) bool {
+		return datacenters[i].DatacenterID < datacenters[j].DatacenterID
+	})
+
+	var items []interface{}
+	ids := make(map[string]interface{})
+	for _, datacenter := range datacenters {
+		items = append(items, map[string]interface{}{
+			"datacenter_id":     datacenter.DatacenterID,
+			"nickname":          datacenter.Nickname,
+			"city":              datacenter.City,
+			"state_or_province": datacenter.StateOrProvince,
+			"country":           datacenter.Country,
+			"continent":         datacenter.Continent,
+			"latitude":          datacenter.Latitude,
+			"longitude":         datacenter.Longitude,
+			"virtual":           datacenter.Virtual,
+		})
+		ids[datacenter.Nickname] = fmt.Sprintf("%d", datacenter.DatacenterID)
+	}
+
+	d.SetId(domain)
+	d.Set("datacenter_ids", ids)
+	return d.Set("datacenters", items)
+}
@@ -0,0 +1,38 @@
+package akamai
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceGTMDefaultDatacenter() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGTMDefaultDatacenterRead,
+		Schema: map[string]*schema.Schema{
+			"domain": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"datacenter_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"nickname": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceGTMDefaultDatacenterRead(d *schema.ResourceData, meta interface{}) error {
+	domain := d.Get("domain").(string)
+
+	datacenter, err := getGTMDefaultDatacenter(domain)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(gtmID(domain, "default-datacenter"))
+	d.Set("datacenter_id", datacenter.DatacenterID)
+	return d.Set("nickname", datacenter.Nickname)
+}
@@ -0,0 +1,83 @@
+package akamai
+
+import (
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceGTMDomain() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGTMDomainRead,
+		Schema: map[string]*schema.Schema{
+			"domain": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"load_imbalance_percentage": &schema.Schema{
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"datacenter_ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			// Property types keyed by property name
+			"properties": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"resources": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"propagation_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceGTMDomainRead(d *schema.ResourceData, meta interface{}) error {
+	domain, err := getGTMDomain(d.Get("domain").(string))
+	if err != nil {
+		return err
+	}
+
+	var datacenterIDs []int
+	for _, datacenter := range domain.Datacenters {
+		datacenterIDs = append(datacenterIDs, datacenter.DatacenterID)
+	}
+	sort.Ints(datacenterIDs)
+
+	properties := make(map[string]interface{})
+	for _, property := range domain.Properties {
+		properties[property.Name] = property.Type
+	}
+
+	var resources []string
+	for _, resource := range domain.Resources {
+		resources = append(resources, resource.Name)
+	}
+	sort.Strings(resources)
+
+	d.SetId(domain.Name)
+	d.Set("type", domain.Type)
+	d.Set("load_imbalance_percentage", domain.LoadImbalancePercentage)
+	d.Set("datacenter_ids", datacenterIDs)
+	d.Set("properties", properties)
+	d.Set("resources", resources)
+	if domain.Status != nil {
+		d.Set("propagation_status", domain.Status.PropagationStatus)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package akamai
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceProducts() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceProductsRead,
+		Schema: map[string]*schema.Schema{
+			"contract_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// Selects product_id by product name, e.g. Ion Standard
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"product_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"products": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			// Product IDs keyed by product name
+			"product_ids": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceProductsRead(d *schema.ResourceData, meta interface{}) error {
+	contractID := d.Get("contract_id").(string)
+
+	products := papi.NewProducts()
+	if err := products.GetProducts(&papi.Contract{ContractID: contractID}); err != nil {
+		return err
+	}
+
+	var items []interface{}
+	ids := make(map[string]interface{})
+	for _, product := range products.Products.Items {
+		items = append(items, map[string]interface{}{
+			"id":   product.ProductID,
+			"name": product.ProductName,
+		})
+		ids[product.ProductName] = product.ProductID
+	}
+
+	if name := d.Get("name").(string); name != "" {
+		product, err := findProductByName(products, contractID, name)
+		if err != nil {
+			return err
+		}
+		d.Set("product_id", product.ProductID)
+	}
+
+	d.SetId(contractID)
+	d.Set("product_ids", ids)
+	return d.Set("products", items)
+}
+
+// findProductByName returns the product with the given name, ignoring case
+func findProductByName(products *papi.Products, contractID string, name string) (*papi.Product, error) {
+	var names []string
+	for _, product := range products.Products.Items {
+		if strings.EqualFold(product.ProductName, name) {
+			return product, nil
+		}
+		names = append(names, product.ProductName)
+	}
+
+	return nil, fmt.Errorf("product \"%s\" is not available on contract %s, available products: %s", name, contractID, strings.Join(names, ", "))
+}
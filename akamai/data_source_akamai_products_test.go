@@ -0,0 +1,23 @@
+package akamai
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+)
+
+func TestFindProductByName(t *testing.T) {
+	products := papi.NewProducts()
+	products.Products.Items = []*papi.Product{
+		{ProductID: "prd_SPM", ProductName: "Ion Standard"},
+		{ProductID: "prd_Site_Accel", ProductName: "DSA"},
+	}
+
+	if product, err := findProductByName(products, "ctr_1", "ion standard"); err != nil || product.ProductID != "prd_SPM" {
+		t.Fatalf("expected prd_SPM, got %v (%v)", product, err)
+	}
+	if _, err := findProductByName(products, "ctr_1", "Ion Premier"); err == nil || !strings.Contains(err.Error(), "Ion Standard, DSA") {
+		t.Fatalf("expected an error listing the available products, got %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package akamai
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceProperties() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePropertiesRead,
+		Schema: map[string]*schema.Schema{
+			"contract_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name_prefix": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"properties": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"property_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"latest_version": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"staging_version": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"production_version": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			// Property IDs keyed by name
+			"property_ids": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourcePropertiesRead(d *schema.ResourceData, meta interface{}) error {
+	contractID := d.Get("contract_id").(string)
+	groupID := d.Get("group_id").(string)
+	namePrefix := d.Get("name_prefix").(string)
+
+	properties := papi.NewProperties()
+	err := properties.GetProperties(&papi.Contract{ContractID: contractID}, &papi.Group{GroupID: groupID})
+	if err != nil {
+		return err
+	}
+
+	var items []interface{}
+	ids := make(map[string]interface{})
+	for _, property := range filterProperties(properties.Properties.Items, namePrefix) {
+		items = append(items, map[string]interface{}{
+			"property_id":        property.PropertyID,
+			"name":               property.PropertyName,
+			"latest_version":     property.LatestVersion,
+			"staging_version":    property.StagingVersion,
+			"production_version": property.ProductionVersion,
+		})
+		ids[property.PropertyName] = property.PropertyID
+	}
+
+	d.SetId(fmt.Sprintf("%d", hashcode.String(strings.Join([]string{contractID, groupID, namePrefix}, ","))))
+	d.Set("property_ids", ids)
+	return d.Set("properties", items)
+}
+
+// filterProperties returns the properties with the name prefix, sorted by name
+func filterProperties(properties []*papi.Property, namePrefix string) []*papi.Property {
+	var found []*papi.Property
+	for _, property := range properties {
+		if strings.HasPrefix(property.PropertyName, namePrefix) {
+			found = append(found, property)
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].PropertyName < found[j].PropertyName
+	})
+
+	return found
+}
@@ -0,0 +1,24 @@
+package akamai
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+)
+
+func TestFilterProperties(t *testing.T) {
+	properties := []*papi.Property{
+		{PropertyID: "prp_1", PropertyName: "www.example.com"},
+		{PropertyID: "prp_2", PropertyName: "api.example.com"},
+		{PropertyID: "prp_3", PropertyName: "www.example.org"},
+	}
+
+	if found := filterProperties(properties, ""); len(found) != 3 || found[0].PropertyID != "prp_2" {
+		t.Fatalf("expected all properties sorted by name, got %v", found)
+	}
+
+	found := filterProperties(properties, "www.")
+	if len(found) != 2 || found[0].PropertyID != "prp_1" || found[1].PropertyID != "prp_3" {
+		t.Fatalf("expected prp_1 and prp_3, got %v", found)
+	}
+}
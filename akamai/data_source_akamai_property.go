@@ -0,0 +1,150 @@
+package akamai
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceProperty() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePropertyRead,
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"property_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			// The version to read hostnames from, defaults to the latest
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"account_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"contract_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"product_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"rule_format": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"note": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"latest_version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"staging_version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"production_version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"hostnames": &schema.Schema{
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// The edge hostname of each hostname, keyed like akamai_property's edge_hostname
+			"edge_hostnames": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourcePropertyRead(d *schema.ResourceData, meta interface{}) error {
+	property, err := dataSourceGetProperty(d)
+	if err != nil {
+		return err
+	}
+
+	version := d.Get("version").(int)
+	if version == 0 {
+		version = property.LatestVersion
+	}
+
+	hostnames, err := property.GetHostnames(&papi.Version{PropertyVersion: version})
+	if err != nil {
+		return err
+	}
+
+	var hostnameList []interface{}
+	edgeHostnames := make(map[string]interface{})
+	for _, hostname := range hostnames.Hostnames.Items {
+		hostnameList = append(hostnameList, hostname.CnameFrom)
+		edgeHostnames[strings.Replace(hostname.CnameFrom, ".", "-", -1)] = hostname.CnameTo
+	}
+
+	d.SetId(property.PropertyID)
+	d.Set("name", property.PropertyName)
+	d.Set("property_id", property.PropertyID)
+	d.Set("account_id", property.AccountID)
+	d.Set("contract_id", property.ContractID)
+	d.Set("group_id", property.GroupID)
+	d.Set("product_id", property.ProductID)
+	d.Set("rule_format", property.RuleFormat)
+	d.Set("note", property.Note)
+	d.Set("latest_version", property.LatestVersion)
+	d.Set("staging_version", property.StagingVersion)
+	d.Set("production_version", property.ProductionVersion)
+	d.Set("hostnames", hostnameList)
+	d.Set("edge_hostnames", edgeHostnames)
+
+	return nil
+}
+
+// dataSourceGetProperty returns the property of a data source by property_id, or
+// by name if no ID is given
+func dataSourceGetProperty(d *schema.ResourceData) (*papi.Property, error) {
+	propertyID := d.Get("property_id").(string)
+	if propertyID == "" {
+		name := d.Get("name").(string)
+		if name == "" {
+			return nil, errors.New("one of name or property_id must be set")
+		}
+
+		results, err := papi.Search(papi.SearchByPropertyName, name)
+		if err != nil {
+			return nil, err
+		}
+		if results == nil || len(results.Versions.Items) == 0 {
+			return nil, fmt.Errorf("property \"%s\" not found", name)
+		}
+		propertyID = results.Versions.Items[0].PropertyID
+	}
+
+	property := papi.NewProperty(papi.NewProperties())
+	property.PropertyID = propertyID
+	if err := property.GetProperty(); err != nil {
+		return nil, err
+	}
+
+	return property, nil
+}
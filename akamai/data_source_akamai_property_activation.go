@@ -0,0 +1,111 @@
+package akamai
+
+import (
+	"fmt"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func dataSourcePropertyActivation() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePropertyActivationRead,
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"property_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"network": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "staging",
+				ValidateFunc: validation.StringInSlice([]string{"staging", "production"}, false),
+			},
+			// The version active on the network, or 0
+			"active_version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			// The latest activation or deactivation on the network
+			"activation_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"activation_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"submit_date": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"update_date": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"note": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"notify_emails": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourcePropertyActivationRead(d *schema.ResourceData, meta interface{}) error {
+	property, err := dataSourceGetProperty(d)
+	if err != nil {
+		return err
+	}
+
+	network := papi.NetworkValue(d.Get("network").(string))
+	activeVersion := property.StagingVersion
+	if network == papi.NetworkProduction {
+		activeVersion = property.ProductionVersion
+	}
+
+	activations, err := property.GetActivations()
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", property.PropertyID, network))
+	d.Set("name", property.PropertyName)
+	d.Set("property_id", property.PropertyID)
+	d.Set("active_version", activeVersion)
+
+	activation := latestActivation(activations, network)
+	if activation == nil {
+		activation = &papi.Activation{}
+	}
+
+	d.Set("activation_id", activation.ActivationID)
+	d.Set("version", activation.PropertyVersion)
+	d.Set("activation_type", string(activation.ActivationType))
+	d.Set("status", string(activation.Status))
+	d.Set("submit_date", activation.SubmitDate)
+	d.Set("update_date", activation.UpdateDate)
+	d.Set("note", activation.Note)
+	d.Set("notify_emails", activation.NotifyEmails)
+
+	return nil
+}
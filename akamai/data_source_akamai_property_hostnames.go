@@ -0,0 +1,129 @@
+package akamai
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourcePropertyHostnames() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePropertyHostnamesRead,
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"property_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			// Defaults to the latest version
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"hostnames": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"hostname": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"edge_hostname": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"edge_hostname_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						// CPS_MANAGED or DEFAULT (Secure by Default)
+						"cert_provisioning_type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cert_staging_status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cert_production_status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						// The CNAME record validating a Secure by Default certificate
+						"cert_cname_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cert_cname_target": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePropertyHostnamesRead(d *schema.ResourceData, meta interface{}) error {
+	property, err := dataSourceGetProperty(d)
+	if err != nil {
+		return err
+	}
+
+	version := d.Get("version").(int)
+	if version == 0 {
+		version = property.LatestVersion
+	}
+
+	statuses, err := getHostnameCertStatuses(property, version)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d", property.PropertyID, version))
+	d.Set("name", property.PropertyName)
+	d.Set("property_id", property.PropertyID)
+	d.Set("version", version)
+
+	return d.Set("hostnames", flattenHostnameBindings(statuses))
+}
+
+// flattenHostnameBindings returns the hostnames with their edge hostname and certificate status
+func flattenHostnameBindings(statuses []hostnameCertStatus) []interface{} {
+	hostnames := make([]interface{}, 0, len(statuses))
+	for _, status := range statuses {
+		hostname := map[string]interface{}{
+			"hostname":               status.CnameFrom,
+			"edge_hostname":          status.CnameTo,
+			"edge_hostname_id":       status.EdgeHostnameID,
+			"cert_provisioning_type": status.CertProvisioningType,
+			"cert_staging_status":    "",
+			"cert_production_status": "",
+			"cert_cname_name":        "",
+			"cert_cname_target":      "",
+		}
+
+		if status.CertStatus != nil {
+			hostname["cert_cname_name"] = status.CertStatus.ValidationCname.Hostname
+			hostname["cert_cname_target"] = status.CertStatus.ValidationCname.Target
+			if len(status.CertStatus.Staging) > 0 {
+				hostname["cert_staging_status"] = status.CertStatus.Staging[0].Status
+			}
+			if len(status.CertStatus.Production) > 0 {
+				hostname["cert_production_status"] = status.CertStatus.Production[0].Status
+			}
+		}
+
+		hostnames = append(hostnames, hostname)
+	}
+
+	return hostnames
+}
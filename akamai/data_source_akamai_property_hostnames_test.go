@@ -0,0 +1,35 @@
+package akamai
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFlattenHostnameBindings(t *testing.T) {
+	var statuses []hostnameCertStatus
+	if err := json.Unmarshal([]byte(`[
+		{"cnameFrom": "www.example.com", "cnameTo": "www.example.com.edgekey.net", "edgeHostnameId": "ehn_1", "certProvisioningType": "DEFAULT", "certStatus": {
+			"validationCname": {"hostname": "_acme-challenge.www.example.com", "target": "ac.123.example.com.acme-validate.edgekey.net"},
+			"staging": [{"status": "DEPLOYED"}],
+			"production": [{"status": "PENDING"}]
+		}},
+		{"cnameFrom": "cps.example.com", "cnameTo": "cps.example.com.edgekey.net", "edgeHostnameId": "ehn_2", "certProvisioningType": "CPS_MANAGED"}
+	]`), &statuses); err != nil {
+		t.Fatal(err)
+	}
+
+	hostnames := flattenHostnameBindings(statuses)
+	if len(hostnames) != 2 {
+		t.Fatalf("expected 2 hostnames, got %d", len(hostnames))
+	}
+
+	www := hostnames[0].(map[string]interface{})
+	if www["edge_hostname"] != "www.example.com.edgekey.net" || www["cert_staging_status"] != "DEPLOYED" || www["cert_production_status"] != "PENDING" {
+		t.Fatalf("unexpected hostname %v", www)
+	}
+
+	cps := hostnames[1].(map[string]interface{})
+	if cps["edge_hostname_id"] != "ehn_2" || cps["cert_provisioning_type"] != "CPS_MANAGED" || cps["cert_cname_name"] != "" {
+		t.Fatalf("unexpected hostname %v", cps)
+	}
+}
@@ -0,0 +1,107 @@
+package akamai
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func dataSourcePropertyIncludes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePropertyIncludesRead,
+		Schema: map[string]*schema.Schema{
+			"contract_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(includeTypes, false),
+			},
+			"includes": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"include_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"latest_version": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"staging_version": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"production_version": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePropertyIncludesRead(d *schema.ResourceData, meta interface{}) error {
+	contractID := d.Get("contract_id").(string)
+	groupID := d.Get("group_id").(string)
+	includeType := d.Get("type").(string)
+
+	includes, err := getIncludes(contractID, groupID)
+	if err != nil {
+		return err
+	}
+
+	var items []interface{}
+	for _, include := range filterIncludes(includes, includeType) {
+		items = append(items, map[string]interface{}{
+			"include_id":         include.IncludeID,
+			"name":               include.IncludeName,
+			"type":               include.IncludeType,
+			"latest_version":     include.LatestVersion,
+			"staging_version":    include.StagingVersion,
+			"production_version": include.ProductionVersion,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%d", hashcode.String(strings.Join([]string{contractID, groupID, includeType}, ","))))
+	return d.Set("includes", items)
+}
+
+// filterIncludes returns the includes of the type, or all of them if no type is
+// given, sorted by name
+func filterIncludes(includes []*papiInclude, includeType string) []*papiInclude {
+	var found []*papiInclude
+	for _, include := range includes {
+		if includeType == "" || include.IncludeType == includeType {
+			found = append(found, include)
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].IncludeName < found[j].IncludeName
+	})
+
+	return found
+}
@@ -0,0 +1,22 @@
+package akamai
+
+import (
+	"testing"
+)
+
+func TestFilterIncludes(t *testing.T) {
+	includes := []*papiInclude{
+		{IncludeID: "inc_1", IncludeName: "security", IncludeType: "COMMON_SETTINGS"},
+		{IncludeID: "inc_2", IncludeName: "checkout", IncludeType: "MICROSERVICES"},
+		{IncludeID: "inc_3", IncludeName: "caching", IncludeType: "COMMON_SETTINGS"},
+	}
+
+	if found := filterIncludes(includes, ""); len(found) != 3 || found[0].IncludeID != "inc_3" {
+		t.Fatalf("expected all includes sorted by name, got %v", found)
+	}
+
+	found := filterIncludes(includes, "COMMON_SETTINGS")
+	if len(found) != 2 || found[0].IncludeID != "inc_3" || found[1].IncludeID != "inc_1" {
+		t.Fatalf("expected inc_3 and inc_1, got %v", found)
+	}
+}
@@ -0,0 +1,137 @@
+package akamai
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourcePropertyRules() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePropertyRulesRead,
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"property_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			// Defaults to the latest version
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			// Converts the rules to another rule format, defaults to the format of the version
+			"rule_format": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			// The rule tree as JSON
+			"rules": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"etag": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourcePropertyRulesRead(d *schema.ResourceData, meta interface{}) error {
+	property, err := dataSourceGetProperty(d)
+	if err != nil {
+		return err
+	}
+
+	version := d.Get("version").(int)
+	if version == 0 {
+		version = property.LatestVersion
+	}
+
+	rules, err := getRulesJSON(property, version, d.Get("rule_format").(string))
+	if err != nil {
+		return err
+	}
+
+	var tree bytes.Buffer
+	if err := json.Indent(&tree, rules.Rules, "", "  "); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d", property.PropertyID, version))
+	d.Set("name", property.PropertyName)
+	d.Set("property_id", property.PropertyID)
+	d.Set("version", version)
+	d.Set("rule_format", rules.RuleFormat)
+	d.Set("rules", tree.String())
+	d.Set("etag", rules.Etag)
+
+	return nil
+}
+
+// rulesJSON is a rules response with the rule tree left as JSON, so that nothing
+// the edgegrid client does not know about is lost
+type rulesJSON struct {
+	Etag       string          `json:"etag"`
+	RuleFormat string          `json:"ruleFormat"`
+	Rules      json.RawMessage `json:"rules"`
+}
+
+// getRulesJSON fetches the rules of a property version, converted to the rule
+// format if one is given
+//
+// Endpoint: GET /papi/v1/properties/{propertyId}/versions/{propertyVersion}/rules{?contractId,groupId}
+func getRulesJSON(property *papi.Property, version int, format string) (*rulesJSON, error) {
+	req, err := client.NewRequest(
+		papi.Config,
+		"GET",
+		fmt.Sprintf(
+			"/papi/v1/properties/%s/versions/%d/rules?contractId=%s&groupId=%s",
+			property.PropertyID,
+			version,
+			property.ContractID,
+			property.GroupID,
+		),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if format != "" {
+		req.Header.Set("Accept", ruleFormatMediaType(format))
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := &rulesJSON{}
+	if err := json.Unmarshal(body, rules); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
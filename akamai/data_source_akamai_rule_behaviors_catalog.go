@@ -0,0 +1,162 @@
+package akamai
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func dataSourceRuleBehaviorsCatalog() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRuleBehaviorsCatalogRead,
+		Schema: map[string]*schema.Schema{
+			"product_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"rule_format": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "latest",
+				ValidateFunc: validation.StringMatch(ruleFormatRegexp, "must be latest or a dated rule format, e.g. v2018-02-27"),
+			},
+			// The behavior names, sorted
+			"behaviors": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// The criteria names, sorted
+			"criteria": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// The JSON schema of each behavior's options, keyed by behavior name
+			"behavior_options": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// The JSON schema of each criterion's options, keyed by criterion name
+			"criteria_options": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceRuleBehaviorsCatalogRead(d *schema.ResourceData, meta interface{}) error {
+	productID := d.Get("product_id").(string)
+	ruleFormat := d.Get("rule_format").(string)
+
+	catalog, err := getRuleCatalog(productID, ruleFormat)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", productID, ruleFormat))
+	d.Set("behaviors", catalog.behaviorNames())
+	d.Set("criteria", catalog.criteriaNames())
+	d.Set("behavior_options", catalogOptions(catalog.Definitions.Catalog.Behaviors))
+	d.Set("criteria_options", catalogOptions(catalog.Definitions.Catalog.Criteria))
+
+	return nil
+}
+
+// ruleCatalog is the part of a product's rule format schema that lists the
+// available behaviors and criteria
+type ruleCatalog struct {
+	Definitions struct {
+		Catalog struct {
+			Behaviors map[string]ruleCatalogEntry `json:"behaviors"`
+			Criteria  map[string]ruleCatalogEntry `json:"criteria"`
+		} `json:"catalog"`
+	} `json:"definitions"`
+}
+
+type ruleCatalogEntry struct {
+	Properties struct {
+		Options json.RawMessage `json:"options"`
+	} `json:"properties"`
+}
+
+func (catalog *ruleCatalog) behaviorNames() []string {
+	return catalogNames(catalog.Definitions.Catalog.Behaviors)
+}
+
+func (catalog *ruleCatalog) criteriaNames() []string {
+	return catalogNames(catalog.Definitions.Catalog.Criteria)
+}
+
+func catalogNames(entries map[string]ruleCatalogEntry) []string {
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+func catalogOptions(entries map[string]ruleCatalogEntry) map[string]interface{} {
+	options := make(map[string]interface{}, len(entries))
+	for name, entry := range entries {
+		if len(entry.Properties.Options) == 0 {
+			options[name] = "{}"
+			continue
+		}
+		options[name] = string(entry.Properties.Options)
+	}
+
+	return options
+}
+
+// getRuleCatalog fetches the rule format schema for a product. The edgegrid
+// client only returns it compiled for validation, so it is fetched directly.
+//
+// Endpoint: GET /papi/v1/schemas/products/{productId}/{ruleFormat}
+func getRuleCatalog(productID string, ruleFormat string) (*ruleCatalog, error) {
+	req, err := client.NewRequest(
+		papi.Config,
+		"GET",
+		fmt.Sprintf("/papi/v1/schemas/products/%s/%s", productID, ruleFormat),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseRuleCatalog(body)
+}
+
+func parseRuleCatalog(body []byte) (*ruleCatalog, error) {
+	catalog := &ruleCatalog{}
+	if err := json.Unmarshal(body, catalog); err != nil {
+		return nil, err
+	}
+
+	return catalog, nil
+}
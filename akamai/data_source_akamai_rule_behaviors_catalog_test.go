@@ -0,0 +1,43 @@
+package akamai
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRuleCatalog(t *testing.T) {
+	body := []byte(`{
+		"definitions": {
+			"catalog": {
+				"behaviors": {
+					"origin": {"properties": {"options": {"type": "object", "properties": {"hostname": {"type": "string"}}}}},
+					"caching": {"properties": {"options": {"type": "object"}}},
+					"allowPost": {"properties": {}}
+				},
+				"criteria": {
+					"path": {"properties": {"options": {"type": "object"}}}
+				}
+			}
+		}
+	}`)
+
+	catalog, err := parseRuleCatalog(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if names := catalog.behaviorNames(); !reflect.DeepEqual(names, []string{"allowPost", "caching", "origin"}) {
+		t.Errorf("unexpected behaviors: %v", names)
+	}
+	if names := catalog.criteriaNames(); !reflect.DeepEqual(names, []string{"path"}) {
+		t.Errorf("unexpected criteria: %v", names)
+	}
+
+	options := catalogOptions(catalog.Definitions.Catalog.Behaviors)
+	if options["origin"] != `{"type": "object", "properties": {"hostname": {"type": "string"}}}` {
+		t.Errorf("unexpected origin options: %v", options["origin"])
+	}
+	if options["allowPost"] != "{}" {
+		t.Errorf("unexpected allowPost options: %v", options["allowPost"])
+	}
+}
@@ -0,0 +1,58 @@
+package akamai
+
+import (
+	"sort"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceRuleFormats() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRuleFormatsRead,
+		Schema: map[string]*schema.Schema{
+			// The frozen rule formats, oldest first
+			"rule_formats": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// The newest frozen rule format, which latest currently corresponds to
+			"latest": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceRuleFormatsRead(d *schema.ResourceData, meta interface{}) error {
+	ruleFormats := papi.NewRuleFormats()
+	if err := ruleFormats.GetRuleFormats(); err != nil {
+		return err
+	}
+
+	frozen := frozenRuleFormats(ruleFormats.RuleFormats.Items)
+
+	d.SetId("rule_formats")
+	d.Set("rule_formats", frozen)
+	if len(frozen) > 0 {
+		d.Set("latest", frozen[len(frozen)-1])
+	}
+
+	return nil
+}
+
+// frozenRuleFormats returns the dated rule formats, oldest first. Formats with
+// a suffix, e.g. v2018-02-27-beta, are left out.
+func frozenRuleFormats(ruleFormats []string) []string {
+	frozen := []string{}
+	for _, ruleFormat := range ruleFormats {
+		if ruleFormat != "latest" && ruleFormatRegexp.MatchString(ruleFormat) {
+			frozen = append(frozen, ruleFormat)
+		}
+	}
+
+	sort.Strings(frozen)
+	return frozen
+}
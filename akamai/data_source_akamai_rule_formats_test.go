@@ -0,0 +1,15 @@
+package akamai
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFrozenRuleFormats(t *testing.T) {
+	frozen := frozenRuleFormats([]string{"latest", "v2018-02-27", "v2015-08-17", "v2018-09-12-beta", "v2017-06-19"})
+
+	expected := []string{"v2015-08-17", "v2017-06-19", "v2018-02-27"}
+	if !reflect.DeepEqual(frozen, expected) {
+		t.Fatalf("expected %v, got %v", expected, frozen)
+	}
+}
@@ -0,0 +1,311 @@
+package akamai
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+type dnsRecordFieldKind int
+
+const (
+	dnsFieldNumber dnsRecordFieldKind = iota
+	// A bare token, e.g. a CERT type mnemonic
+	dnsFieldToken
+	// A character string, quoted in zone file format
+	dnsFieldQuoted
+	// A domain name, compared without case or trailing dot
+	dnsFieldName
+	// Hex data, which may be split by whitespace and is compared without case
+	dnsFieldHex
+	// Base64 data, which may be split by whitespace
+	dnsFieldBase64
+)
+
+type dnsRecordField struct {
+	name     string
+	kind     dnsRecordFieldKind
+	optional bool
+}
+
+// dnsExtendedRecordFields are the rdata fields, in wire order, of the record types
+// akamai_dns_record configures with typed blocks rather than raw target strings.
+// Only the last field of a type may be hex or base64 data.
+var dnsExtendedRecordFields = map[string][]dnsRecordField{
+	"AFSDB": {
+		{name: "subtype", kind: dnsFieldNumber},
+		{name: "hostname", kind: dnsFieldName},
+	},
+	"CERT": {
+		{name: "type", kind: dnsFieldToken},
+		{name: "key_tag", kind: dnsFieldNumber},
+		{name: "algorithm", kind: dnsFieldToken},
+		{name: "certificate", kind: dnsFieldBase64},
+	},
+	"DS": {
+		{name: "key_tag", kind: dnsFieldNumber},
+		{name: "algorithm", kind: dnsFieldNumber},
+		{name: "digest_type", kind: dnsFieldNumber},
+		{name: "digest", kind: dnsFieldHex},
+	},
+	"HINFO": {
+		{name: "hardware", kind: dnsFieldQuoted},
+		{name: "software", kind: dnsFieldQuoted},
+	},
+	// LOC latitude and longitude span several tokens, see parseLOCRdata
+	"LOC": {
+		{name: "latitude", kind: dnsFieldToken},
+		{name: "longitude", kind: dnsFieldToken},
+		{name: "altitude", kind: dnsFieldToken},
+		{name: "size", kind: dnsFieldToken, optional: true},
+		{name: "horiz_precision", kind: dnsFieldToken, optional: true},
+		{name: "vert_precision", kind: dnsFieldToken, optional: true},
+	},
+	"NAPTR": {
+		{name: "order", kind: dnsFieldNumber},
+		{name: "preference", kind: dnsFieldNumber},
+		{name: "flags", kind: dnsFieldQuoted},
+		{name: "service", kind: dnsFieldQuoted},
+		{name: "regexp", kind: dnsFieldQuoted},
+		{name: "replacement", kind: dnsFieldName},
+	},
+	"SSHFP": {
+		{name: "algorithm", kind: dnsFieldNumber},
+		{name: "fingerprint_type", kind: dnsFieldNumber},
+		{name: "fingerprint", kind: dnsFieldHex},
+	},
+	"TLSA": {
+		{name: "usage", kind: dnsFieldNumber},
+		{name: "selector", kind: dnsFieldNumber},
+		{name: "match_type", kind: dnsFieldNumber},
+		{name: "certificate", kind: dnsFieldHex},
+	},
+}
+
+// dnsSOAFields are the rdata fields of the SOA record, which is managed by
+// akamai_dns_zone rather than akamai_dns_record
+var dnsSOAFields = []dnsRecordField{
+	{name: "primary", kind: dnsFieldName},
+	{name: "contact", kind: dnsFieldName},
+	{name: "serial", kind: dnsFieldNumber},
+	{name: "refresh", kind: dnsFieldNumber},
+	{name: "retry", kind: dnsFieldNumber},
+	{name: "expire", kind: dnsFieldNumber},
+	{name: "minimum", kind: dnsFieldNumber},
+}
+
+func dnsRecordFields(recordType string) []dnsRecordField {
+	if recordType == "SOA" {
+		return dnsSOAFields
+	}
+	return dnsExtendedRecordFields[recordType]
+}
+
+func dnsExtendedRecordTypes() []string {
+	var types []string
+	for recordType := range dnsExtendedRecordFields {
+		types = append(types, recordType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// dnsRecordBlock is the name of the block that configures records of the type
+func dnsRecordBlock(recordType string) string {
+	return strings.ToLower(recordType)
+}
+
+// dnsExtendedRecordSchema adds a block, one per record, for each extended record type
+func dnsExtendedRecordSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	for recordType, fields := range dnsExtendedRecordFields {
+		elem := map[string]*schema.Schema{}
+		for _, field := range fields {
+			fieldSchema := &schema.Schema{
+				Type:     schema.TypeString,
+				Required: !field.optional,
+				Optional: field.optional,
+			}
+			if field.kind == dnsFieldNumber {
+				fieldSchema.Type = schema.TypeInt
+			}
+			elem[field.name] = fieldSchema
+		}
+
+		s[dnsRecordBlock(recordType)] = &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     &schema.Resource{Schema: elem},
+		}
+	}
+
+	return s
+}
+
+// formatDNSRdata formats the typed fields of a record as rdata
+func formatDNSRdata(recordType string, values map[string]interface{}) string {
+	var tokens []string
+	for _, field := range dnsRecordFields(recordType) {
+		switch field.kind {
+		case dnsFieldNumber:
+			tokens = append(tokens, strconv.Itoa(values[field.name].(int)))
+		case dnsFieldQuoted:
+			tokens = append(tokens, quoteDNSString(values[field.name].(string)))
+		default:
+			if value, ok := values[field.name].(string); ok && value != "" {
+				tokens = append(tokens, value)
+			}
+		}
+	}
+
+	return strings.Join(tokens, " ")
+}
+
+// parseDNSRdata parses rdata into the typed fields of a record
+func parseDNSRdata(recordType string, rdata string) (map[string]interface{}, error) {
+	fields := dnsRecordFields(recordType)
+	tokens, err := splitDNSRdata(rdata)
+	if err != nil {
+		return nil, err
+	}
+
+	if recordType == "LOC" {
+		if tokens, err = groupLOCTokens(tokens); err != nil {
+			return nil, fmt.Errorf("invalid LOC rdata %q: %s", rdata, err)
+		}
+	}
+
+	last := fields[len(fields)-1]
+	if (last.kind == dnsFieldHex || last.kind == dnsFieldBase64) && len(tokens) > len(fields) {
+		tokens = append(tokens[:len(fields)-1], strings.Join(tokens[len(fields)-1:], ""))
+	}
+
+	values := map[string]interface{}{}
+	for i, field := range fields {
+		if i >= len(tokens) {
+			if !field.optional {
+				return nil, fmt.Errorf("invalid %s rdata %q: missing %s", recordType, rdata, field.name)
+			}
+			values[field.name] = ""
+			continue
+		}
+
+		switch field.kind {
+		case dnsFieldNumber:
+			number, err := strconv.Atoi(tokens[i])
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s rdata %q: %s is not a number", recordType, rdata, field.name)
+			}
+			values[field.name] = number
+		default:
+			values[field.name] = tokens[i]
+		}
+	}
+	if len(tokens) > len(fields) {
+		return nil, fmt.Errorf("invalid %s rdata %q: too many fields", recordType, rdata)
+	}
+
+	return values, nil
+}
+
+// normalizeDNSRdata returns the rdata with the differences in quoting, whitespace,
+// and case that don't change the record removed
+func normalizeDNSRdata(recordType string, rdata string) string {
+	values, err := parseDNSRdata(recordType, rdata)
+	if err != nil {
+		return rdata
+	}
+
+	for _, field := range dnsRecordFields(recordType) {
+		switch field.kind {
+		case dnsFieldName:
+			values[field.name] = strings.ToLower(strings.TrimSuffix(values[field.name].(string), "."))
+		case dnsFieldHex:
+			values[field.name] = strings.ToUpper(values[field.name].(string))
+		}
+	}
+
+	return formatDNSRdata(recordType, values)
+}
+
+// splitDNSRdata splits rdata into tokens, unquoting character strings
+func splitDNSRdata(rdata string) ([]string, error) {
+	var tokens []string
+	var token bytes.Buffer
+	inToken, quoted, escaped := false, false, false
+
+	for _, r := range rdata {
+		switch {
+		case escaped:
+			token.WriteRune(r)
+			escaped = false
+		case r == '\\' && quoted:
+			escaped = true
+		case r == '"':
+			if quoted {
+				tokens = append(tokens, token.String())
+				token.Reset()
+				inToken = false
+			}
+			quoted = !quoted
+		case quoted:
+			token.WriteRune(r)
+		case r == ' ' || r == '\t':
+			if inToken {
+				tokens = append(tokens, token.String())
+				token.Reset()
+				inToken = false
+			}
+		default:
+			token.WriteRune(r)
+			inToken = true
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quoted string in %q", rdata)
+	}
+	if inToken {
+		tokens = append(tokens, token.String())
+	}
+
+	return tokens, nil
+}
+
+// groupLOCTokens joins the degrees, minutes, seconds and direction of the LOC
+// latitude and longitude into single tokens
+func groupLOCTokens(tokens []string) ([]string, error) {
+	var grouped []string
+	for _, directions := range []string{"NS", "EW"} {
+		end := -1
+		for i, token := range tokens {
+			if len(token) == 1 && strings.Contains(directions, strings.ToUpper(token)) {
+				end = i
+				break
+			}
+		}
+		if end < 0 {
+			return nil, fmt.Errorf("missing %c or %c", directions[0], directions[1])
+		}
+		grouped = append(grouped, strings.Join(tokens[:end+1], " "))
+		tokens = tokens[end+1:]
+	}
+
+	return append(grouped, tokens...), nil
+}
+
+func quoteDNSString(value string) string {
+	value = strings.Replace(value, `\`, `\\`, -1)
+	value = strings.Replace(value, `"`, `\"`, -1)
+	return `"` + value + `"`
+}
+
+func dnsExtendedBlocks() []string {
+	var blocks []string
+	for _, recordType := range dnsExtendedRecordTypes() {
+		blocks = append(blocks, dnsRecordBlock(recordType))
+	}
+	return blocks
+}
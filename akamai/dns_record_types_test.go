@@ -0,0 +1,78 @@
+package akamai
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDNSRdata(t *testing.T) {
+	tests := []struct {
+		recordType string
+		rdata      string
+		expected   map[string]interface{}
+	}{
+		{
+			"NAPTR",
+			`100 10 "S" "SIP+D2U" "!^.*$!sip:info@example.com!" _sip._udp.example.com.`,
+			map[string]interface{}{"order": 100, "preference": 10, "flags": "S", "service": "SIP+D2U", "regexp": "!^.*$!sip:info@example.com!", "replacement": "_sip._udp.example.com."},
+		},
+		{
+			"TLSA",
+			"3 1 1 0C72AC70B745AC19998811B131D662C9 AC69DBDBE7CB23E5B514B56664C5D3D6",
+			map[string]interface{}{"usage": 3, "selector": 1, "match_type": 1, "certificate": "0C72AC70B745AC19998811B131D662C9AC69DBDBE7CB23E5B514B56664C5D3D6"},
+		},
+		{
+			"HINFO",
+			`"Intel \"x86\"" "Linux"`,
+			map[string]interface{}{"hardware": `Intel "x86"`, "software": "Linux"},
+		},
+		{
+			"LOC",
+			"51 30 12.748 N 0 7 39.611 W 0.00m",
+			map[string]interface{}{"latitude": "51 30 12.748 N", "longitude": "0 7 39.611 W", "altitude": "0.00m", "size": "", "horiz_precision": "", "vert_precision": ""},
+		},
+	}
+
+	for _, test := range tests {
+		values, err := parseDNSRdata(test.recordType, test.rdata)
+		if err != nil {
+			t.Errorf("unexpected error parsing %q: %s", test.rdata, err)
+			continue
+		}
+		if !reflect.DeepEqual(values, test.expected) {
+			t.Errorf("expected %q to parse to %v, got %v", test.rdata, test.expected, values)
+		}
+	}
+}
+
+func TestParseDNSRdataInvalid(t *testing.T) {
+	for recordType, rdata := range map[string]string{
+		"DS":    "60485 5 1",
+		"SSHFP": "one 1 123456789ABCDEF",
+		"HINFO": `"Intel" "Linux`,
+		"LOC":   "51 30 12.748 0 7 39.611 W 0.00m",
+	} {
+		if _, err := parseDNSRdata(recordType, rdata); err == nil {
+			t.Errorf("expected an error parsing %s rdata %q", recordType, rdata)
+		}
+	}
+}
+
+func TestFormatDNSRdata(t *testing.T) {
+	rdata := formatDNSRdata("DS", map[string]interface{}{"key_tag": 60485, "algorithm": 5, "digest_type": 1, "digest": "2BB183AF5F22588179A53B0A98631FAD1A292118"})
+	if expected := "60485 5 1 2BB183AF5F22588179A53B0A98631FAD1A292118"; rdata != expected {
+		t.Errorf("expected %q, got %q", expected, rdata)
+	}
+}
+
+func TestSameRdataExtended(t *testing.T) {
+	if !sameRdata("SSHFP", []string{"2 1 123456789abcdef67890123456789abcdef67890"}, []string{"2 1 12345678 9ABCDEF67890123456789ABCDEF67890"}) {
+		t.Error("expected SSHFP fingerprints to be compared without case or whitespace")
+	}
+	if !sameRdata("AFSDB", []string{"1 afsdb.example.com"}, []string{"1 AFSDB.example.com."}) {
+		t.Error("expected AFSDB hostnames to be compared without case or trailing dot")
+	}
+	if sameRdata("DS", []string{"60485 5 1 2BB183AF"}, []string{"60485 1 5 2BB183AF"}) {
+		t.Error("expected DS records with swapped fields to differ")
+	}
+}
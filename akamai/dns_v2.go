@@ -0,0 +1,370 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v1"
+)
+
+// Edge DNS
+//
+// The Edge DNS (FastDNS v2) resources manage zones and record sets one at a
+// time, rather than the whole zone file like akamai_fastdns_zone. The edgegrid
+// client only supports the v1 API, so the v2 endpoints are called directly with
+// the fastdns_section credentials.
+//
+// https://developer.akamai.com/api/cloud_security/edge_dns_zone_management/v2.html
+
+// edgeDNSRequest calls the Edge DNS API, decoding the response into result if
+// it is not nil. Errors are client.APIError, see isNotFound.
+func edgeDNSRequest(method string, path string, body interface{}, result interface{}) error {
+	var req *http.Request
+	var err error
+	if body != nil {
+		req, err = client.NewJSONRequest(dns.Config, method, "/config-dns/v2"+path, body)
+	} else {
+		req, err = client.NewRequest(dns.Config, method, "/config-dns/v2"+path, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Do(dns.Config, req)
+	if err != nil {
+		return err
+	}
+
+	if client.IsError(res) {
+		return client.NewAPIError(res)
+	}
+
+	if result == nil || res.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	return client.BodyJSON(res, result)
+}
+
+// isNotFound reports whether err is an API error for something that does not exist
+func isNotFound(err error) bool {
+	apiErr, ok := err.(client.APIError)
+	return ok && apiErr.Response != nil && apiErr.Response.StatusCode == http.StatusNotFound
+}
+
+// Edge DNS identifies contracts and groups without the PAPI prefixes
+func edgeDNSContractID(contractID string) string {
+	return strings.TrimPrefix(contractID, "ctr_")
+}
+
+func edgeDNSGroupID(groupID string) string {
+	return strings.TrimPrefix(groupID, "grp_")
+}
+
+type edgeDNSZone struct {
+	Zone            string   `json:"zone"`
+	Type            string   `json:"type"`
+	Comment         string   `json:"comment,omitempty"`
+	SignAndServe    bool     `json:"signAndServe"`
+	EndCustomerID   string   `json:"endCustomerId,omitempty"`
+	Target          string   `json:"target,omitempty"`
+	Masters         []string `json:"masters,omitempty"`
+	ContractID      string   `json:"contractId,omitempty"`
+	ActivationState string   `json:"activationState,omitempty"`
+	VersionID       string   `json:"versionId,omitempty"`
+}
+
+// getEdgeDNSZone returns the zone, or nil if it does not exist
+//
+// Endpoint: GET /config-dns/v2/zones/{zone}
+func getEdgeDNSZone(zone string) (*edgeDNSZone, error) {
+	result := &edgeDNSZone{}
+	if err := edgeDNSRequest("GET", "/zones/"+zone, nil, result); err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// createEdgeDNSZone creates the zone. Primary zones are created without
+// records, so a change list is submitted to add the default SOA and NS records.
+//
+// Endpoint: POST /config-dns/v2/zones{?contractId,gid}
+// Endpoint: POST /config-dns/v2/changelists{?zone}
+// Endpoint: POST /config-dns/v2/changelists/{zone}/submit
+func createEdgeDNSZone(zone *edgeDNSZone, contractID string, groupID string) error {
+	log.Printf("[DEBUG] Creating %s zone %s\n", zone.Type, zone.Zone)
+
+	path := "/zones?contractId=" + edgeDNSContractID(contractID)
+	if groupID != "" {
+		path += "&gid=" + edgeDNSGroupID(groupID)
+	}
+	if err := edgeDNSRequest("POST", path, zone, nil); err != nil {
+		return err
+	}
+
+	if zone.Type != "PRIMARY" {
+		return nil
+	}
+
+	if err := edgeDNSRequest("POST", "/changelists?zone="+zone.Zone, map[string]interface{}{}, nil); err != nil {
+		return err
+	}
+
+	return edgeDNSRequest("POST", "/changelists/"+zone.Zone+"/submit", map[string]interface{}{}, nil)
+}
+
+// updateEdgeDNSZone updates the zone settings
+//
+// Endpoint: PUT /config-dns/v2/zones/{zone}
+func updateEdgeDNSZone(zone *edgeDNSZone) error {
+	log.Printf("[DEBUG] Updating zone %s\n", zone.Zone)
+	return edgeDNSRequest("PUT", "/zones/"+zone.Zone, zone, nil)
+}
+
+// edgeDNSPollInterval is how often Edge DNS requests are polled
+var edgeDNSPollInterval = 10 * time.Second
+
+// seedEdgeDNSZone creates a primary zone with the records of an existing zone,
+// transferred from its masters. The zone is created as a secondary of the
+// masters and converted to a primary zone once the first transfer completes.
+//
+// Endpoint: POST /config-dns/v2/zones{?contractId,gid}
+// Endpoint: GET /config-dns/v2/zones/{zone}/recordsets
+// Endpoint: PUT /config-dns/v2/zones/{zone}
+func seedEdgeDNSZone(zone *edgeDNSZone, masters []string, contractID string, groupID string, timeout time.Duration) error {
+	secondary := *zone
+	secondary.Type = "SECONDARY"
+	secondary.Masters = masters
+	secondary.SignAndServe = false
+	if err := createEdgeDNSZone(&secondary, contractID, groupID); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Waiting for zone %s to be transferred from %s\n", zone.Zone, strings.Join(masters, ", "))
+	deadline := time.Now().Add(timeout)
+	for {
+		recordSets, err := getEdgeDNSRecordSets(zone.Zone)
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+		if hasSOARecord(recordSets) {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout after %s waiting for zone %s to be transferred from %s", timeout, zone.Zone, strings.Join(masters, ", "))
+		}
+		time.Sleep(edgeDNSPollInterval)
+	}
+
+	log.Printf("[DEBUG] Converting zone %s to %s\n", zone.Zone, zone.Type)
+	return updateEdgeDNSZone(zone)
+}
+
+func hasSOARecord(recordSets []*edgeDNSRecordSet) bool {
+	for _, recordSet := range recordSets {
+		if recordSet.Type == "SOA" {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteEdgeDNSZone submits a delete request for the zone and waits for it to
+// complete
+//
+// Endpoint: POST /config-dns/v2/zones/delete-requests
+// Endpoint: GET /config-dns/v2/zones/delete-requests/{requestId}
+// Endpoint: GET /config-dns/v2/zones/delete-requests/{requestId}/result
+func deleteEdgeDNSZone(zone string, timeout time.Duration) error {
+	log.Printf("[DEBUG] Deleting zone %s\n", zone)
+
+	submitted := struct {
+		RequestID string `json:"requestId"`
+	}{}
+	err := edgeDNSRequest("POST", "/zones/delete-requests", map[string]interface{}{
+		"zones": []string{zone},
+	}, &submitted)
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		status := struct {
+			IsComplete   bool `json:"isComplete"`
+			FailureCount int  `json:"failureCount"`
+		}{}
+		if err := edgeDNSRequest("GET", "/zones/delete-requests/"+submitted.RequestID, nil, &status); err != nil {
+			return err
+		}
+
+		if status.FailureCount > 0 {
+			result := struct {
+				FailureDeletingZones []struct {
+					Zone          string `json:"zone"`
+					FailureReason string `json:"failureReason"`
+				} `json:"failureDeletingZones"`
+			}{}
+			if err := edgeDNSRequest("GET", "/zones/delete-requests/"+submitted.RequestID+"/result", nil, &result); err != nil {
+				return fmt.Errorf("deleting zone %s failed, see delete request %s", zone, submitted.RequestID)
+			}
+			for _, failure := range result.FailureDeletingZones {
+				if failure.Zone == zone {
+					return fmt.Errorf("deleting zone %s failed: %s", zone, failure.FailureReason)
+				}
+			}
+			return fmt.Errorf("deleting zone %s failed, see delete request %s", zone, submitted.RequestID)
+		}
+		if status.IsComplete {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout after %s waiting for delete request %s of zone %s", timeout, submitted.RequestID, zone)
+		}
+		time.Sleep(edgeDNSPollInterval)
+	}
+}
+
+type edgeDNSRecordSet struct {
+	Name  string   `json:"name"`
+	Type  string   `json:"type"`
+	TTL   int      `json:"ttl"`
+	Rdata []string `json:"rdata"`
+}
+
+func edgeDNSRecordSetPath(zone string, name string, recordType string) string {
+	return fmt.Sprintf("/zones/%s/names/%s/types/%s", zone, name, recordType)
+}
+
+// getEdgeDNSRecordSet returns the record set, or nil if it does not exist
+//
+// Endpoint: GET /config-dns/v2/zones/{zone}/names/{name}/types/{type}
+func getEdgeDNSRecordSet(zone string, name string, recordType string) (*edgeDNSRecordSet, error) {
+	result := &edgeDNSRecordSet{}
+	if err := edgeDNSRequest("GET", edgeDNSRecordSetPath(zone, name, recordType), nil, result); err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// saveEdgeDNSRecordSet creates the record set, or replaces it if it exists
+//
+// Endpoint: POST /config-dns/v2/zones/{zone}/names/{name}/types/{type}
+// Endpoint: PUT /config-dns/v2/zones/{zone}/names/{name}/types/{type}
+func saveEdgeDNSRecordSet(zone string, recordSet *edgeDNSRecordSet, exists bool) error {
+	method := "POST"
+	if exists {
+		method = "PUT"
+	}
+
+	log.Printf("[DEBUG] Saving %s record set %s in zone %s\n", recordSet.Type, recordSet.Name, zone)
+	return edgeDNSRequest(method, edgeDNSRecordSetPath(zone, recordSet.Name, recordSet.Type), recordSet, nil)
+}
+
+// deleteEdgeDNSRecordSet deletes the record set
+//
+// Endpoint: DELETE /config-dns/v2/zones/{zone}/names/{name}/types/{type}
+func deleteEdgeDNSRecordSet(zone string, name string, recordType string) error {
+	log.Printf("[DEBUG] Deleting %s record set %s in zone %s\n", recordType, name, zone)
+
+	err := edgeDNSRequest("DELETE", edgeDNSRecordSetPath(zone, name, recordType), nil, nil)
+	if isNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+// getEdgeDNSRecordSets returns all record sets in the zone
+//
+// Endpoint: GET /config-dns/v2/zones/{zone}/recordsets?showAll=true
+func getEdgeDNSRecordSets(zone string) ([]*edgeDNSRecordSet, error) {
+	result := &struct {
+		RecordSets []*edgeDNSRecordSet `json:"recordsets"`
+	}{}
+	if err := edgeDNSRequest("GET", fmt.Sprintf("/zones/%s/recordsets?showAll=true", zone), nil, result); err != nil {
+		return nil, err
+	}
+
+	return result.RecordSets, nil
+}
+
+// getEdgeDNSAuthorities returns the authoritative nameservers assigned to the contract
+//
+// Endpoint: GET /config-dns/v2/data/authorities?contractIds={contractId}
+func getEdgeDNSAuthorities(contractID string) ([]string, error) {
+	result := &struct {
+		Contracts []struct {
+			ContractID  string   `json:"contractId"`
+			Authorities []string `json:"authorities"`
+		} `json:"contracts"`
+	}{}
+	if err := edgeDNSRequest("GET", "/data/authorities?contractIds="+edgeDNSContractID(contractID), nil, result); err != nil {
+		return nil, err
+	}
+
+	for _, contract := range result.Contracts {
+		if contract.ContractID == edgeDNSContractID(contractID) {
+			return contract.Authorities, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no nameservers found for contract %s", contractID)
+}
+
+type edgeDNSTSIGKey struct {
+	Name      string `json:"name"`
+	Algorithm string `json:"algorithm"`
+	Secret    string `json:"secret"`
+}
+
+// getEdgeDNSTSIGKey returns the TSIG key of the zone, or nil if it has none
+//
+// Endpoint: GET /config-dns/v2/zones/{zone}/key
+func getEdgeDNSTSIGKey(zone string) (*edgeDNSTSIGKey, error) {
+	result := &edgeDNSTSIGKey{}
+	if err := edgeDNSRequest("GET", fmt.Sprintf("/zones/%s/key", zone), nil, result); err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// saveEdgeDNSTSIGKey sets the TSIG key of the zone, replacing any existing key
+//
+// Endpoint: PUT /config-dns/v2/zones/{zone}/key
+func saveEdgeDNSTSIGKey(zone string, key *edgeDNSTSIGKey) error {
+	log.Printf("[DEBUG] Saving TSIG key %s for zone %s\n", key.Name, zone)
+	return edgeDNSRequest("PUT", fmt.Sprintf("/zones/%s/key", zone), key, nil)
+}
+
+// deleteEdgeDNSTSIGKey removes the TSIG key from the zone
+//
+// Endpoint: DELETE /config-dns/v2/zones/{zone}/key
+func deleteEdgeDNSTSIGKey(zone string) error {
+	log.Printf("[DEBUG] Deleting TSIG key for zone %s\n", zone)
+
+	err := edgeDNSRequest("DELETE", fmt.Sprintf("/zones/%s/key", zone), nil, nil)
+	if isNotFound(err) {
+		return nil
+	}
+
+	return err
+}
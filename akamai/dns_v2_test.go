@@ -0,0 +1,41 @@
+package akamai
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+)
+
+func TestEdgeDNSIDs(t *testing.T) {
+	if id := edgeDNSContractID("ctr_1-ABCD"); id != "1-ABCD" {
+		t.Errorf("expected 1-ABCD, got %s", id)
+	}
+	if id := edgeDNSContractID("1-ABCD"); id != "1-ABCD" {
+		t.Errorf("expected 1-ABCD, got %s", id)
+	}
+	if id := edgeDNSGroupID("grp_123"); id != "123" {
+		t.Errorf("expected 123, got %s", id)
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	if !isNotFound(client.APIError{Response: &http.Response{StatusCode: 404}}) {
+		t.Error("expected a 404 to be not found")
+	}
+	if isNotFound(client.APIError{Response: &http.Response{StatusCode: 403}}) {
+		t.Error("expected a 403 not to be not found")
+	}
+	if isNotFound(nil) {
+		t.Error("expected nil not to be not found")
+	}
+}
+
+func TestHasSOARecord(t *testing.T) {
+	if hasSOARecord([]*edgeDNSRecordSet{{Name: "example.com", Type: "NS"}}) {
+		t.Error("expected no SOA record")
+	}
+	if !hasSOARecord([]*edgeDNSRecordSet{{Name: "example.com", Type: "NS"}, {Name: "example.com", Type: "SOA"}}) {
+		t.Error("expected an SOA record")
+	}
+}
@@ -0,0 +1,153 @@
+package akamai
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Edge DNS creates a primary zone with default SOA and NS record sets at the
+// apex. akamai_dns_zone adopts them, so they aren't declared as akamai_dns_record
+// resources, and lets their settings be overridden.
+
+var dnsZoneSOASettings = []string{"refresh", "retry", "expire", "minimum"}
+
+func dnsZoneSOASchema() *schema.Schema {
+	soa := map[string]*schema.Schema{
+		// The zone administrator's email address in domain name form,
+		// e.g. hostmaster.example.com.
+		"contact": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"ttl": &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+			Computed: true,
+		},
+	}
+	for _, setting := range dnsZoneSOASettings {
+		soa[setting] = &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+			Computed: true,
+		}
+	}
+
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Computed: true,
+		MaxItems: 1,
+		Elem:     &schema.Resource{Schema: soa},
+	}
+}
+
+// saveDNSZoneApex updates the SOA and NS record sets of a primary zone with the
+// configured settings. Settings that aren't configured keep their value.
+func saveDNSZoneApex(d *schema.ResourceData) error {
+	dnsWriteLock.Lock()
+	defer dnsWriteLock.Unlock()
+
+	zone := d.Get("zone").(string)
+	if soa, ok := d.GetOk("soa.0"); ok {
+		recordSet, err := getEdgeDNSRecordSet(zone, zone, "SOA")
+		if err != nil {
+			return err
+		}
+		if recordSet == nil || len(recordSet.Rdata) != 1 {
+			return fmt.Errorf("zone %s has no SOA record", zone)
+		}
+
+		changed, err := overrideSOA(recordSet, soa.(map[string]interface{}))
+		if err != nil {
+			return err
+		}
+		if changed {
+			if err := saveEdgeDNSRecordSet(zone, recordSet, true); err != nil {
+				return err
+			}
+		}
+	}
+
+	if ttl, ok := d.GetOk("ns_ttl"); ok {
+		recordSet, err := getEdgeDNSRecordSet(zone, zone, "NS")
+		if err != nil {
+			return err
+		}
+		if recordSet == nil {
+			return fmt.Errorf("zone %s has no NS records", zone)
+		}
+
+		if recordSet.TTL != ttl.(int) {
+			recordSet.TTL = ttl.(int)
+			if err := saveEdgeDNSRecordSet(zone, recordSet, true); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// overrideSOA sets the non-zero settings in the SOA record set, and reports
+// whether it changed
+func overrideSOA(recordSet *edgeDNSRecordSet, settings map[string]interface{}) (bool, error) {
+	values, err := parseDNSRdata("SOA", recordSet.Rdata[0])
+	if err != nil {
+		return false, err
+	}
+
+	changed := false
+	if ttl, ok := settings["ttl"].(int); ok && ttl != 0 && ttl != recordSet.TTL {
+		recordSet.TTL = ttl
+		changed = true
+	}
+	if contact, ok := settings["contact"].(string); ok && contact != "" && contact != values["contact"] {
+		values["contact"] = contact
+		changed = true
+	}
+	for _, setting := range dnsZoneSOASettings {
+		if value, ok := settings[setting].(int); ok && value != 0 && value != values[setting] {
+			values[setting] = value
+			changed = true
+		}
+	}
+
+	recordSet.Rdata = []string{formatDNSRdata("SOA", values)}
+	return changed, nil
+}
+
+// readDNSZoneApex reads the SOA and NS settings of a primary zone
+func readDNSZoneApex(d *schema.ResourceData, zone string) error {
+	soa, err := getEdgeDNSRecordSet(zone, zone, "SOA")
+	if err != nil {
+		return err
+	}
+	if soa != nil && len(soa.Rdata) == 1 {
+		values, err := parseDNSRdata("SOA", soa.Rdata[0])
+		if err != nil {
+			return err
+		}
+
+		settings := map[string]interface{}{
+			"contact": values["contact"],
+			"ttl":     soa.TTL,
+		}
+		for _, setting := range dnsZoneSOASettings {
+			settings[setting] = values[setting]
+		}
+		d.Set("soa", []interface{}{settings})
+	}
+
+	ns, err := getEdgeDNSRecordSet(zone, zone, "NS")
+	if err != nil {
+		return err
+	}
+	if ns != nil {
+		d.Set("ns_ttl", ns.TTL)
+	}
+
+	return nil
+}
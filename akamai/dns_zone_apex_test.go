@@ -0,0 +1,33 @@
+package akamai
+
+import (
+	"testing"
+)
+
+func TestOverrideSOA(t *testing.T) {
+	recordSet := &edgeDNSRecordSet{
+		Name:  "example.com",
+		Type:  "SOA",
+		TTL:   86400,
+		Rdata: []string{"a1-49.akam.net. hostmaster.akamai.com. 2019010101 3600 600 604800 300"},
+	}
+
+	changed, err := overrideSOA(recordSet, map[string]interface{}{"contact": "hostmaster.example.com.", "ttl": 0, "refresh": 7200, "retry": 0, "expire": 604800, "minimum": 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Error("expected the SOA record to change")
+	}
+	if expected := "a1-49.akam.net. hostmaster.example.com. 2019010101 7200 600 604800 300"; recordSet.Rdata[0] != expected || recordSet.TTL != 86400 {
+		t.Errorf("expected %q with TTL 86400, got %q with TTL %d", expected, recordSet.Rdata[0], recordSet.TTL)
+	}
+
+	changed, err = overrideSOA(recordSet, map[string]interface{}{"refresh": 7200})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed {
+		t.Error("expected the SOA record not to change")
+	}
+}
@@ -0,0 +1,283 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+)
+
+// Edge Hostname API (HAPI)
+//
+// PAPI can only create edge hostnames, their other settings are changed with
+// HAPI using the PAPI credentials, where the DNS zone is the domain suffix and
+// the record name the domain prefix.
+//
+// https://developer.akamai.com/api/core_features/edge_hostnames/v1.html
+
+type hapiPatch struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value string `json:"value"`
+}
+
+// setEdgeHostnameTTL submits a change of the edge hostname TTL, which is applied
+// asynchronously
+//
+// Endpoint: PATCH /hapi/v1/dns-zones/{dnsZone}/edge-hostnames/{recordName}
+func setEdgeHostnameTTL(domainPrefix string, domainSuffix string, ttl int) error {
+	log.Printf("[DEBUG] Setting TTL of edge hostname %s.%s to %d\n", domainPrefix, domainSuffix, ttl)
+
+	_, err := patchEdgeHostname(domainPrefix, domainSuffix, []hapiPatch{
+		{Op: "replace", Path: "/ttl", Value: strconv.Itoa(ttl)},
+	}, "")
+	return err
+}
+
+// hapiEdgeHostname is the part of a HAPI edge hostname PAPI does not return
+type hapiEdgeHostname struct {
+	TTL               int    `json:"ttl"`
+	UseDefaultTTL     bool   `json:"useDefaultTtl"`
+	IPVersionBehavior string `json:"ipVersionBehavior"`
+	Comments          string `json:"comments"`
+	ChinaCDN          struct {
+		IsChinaCDN        bool   `json:"isChinaCdn"`
+		CustomChinaCDNMap string `json:"customChinaCdnMap"`
+	} `json:"chinaCdn"`
+}
+
+// getHAPIEdgeHostname returns the HAPI settings of an edge hostname, or nil if
+// HAPI does not know it yet, as is the case shortly after it is created
+//
+// Endpoint: GET /hapi/v1/dns-zones/{dnsZone}/edge-hostnames/{recordName}
+func getHAPIEdgeHostname(domainPrefix string, domainSuffix string) (*hapiEdgeHostname, error) {
+	req, err := client.NewRequest(
+		papi.Config,
+		"GET",
+		fmt.Sprintf("/hapi/v1/dns-zones/%s/edge-hostnames/%s", domainSuffix, domainPrefix),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.IsError(res) {
+		if res.StatusCode == 404 {
+			log.Printf("[DEBUG] Edge hostname %s.%s not found in HAPI\n", domainPrefix, domainSuffix)
+			return nil, nil
+		}
+		return nil, client.NewAPIError(res)
+	}
+
+	edgeHostname := &hapiEdgeHostname{}
+	if err := client.BodyJSON(res, edgeHostname); err != nil {
+		return nil, err
+	}
+
+	return edgeHostname, nil
+}
+
+// hapiCertificate is the Enhanced TLS certificate of an edge hostname
+type hapiCertificate struct {
+	CertificateID string `json:"certificateId"`
+	SlotNumber    int    `json:"slotNumber"`
+	Status        string `json:"status"`
+}
+
+// EnrollmentID returns the CPS enrollment of the certificate, or 0 if it is not
+// a CPS certificate
+func (certificate *hapiCertificate) EnrollmentID() int {
+	id, err := strconv.Atoi(certificate.CertificateID)
+	if err != nil {
+		return 0
+	}
+
+	return id
+}
+
+// getEdgeHostnameCertificate returns the Enhanced TLS certificate of an edge
+// hostname, or nil if it has none
+//
+// Endpoint: GET /hapi/v1/dns-zones/{dnsZone}/edge-hostnames/{recordName}/certificate
+func getEdgeHostnameCertificate(domainPrefix string, domainSuffix string) (*hapiCertificate, error) {
+	req, err := client.NewRequest(
+		papi.Config,
+		"GET",
+		fmt.Sprintf("/hapi/v1/dns-zones/%s/edge-hostnames/%s/certificate", domainSuffix, domainPrefix),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.IsError(res) {
+		if res.StatusCode == 404 {
+			log.Printf("[DEBUG] Edge hostname %s.%s has no certificate\n", domainPrefix, domainSuffix)
+			return nil, nil
+		}
+		return nil, client.NewAPIError(res)
+	}
+
+	certificate := &hapiCertificate{}
+	if err := client.BodyJSON(res, certificate); err != nil {
+		return nil, err
+	}
+
+	return certificate, nil
+}
+
+// deleteEdgeHostname submits the deletion of an edge hostname, which is applied
+// asynchronously
+//
+// Endpoint: DELETE /hapi/v1/dns-zones/{dnsZone}/edge-hostnames/{recordName}
+func deleteEdgeHostname(domainPrefix string, domainSuffix string, comments string) error {
+	query := ""
+	if comments != "" {
+		query = "?comments=" + url.QueryEscape(comments)
+	}
+
+	req, err := client.NewRequest(
+		papi.Config,
+		"DELETE",
+		fmt.Sprintf("/hapi/v1/dns-zones/%s/edge-hostnames/%s%s", domainSuffix, domainPrefix, query),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return err
+	}
+
+	if client.IsError(res) {
+		return client.NewAPIError(res)
+	}
+
+	return nil
+}
+
+// hapiChangeRequest is a change to an edge hostname, which is applied asynchronously
+type hapiChangeRequest struct {
+	ChangeID      int    `json:"changeId"`
+	Status        string `json:"status"`
+	StatusMessage string `json:"statusMessage"`
+}
+
+var edgeHostnameChangePollInterval = 30 * time.Second
+
+// patchEdgeHostname submits changes to an edge hostname, recording the comments
+// with the change request if given
+func patchEdgeHostname(domainPrefix string, domainSuffix string, patches []hapiPatch, comments string) (*hapiChangeRequest, error) {
+	query := ""
+	if comments != "" {
+		query = "?comments=" + url.QueryEscape(comments)
+	}
+
+	req, err := client.NewJSONRequest(
+		papi.Config,
+		"PATCH",
+		fmt.Sprintf("/hapi/v1/dns-zones/%s/edge-hostnames/%s%s", domainSuffix, domainPrefix, query),
+		patches,
+	)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json-patch+json")
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	change := &hapiChangeRequest{}
+	if err := client.BodyJSON(res, change); err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+// getEdgeHostnameChange returns the status of a change to an edge hostname
+//
+// Endpoint: GET /hapi/v1/change-requests/{changeId}
+func getEdgeHostnameChange(changeID int) (*hapiChangeRequest, error) {
+	req, err := client.NewRequest(
+		papi.Config,
+		"GET",
+		fmt.Sprintf("/hapi/v1/change-requests/%d", changeID),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	change := &hapiChangeRequest{}
+	if err := client.BodyJSON(res, change); err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+// waitForEdgeHostnameChange waits for a change to an edge hostname to be applied,
+// so that it is read back rather than the previous settings
+func waitForEdgeHostnameChange(change *hapiChangeRequest, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		log.Printf("[DEBUG] Edge hostname change %d is %s\n", change.ChangeID, change.Status)
+		if done, err := edgeHostnameChangeDone(change); done || err != nil {
+			return err
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout after %s waiting for edge hostname change %d (status: %s)", timeout, change.ChangeID, change.Status)
+		}
+		time.Sleep(edgeHostnameChangePollInterval)
+
+		var err error
+		if change, err = getEdgeHostnameChange(change.ChangeID); err != nil {
+			return err
+		}
+	}
+}
+
+// edgeHostnameChangeDone reports whether the change was applied, or an error if it failed
+func edgeHostnameChangeDone(change *hapiChangeRequest) (bool, error) {
+	switch change.Status {
+	case "SUCCEEDED":
+		return true, nil
+	case "FAILED":
+		return true, fmt.Errorf("edge hostname change %d failed: %s", change.ChangeID, change.StatusMessage)
+	}
+
+	return false, nil
+}
@@ -0,0 +1,185 @@
+package akamai
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// Edge hostname use cases
+//
+// Use cases, e.g. Download_Mode with BACKGROUND or FOREGROUND, tune the mapping
+// of download delivery and media products. The edgegrid client does not
+// support them, so edge hostnames with use cases are saved and read directly.
+//
+// https://developer.akamai.com/api/luna/papi/data.html#usecase
+
+var akpsEdgeHostnameUseCases = &schema.Schema{
+	Type:     schema.TypeList,
+	Optional: true,
+	Computed: true,
+	ForceNew: true,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"use_case": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"option": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "GLOBAL",
+				ValidateFunc: validation.StringInSlice([]string{"GLOBAL"}, false),
+			},
+		},
+	},
+}
+
+type edgeHostnameUseCase struct {
+	UseCase string `json:"useCase"`
+	Option  string `json:"option"`
+	Type    string `json:"type"`
+}
+
+func expandEdgeHostnameUseCases(useCases []interface{}) []edgeHostnameUseCase {
+	var expanded []edgeHostnameUseCase
+	for _, v := range useCases {
+		useCase := v.(map[string]interface{})
+		expanded = append(expanded, edgeHostnameUseCase{
+			UseCase: useCase["use_case"].(string),
+			Option:  useCase["option"].(string),
+			Type:    useCase["type"].(string),
+		})
+	}
+
+	return expanded
+}
+
+func flattenEdgeHostnameUseCases(useCases []edgeHostnameUseCase) []interface{} {
+	var flattened []interface{}
+	for _, useCase := range useCases {
+		flattened = append(flattened, map[string]interface{}{
+			"use_case": useCase.UseCase,
+			"option":   useCase.Option,
+			"type":     useCase.Type,
+		})
+	}
+
+	return flattened
+}
+
+// saveEdgeHostnameWithUseCases creates an edge hostname like EdgeHostname.Save,
+// including its use cases
+//
+// Endpoint: POST /papi/v1/edgehostnames/{?contractId,groupId}
+func saveEdgeHostnameWithUseCases(edgeHostnames *papi.EdgeHostnames, edgeHostname *papi.EdgeHostname, useCases []edgeHostnameUseCase) error {
+	body := client.JSONBody{
+		"productId":         edgeHostname.ProductID,
+		"domainPrefix":      edgeHostname.DomainPrefix,
+		"domainSuffix":      edgeHostname.DomainSuffix,
+		"secure":            edgeHostname.Secure,
+		"ipVersionBehavior": edgeHostname.IPVersionBehavior,
+		"useCases":          useCases,
+	}
+	if edgeHostname.CertEnrollmentId != 0 {
+		body["certEnrollmentId"] = edgeHostname.CertEnrollmentId
+	}
+	if edgeHostname.SlotNumber != 0 {
+		body["slotNumber"] = edgeHostname.SlotNumber
+	}
+
+	req, err := client.NewJSONRequest(
+		papi.Config,
+		"POST",
+		fmt.Sprintf("/papi/v1/edgehostnames?contractId=%s&groupId=%s", edgeHostnames.ContractID, edgeHostnames.GroupID),
+		body,
+	)
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return err
+	}
+
+	if client.IsError(res) {
+		return client.NewAPIError(res)
+	}
+
+	var location client.JSONBody
+	if err = client.BodyJSON(res, &location); err != nil {
+		return err
+	}
+
+	link, _ := location["edgeHostnameLink"].(string)
+	u, err := url.Parse(link)
+	if err != nil {
+		return err
+	}
+	for _, part := range strings.Split(u.Path, "/") {
+		if strings.HasPrefix(part, "ehn_") {
+			edgeHostname.EdgeHostnameID = part
+		}
+	}
+	if edgeHostname.EdgeHostnameID == "" {
+		return fmt.Errorf("unexpected edge hostname link %q", link)
+	}
+
+	edgeHostnames.AddEdgeHostname(edgeHostname)
+
+	return nil
+}
+
+// getEdgeHostnameUseCases returns the use cases of an edge hostname
+//
+// Endpoint: GET /papi/v1/edgehostnames/{edgeHostnameId}{?contractId,groupId}
+func getEdgeHostnameUseCases(edgeHostnames *papi.EdgeHostnames, edgeHostnameID string) ([]edgeHostnameUseCase, error) {
+	req, err := client.NewRequest(
+		papi.Config,
+		"GET",
+		fmt.Sprintf("/papi/v1/edgehostnames/%s?contractId=%s&groupId=%s", edgeHostnameID, edgeHostnames.ContractID, edgeHostnames.GroupID),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	var response struct {
+		EdgeHostnames struct {
+			Items []struct {
+				UseCases []edgeHostnameUseCase `json:"useCases"`
+			} `json:"items"`
+		} `json:"edgeHostnames"`
+	}
+	if err := client.BodyJSON(res, &response); err != nil {
+		return nil, err
+	}
+
+	if len(response.EdgeHostnames.Items) == 0 {
+		return nil, nil
+	}
+
+	return response.EdgeHostnames.Items[0].UseCases, nil
+}
@@ -0,0 +1,26 @@
+package akamai
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestEdgeHostnameUseCases(t *testing.T) {
+	useCases := []interface{}{
+		map[string]interface{}{"use_case": "Download_Mode", "option": "BACKGROUND", "type": "GLOBAL"},
+	}
+
+	expanded := expandEdgeHostnameUseCases(useCases)
+	body, err := json.Marshal(expanded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != `[{"useCase":"Download_Mode","option":"BACKGROUND","type":"GLOBAL"}]` {
+		t.Fatalf("unexpected use cases %s", body)
+	}
+
+	if flattened := flattenEdgeHostnameUseCases(expanded); !reflect.DeepEqual(flattened, useCases) {
+		t.Fatalf("expected %v, got %v", useCases, flattened)
+	}
+}
@@ -0,0 +1,296 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Global Traffic Management
+//
+// The edgegrid client has no GTM package, so the Config GTM v1 endpoints are
+// called directly with the gtm_section credentials.
+//
+// https://developer.akamai.com/api/web_performance/global_traffic_management/v1.html
+
+var gtmConfig edgegrid.Config
+
+// gtmRequest calls the GTM API, decoding the response into result if it is not
+// nil. Errors are client.APIError, see isNotFound.
+func gtmRequest(method string, path string, body interface{}, result interface{}) error {
+	var req *http.Request
+	var err error
+	if body != nil {
+		req, err = client.NewJSONRequest(gtmConfig, method, "/config-gtm/v1"+path, body)
+	} else {
+		req, err = client.NewRequest(gtmConfig, method, "/config-gtm/v1"+path, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Do(gtmConfig, req)
+	if err != nil {
+		return err
+	}
+
+	if client.IsError(res) {
+		return client.NewAPIError(res)
+	}
+
+	if result == nil || res.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	return client.BodyJSON(res, result)
+}
+
+// gtmID returns the ID of a GTM domain object, <domain>:<name>
+func gtmID(domain string, name string) string {
+	return domain + ":" + name
+}
+
+func parseGTMID(id string) (string, string, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid ID %q, expected <domain>:<name>", id)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// gtmStatus is the status of a change to a domain
+type gtmStatus struct {
+	ChangeID              string `json:"changeId"`
+	Message               string `json:"message"`
+	PassingValidation     bool   `json:"passingValidation"`
+	PropagationStatus     string `json:"propagationStatus"`
+	PropagationStatusDate string `json:"propagationStatusDate"`
+}
+
+// gtmWaitOnComplete is shared by the GTM resources. Changes to a domain take a
+// few minutes to propagate to the GTM nameservers.
+var gtmWaitOnComplete = &schema.Schema{
+	Type:     schema.TypeBool,
+	Optional: true,
+	Default:  true,
+}
+
+// gtmPollInterval is how often the status of a GTM domain is polled
+var gtmPollInterval = 15 * time.Second
+
+// getGTMDomainStatus returns the status of the latest change to the domain
+//
+// Endpoint: GET /config-gtm/v1/domains/{domain}/status/current
+func getGTMDomainStatus(domain string) (*gtmStatus, error) {
+	result := &gtmStatus{}
+	if err := gtmRequest("GET", fmt.Sprintf("/domains/%s/status/current", domain), nil, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// waitForGTMPropagation waits for the changes to the domain to propagate, if
+// wait_on_complete is set
+func waitForGTMPropagation(d *schema.ResourceData, domain string, timeout time.Duration) error {
+	if !d.Get("wait_on_complete").(bool) {
+		log.Printf("[INFO] Not waiting for the changes to GTM domain %s to propagate\n", domain)
+		return nil
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		status, err := getGTMDomainStatus(domain)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("[DEBUG] GTM domain %s change %s is %s\n", domain, status.ChangeID, status.PropagationStatus)
+		if done, err := gtmPropagated(domain, status); done || err != nil {
+			return err
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout after %s waiting for change %s to GTM domain %s to propagate (status: %s)", timeout, status.ChangeID, domain, status.PropagationStatus)
+		}
+		time.Sleep(gtmPollInterval)
+	}
+}
+
+// gtmPropagated reports whether the change has propagated, or an error if it was denied
+func gtmPropagated(domain string, status *gtmStatus) (bool, error) {
+	switch status.PropagationStatus {
+	case "COMPLETE":
+		return true, nil
+	case "DENIED":
+		return true, fmt.Errorf("change %s to GTM domain %s was denied: %s", status.ChangeID, domain, status.Message)
+	}
+
+	return false, nil
+}
+
+type gtmResourceInstance struct {
+	DatacenterID         int      `json:"datacenterId"`
+	UseDefaultLoadObject bool     `json:"useDefaultLoadObject"`
+	LoadObject           string   `json:"loadObject,omitempty"`
+	LoadObjectPort       int      `json:"loadObjectPort,omitempty"`
+	LoadServers          []string `json:"loadServers"`
+}
+
+type gtmResource struct {
+	Name                        string                 `json:"name"`
+	Type                        string                 `json:"type"`
+	Description                 string                 `json:"description,omitempty"`
+	AggregationType             string                 `json:"aggregationType"`
+	ConstrainedProperty         string                 `json:"constrainedProperty,omitempty"`
+	HostHeader                  string                 `json:"hostHeader,omitempty"`
+	LeastSquaresDecay           float64                `json:"leastSquaresDecay,omitempty"`
+	LoadImbalancePercentage     float64                `json:"loadImbalancePercentage,omitempty"`
+	MaxUMultiplicativeIncrement float64                `json:"maxUMultiplicativeIncrement,omitempty"`
+	DecayRate                   float64                `json:"decayRate,omitempty"`
+	UpperBound                  int                    `json:"upperBound,omitempty"`
+	ResourceInstances           []*gtmResourceInstance `json:"resourceInstances"`
+}
+
+// getGTMResource returns the resource, or nil if it does not exist
+//
+// Endpoint: GET /config-gtm/v1/domains/{domain}/resources/{resource}
+func getGTMResource(domain string, name string) (*gtmResource, error) {
+	result := &gtmResource{}
+	if err := gtmRequest("GET", fmt.Sprintf("/domains/%s/resources/%s", domain, name), nil, result); err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// saveGTMResource creates or replaces the resource
+//
+// Endpoint: PUT /config-gtm/v1/domains/{domain}/resources/{resource}
+func saveGTMResource(domain string, resource *gtmResource) (*gtmStatus, error) {
+	result := &struct {
+		Status *gtmStatus `json:"status"`
+	}{}
+	if err := gtmRequest("PUT", fmt.Sprintf("/domains/%s/resources/%s", domain, resource.Name), resource, result); err != nil {
+		return nil, err
+	}
+
+	return result.Status, nil
+}
+
+// deleteGTMResource deletes the resource
+//
+// Endpoint: DELETE /config-gtm/v1/domains/{domain}/resources/{resource}
+func deleteGTMResource(domain string, name string) (*gtmStatus, error) {
+	result := &struct {
+		Status *gtmStatus `json:"status"`
+	}{}
+	if err := gtmRequest("DELETE", fmt.Sprintf("/domains/%s/resources/%s", domain, name), nil, result); err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return result.Status, nil
+}
+
+type gtmDatacenter struct {
+	DatacenterID    int     `json:"datacenterId"`
+	Nickname        string  `json:"nickname"`
+	City            string  `json:"city,omitempty"`
+	StateOrProvince string  `json:"stateOrProvince,omitempty"`
+	Country         string  `json:"country,omitempty"`
+	Continent       string  `json:"continent,omitempty"`
+	Latitude        float64 `json:"latitude,omitempty"`
+	Longitude       float64 `json:"longitude,omitempty"`
+	Virtual         bool    `json:"virtual"`
+}
+
+// gtmDefaultDatacenterID is the ID of the datacenter that geographic, CIDR and
+// AS maps hand out for requests not assigned by the map
+const gtmDefaultDatacenterID = 5400
+
+// getGTMDatacenter returns the datacenter, or nil if it does not exist
+//
+// Endpoint: GET /config-gtm/v1/domains/{domain}/datacenters/{datacenterId}
+func getGTMDatacenter(domain string, id int) (*gtmDatacenter, error) {
+	result := &gtmDatacenter{}
+	if err := gtmRequest("GET", fmt.Sprintf("/domains/%s/datacenters/%d", domain, id), nil, result); err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// getGTMDefaultDatacenter returns the default datacenter for maps, which is
+// created the first time it is needed
+//
+// Endpoint: POST /config-gtm/v1/domains/{domain}/datacenters/default-datacenter-for-maps
+func getGTMDefaultDatacenter(domain string) (*gtmDatacenter, error) {
+	datacenter, err := getGTMDatacenter(domain, gtmDefaultDatacenterID)
+	if err != nil || datacenter != nil {
+		return datacenter, err
+	}
+
+	result := &struct {
+		Resource *gtmDatacenter `json:"resource"`
+	}{}
+	if err := gtmRequest("POST", fmt.Sprintf("/domains/%s/datacenters/default-datacenter-for-maps", domain), map[string]interface{}{}, result); err != nil {
+		return nil, err
+	}
+
+	return result.Resource, nil
+}
+
+type gtmDomain struct {
+	Name                    string           `json:"name"`
+	Type                    string           `json:"type"`
+	LoadImbalancePercentage float64          `json:"loadImbalancePercentage"`
+	Datacenters             []*gtmDatacenter `json:"datacenters"`
+	Properties              []struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	} `json:"properties"`
+	Resources []*gtmResource `json:"resources"`
+	Status    *gtmStatus     `json:"status"`
+}
+
+// getGTMDomain returns the domain with its datacenters, properties and resources
+//
+// Endpoint: GET /config-gtm/v1/domains/{domain}
+func getGTMDomain(domain string) (*gtmDomain, error) {
+	result := &gtmDomain{}
+	if err := gtmRequest("GET", "/domains/"+domain, nil, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// getGTMDatacenters returns the datacenters of the domain
+//
+// Endpoint: GET /config-gtm/v1/domains/{domain}/datacenters
+func getGTMDatacenters(domain string) ([]*gtmDatacenter, error) {
+	result := &struct {
+		Items []*gtmDatacenter `json:"items"`
+	}{}
+	if err := gtmRequest("GET", fmt.Sprintf("/domains/%s/datacenters", domain), nil, result); err != nil {
+		return nil, err
+	}
+
+	return result.Items, nil
+}
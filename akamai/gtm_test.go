@@ -0,0 +1,33 @@
+package akamai
+
+import (
+	"testing"
+)
+
+func TestParseGTMID(t *testing.T) {
+	domain, name, err := parseGTMID(gtmID("example.akadns.net", "origin:load"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if domain != "example.akadns.net" || name != "origin:load" {
+		t.Errorf("expected example.akadns.net and origin:load, got %s and %s", domain, name)
+	}
+
+	for _, id := range []string{"example.akadns.net", "example.akadns.net:", ":origin"} {
+		if _, _, err := parseGTMID(id); err == nil {
+			t.Errorf("expected an error parsing %q", id)
+		}
+	}
+}
+
+func TestGTMPropagated(t *testing.T) {
+	if done, err := gtmPropagated("example.akadns.net", &gtmStatus{PropagationStatus: "PENDING"}); done || err != nil {
+		t.Errorf("expected a pending change not to be done, got %t, %v", done, err)
+	}
+	if done, err := gtmPropagated("example.akadns.net", &gtmStatus{PropagationStatus: "COMPLETE"}); !done || err != nil {
+		t.Errorf("expected a complete change to be done, got %t, %v", done, err)
+	}
+	if done, err := gtmPropagated("example.akadns.net", &gtmStatus{ChangeID: "abc", PropagationStatus: "DENIED", Message: "invalid"}); !done || err == nil {
+		t.Errorf("expected a denied change to fail, got %t, %v", done, err)
+	}
+}
@@ -0,0 +1,634 @@
+package akamai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// Property activations
+//
+// papi.Activation.Save() only sends a compliance record reason, so activations
+// are submitted here with the full request body.
+//
+// https://developer.akamai.com/api/luna/papi/resources.html#activateaproperty
+var akpsComplianceRecord = &schema.Schema{
+	Type:     schema.TypeSet,
+	Optional: true,
+	MaxItems: 1,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"noncompliance_reason": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"NONE", "OTHER", "NO_PRODUCTION_TRAFFIC", "EMERGENCY"}, false),
+			},
+			"other_noncompliance_reason": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ticket_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"customer_email": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"peer_reviewed_by": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"unit_tested": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	},
+}
+
+// What to do when an activation is still pending on the network
+var akpsOnPendingActivation = &schema.Schema{
+	Type:         schema.TypeString,
+	Optional:     true,
+	Default:      "fail",
+	ValidateFunc: validation.StringInSlice([]string{"wait", "cancel", "fail"}, false),
+}
+
+type activationComplianceRecord struct {
+	NoncomplianceReason      string `json:"noncomplianceReason"`
+	OtherNoncomplianceReason string `json:"otherNoncomplianceReason,omitempty"`
+	TicketID                 string `json:"ticketId,omitempty"`
+	CustomerEmail            string `json:"customerEmail,omitempty"`
+	PeerReviewedBy           string `json:"peerReviewedBy,omitempty"`
+	UnitTested               bool   `json:"unitTested"`
+}
+
+// activationRequest overrides the compliance record of the embedded papi.Activation
+// and adds fast fallback. Warnings holds the warnings acknowledged by saveActivation.
+type activationRequest struct {
+	*papi.Activation
+	ComplianceRecord *activationComplianceRecord `json:"complianceRecord,omitempty"`
+	UseFastFallback  bool                        `json:"useFastFallback,omitempty"`
+	Warnings         []string                    `json:"-"`
+}
+
+func getComplianceRecord(d *schema.ResourceData) *activationComplianceRecord {
+	record, ok := d.GetOk("compliance_record")
+	if !ok || record.(*schema.Set).Len() == 0 {
+		return &activationComplianceRecord{NoncomplianceReason: "NO_PRODUCTION_TRAFFIC"}
+	}
+
+	config := record.(*schema.Set).List()[0].(map[string]interface{})
+	return &activationComplianceRecord{
+		NoncomplianceReason:      config["noncompliance_reason"].(string),
+		OtherNoncomplianceReason: config["other_noncompliance_reason"].(string),
+		TicketID:                 config["ticket_id"].(string),
+		CustomerEmail:            config["customer_email"].(string),
+		PeerReviewedBy:           config["peer_reviewed_by"].(string),
+		UnitTested:               config["unit_tested"].(bool),
+	}
+}
+
+// getAcceptableWarnings returns the warning message IDs that may be acknowledged,
+// nil meaning that all warnings are acceptable
+func getAcceptableWarnings(d *schema.ResourceData) map[string]bool {
+	warnings, ok := d.GetOk("acceptable_rule_warnings")
+	if !ok || warnings.(*schema.Set).Len() == 0 {
+		return nil
+	}
+
+	acceptable := make(map[string]bool)
+	for _, messageID := range setToStringSlice(warnings.(*schema.Set)) {
+		acceptable[messageID] = true
+	}
+
+	return acceptable
+}
+
+// saveActivation submits the activation, acknowledging any warnings if
+// acknowledgeWarnings is true and every warning is in acceptableWarnings
+// (when set), and populates it from the created activation.
+//
+// Endpoint: POST /papi/v1/properties/{propertyId}/activations/{?contractId,groupId}
+func saveActivation(property *papi.Property, request *activationRequest, acknowledgeWarnings bool, acceptableWarnings map[string]bool) error {
+	activation := request.Activation
+
+	req, err := client.NewJSONRequest(
+		papi.Config,
+		"POST",
+		fmt.Sprintf(
+			"/papi/v1/properties/%s/activations?contractId=%s&groupId=%s",
+			property.PropertyID,
+			property.ContractID,
+			property.GroupID,
+		),
+		request,
+	)
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return err
+	}
+
+	if client.IsError(res) {
+		body, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return err
+		}
+
+		if res.StatusCode != 400 || !acknowledgeWarnings {
+			return client.NewAPIErrorFromBody(res, body)
+		}
+
+		warnings := &struct {
+			Warnings []struct {
+				Detail    string `json:"detail"`
+				MessageID string `json:"messageId"`
+			} `json:"warnings,omitempty"`
+		}{}
+		if err = json.Unmarshal(body, warnings); err != nil || len(warnings.Warnings) == 0 {
+			// Just in case we got a 400 for a different reason
+			return client.NewAPIErrorFromBody(res, body)
+		}
+
+		var unexpected []string
+		for _, warning := range warnings.Warnings {
+			if acceptableWarnings != nil && !acceptableWarnings[warning.MessageID] {
+				unexpected = append(unexpected, fmt.Sprintf("%s: %s", warning.MessageID, warning.Detail))
+			}
+		}
+		if len(unexpected) > 0 {
+			return fmt.Errorf("activation of %s v%d has warnings that are not in acceptable_rule_warnings:\n%s", property.PropertyID, activation.PropertyVersion, strings.Join(unexpected, "\n"))
+		}
+
+		for _, warning := range warnings.Warnings {
+			log.Printf("[DEBUG] Acknowledging activation warning %s: %s\n", warning.MessageID, warning.Detail)
+			activation.AcknowledgeWarnings = append(activation.AcknowledgeWarnings, warning.MessageID)
+			request.Warnings = append(request.Warnings, fmt.Sprintf("%s: %s", warning.MessageID, warning.Detail))
+		}
+
+		// Don't acknowledge warnings again, halting a potential endless recursion
+		return saveActivation(property, request, false, nil)
+	}
+
+	var location client.JSONBody
+	if err = client.BodyJSON(res, &location); err != nil {
+		return err
+	}
+
+	link, ok := location["activationLink"].(string)
+	if !ok {
+		return fmt.Errorf("activation of %s v%d did not return an activation link", property.PropertyID, activation.PropertyVersion)
+	}
+
+	activationURL, err := url.Parse(link)
+	if err != nil {
+		return err
+	}
+	for _, part := range strings.Split(activationURL.Path, "/") {
+		if strings.HasPrefix(part, "atv_") {
+			activation.ActivationID = part
+		}
+	}
+
+	_, err = activation.GetActivation(property)
+	return err
+}
+
+// activationPollInterval is how often the status of a (de)activation is polled
+var activationPollInterval = 30 * time.Second
+
+// waitForActivation polls the (de)activation until it is active, failing once
+// the timeout has elapsed or the (de)activation has failed
+func waitForActivation(property *papi.Property, activation *papi.Activation, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		if _, err := activation.GetActivation(property); err != nil {
+			return err
+		}
+		log.Printf("[DEBUG] Property Status: %s\n", activation.Status)
+
+		if activation.Status == papi.StatusActive {
+			return nil
+		}
+		if failedActivationStatuses[activation.Status] {
+			return activationFailures.record(activationFailedError(property, activation))
+		}
+
+		if time.Now().After(deadline) {
+			return activationFailures.record(fmt.Errorf("timeout after %s waiting for %s %s of %s v%d on %s (status: %s)", timeout, strings.ToLower(string(activation.ActivationType)), activation.ActivationID, property.PropertyID, activation.PropertyVersion, activation.Network, activation.Status))
+		}
+
+		select {
+		case <-time.After(activationPollInterval):
+		case <-activationFailures.aborted:
+			return fmt.Errorf("stopped waiting for %s %s of %s v%d on %s, which continues in the background: %s", strings.ToLower(string(activation.ActivationType)), activation.ActivationID, property.PropertyID, activation.PropertyVersion, activation.Network, activationFailures.err())
+		}
+	}
+}
+
+// activationFailureTracker implements activation_failure_mode. With fail_fast, the first
+// failed (de)activation aborts the (de)activations submitted or awaited after it in the
+// same run. With best_effort, the default, they carry on and Terraform reports every
+// error at the end of the apply.
+type activationFailureTracker struct {
+	failFast bool
+	once     sync.Once
+	aborted  chan struct{}
+	first    error
+}
+
+var activationFailures = newActivationFailureTracker(false)
+
+func newActivationFailureTracker(failFast bool) *activationFailureTracker {
+	return &activationFailureTracker{failFast: failFast, aborted: make(chan struct{})}
+}
+
+// record returns err, first aborting the other activations with fail_fast
+func (t *activationFailureTracker) record(err error) error {
+	if err == nil || !t.failFast {
+		return err
+	}
+
+	t.once.Do(func() {
+		t.first = err
+		close(t.aborted)
+	})
+
+	return err
+}
+
+// err returns an error once an activation has failed with fail_fast
+func (t *activationFailureTracker) err() error {
+	select {
+	case <-t.aborted:
+		return fmt.Errorf("activation_failure_mode is fail_fast and an earlier activation failed: %s", t.first)
+	default:
+		return nil
+	}
+}
+
+// estimateActivationDuration averages how long the most recent successful
+// activations on the network took, from submission to the last update
+func estimateActivationDuration(activations *papi.Activations, network papi.NetworkValue, samples int) (time.Duration, int) {
+	var durations []time.Duration
+	for _, activation := range activations.Activations.Items {
+		if activation.Network != network || activation.Status != papi.StatusActive || activation.ActivationType != papi.ActivationTypeActivate {
+			continue
+		}
+
+		submitted, err := time.Parse(time.RFC3339, activation.SubmitDate)
+		if err != nil {
+			continue
+		}
+		updated, err := time.Parse(time.RFC3339, activation.UpdateDate)
+		if err != nil || updated.Before(submitted) {
+			continue
+		}
+
+		durations = append(durations, updated.Sub(submitted))
+	}
+
+	// activations are listed newest first
+	if len(durations) > samples {
+		durations = durations[:samples]
+	}
+	if len(durations) == 0 {
+		return 0, 0
+	}
+
+	var total time.Duration
+	for _, duration := range durations {
+		total += duration
+	}
+
+	return (total / time.Duration(len(durations))).Round(time.Minute), len(durations)
+}
+
+// resourcePropertyEstimateActivation logs a warning with the expected duration
+// when the plan will activate an existing property on production
+func resourcePropertyEstimateActivation(d *schema.ResourceDiff) {
+	if changed := d.GetChangedKeysPrefix(""); d.Id() == "" || !d.Get("activate").(bool) || len(changed) == 0 || onlyDestroySettingsChanged(changed) {
+		return
+	}
+
+	network := papi.NetworkValue(strings.ToUpper(d.Get("network").(string)))
+	if network != papi.NetworkProduction {
+		return
+	}
+
+	property := papi.NewProperty(papi.NewProperties())
+	property.PropertyID = d.Id()
+	property.Contract = &papi.Contract{ContractID: d.Get("contract_id").(string)}
+	property.Group = &papi.Group{GroupID: d.Get("group_id").(string)}
+
+	activations, err := property.GetActivations()
+	if err != nil {
+		log.Printf("[WARN] %s will be activated on %s; unable to estimate the activation duration: %s", property.PropertyID, network, err)
+		return
+	}
+
+	estimate, samples := estimateActivationDuration(activations, network, 5)
+	if samples == 0 {
+		log.Printf("[WARN] %s will be activated on %s; no previous activations to estimate the duration from", property.PropertyID, network)
+		return
+	}
+
+	log.Printf("[WARN] %s will be activated on %s; based on the last %d activations this is expected to take about %s", property.PropertyID, network, samples, estimate)
+}
+
+// destroySettings are only used when the resource is destroyed, so changing them
+// only updates the state
+var destroySettings = map[string]bool{
+	"retain_on_destroy":             true,
+	"deactivate_on_destroy":         true,
+	"allow_production_deactivation": true,
+	"prevent_deactivation_on":       true,
+	"force_deactivation":            true,
+}
+
+// onlyDestroySettingsChanged reports whether the changed keys, as returned by
+// ResourceDiff.GetChangedKeysPrefix, are all destroy settings
+func onlyDestroySettingsChanged(keys []string) bool {
+	for _, key := range keys {
+		if !destroySettings[strings.SplitN(key, ".", 2)[0]] {
+			return false
+		}
+	}
+
+	return len(keys) > 0
+}
+
+// changedKeys returns the top level keys of the schema that have changed
+func changedKeys(d *schema.ResourceData, resourceSchema map[string]*schema.Schema) []string {
+	var keys []string
+	for key := range resourceSchema {
+		if d.HasChange(key) {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// productionDeactivationAllowed reports whether destroying the resource may deactivate
+// the property on the network, logging when production is left active
+func productionDeactivationAllowed(d *schema.ResourceData, network papi.NetworkValue) bool {
+	if network != papi.NetworkProduction || d.Get("allow_production_deactivation").(bool) {
+		return true
+	}
+
+	log.Printf("[WARN] allow_production_deactivation is false, leaving %s active on production and removing it from state", d.Id())
+	return false
+}
+
+// checkDeactivationPrevented fails when destroying the resource would deactivate the
+// property on a network in prevent_deactivation_on, unless force_deactivation is set
+func checkDeactivationPrevented(d *schema.ResourceData, network papi.NetworkValue) error {
+	if d.Get("force_deactivation").(bool) {
+		return nil
+	}
+
+	for _, prevented := range setToStringSlice(d.Get("prevent_deactivation_on").(*schema.Set)) {
+		if strings.ToUpper(prevented) == string(network) {
+			return fmt.Errorf("%s is active on %s, which is listed in prevent_deactivation_on: apply force_deactivation = true before destroying it, or remove it from the state with terraform state rm", d.Id(), strings.ToLower(string(network)))
+		}
+	}
+
+	return nil
+}
+
+// failedActivationStatuses are the statuses of (de)activations that did not complete
+var failedActivationStatuses = map[papi.StatusValue]bool{
+	papi.StatusFailed:  true,
+	papi.StatusAborted: true,
+}
+
+// activationFailedError describes a failed (de)activation, including its fatal error if any
+func activationFailedError(property *papi.Property, activation *papi.Activation) error {
+	message := fmt.Sprintf("%s %s of %s v%d on %s has status %s", strings.ToLower(string(activation.ActivationType)), activation.ActivationID, property.PropertyID, activation.PropertyVersion, activation.Network, activation.Status)
+
+	details, err := getActivationDetails(property, activation.ActivationID)
+	if err != nil || details.FatalError == "" {
+		return errors.New(message)
+	}
+
+	return fmt.Errorf("%s: %s", message, details.FatalError)
+}
+
+// latestActivation returns the most recently submitted (de)activation on the network, or nil
+func latestActivation(activations *papi.Activations, network papi.NetworkValue) *papi.Activation {
+	var latest *papi.Activation
+	for _, activation := range activations.Activations.Items {
+		if activation.Network == network && (latest == nil || activation.SubmitDate > latest.SubmitDate) {
+			latest = activation
+		}
+	}
+
+	return latest
+}
+
+// setActivationStatuses sets the status of the latest (de)activation on each network
+func setActivationStatuses(d *schema.ResourceData, property *papi.Property) error {
+	activations, err := property.GetActivations()
+	if err != nil {
+		return err
+	}
+
+	for network, key := range map[papi.NetworkValue]string{
+		papi.NetworkStaging:    "staging_activation_status",
+		papi.NetworkProduction: "production_activation_status",
+	} {
+		status := ""
+		if activation := latestActivation(activations, network); activation != nil {
+			status = string(activation.Status)
+		}
+		d.Set(key, status)
+	}
+
+	return nil
+}
+
+// pendingActivationStatuses are the statuses of (de)activations still in progress
+var pendingActivationStatuses = map[papi.StatusValue]bool{
+	papi.StatusNew:                 true,
+	papi.StatusPending:             true,
+	papi.StatusZone1:               true,
+	papi.StatusZone2:               true,
+	papi.StatusZone3:               true,
+	papi.StatusPendingDeactivation: true,
+}
+
+// resolvePendingActivations waits for, cancels, or fails on (de)activations still
+// in progress on the network, depending on onPending
+func resolvePendingActivations(property *papi.Property, network papi.NetworkValue, onPending string, timeout time.Duration) error {
+	activations, err := property.GetActivations()
+	if err != nil {
+		return err
+	}
+
+	for _, pending := range activations.Activations.Items {
+		if pending.Network != network || !pendingActivationStatuses[pending.Status] {
+			continue
+		}
+
+		switch onPending {
+		case "wait":
+			log.Printf("[DEBUG] Waiting for pending activation %s of %s v%d on %s\n", pending.ActivationID, property.PropertyID, pending.PropertyVersion, network)
+			if err := waitForActivation(property, pending, timeout); err != nil {
+				return err
+			}
+		case "cancel":
+			log.Printf("[DEBUG] Cancelling pending activation %s of %s v%d on %s\n", pending.ActivationID, property.PropertyID, pending.PropertyVersion, network)
+			if err := cancelActivation(property, pending); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%s %s of %s v%d is still pending on %s (status: %s); set on_pending_activation to wait or cancel", strings.ToLower(string(pending.ActivationType)), pending.ActivationID, property.PropertyID, pending.PropertyVersion, network, pending.Status)
+		}
+	}
+
+	return nil
+}
+
+// cancelActivation cancels an activation that has not started deploying yet
+//
+// Endpoint: DELETE /papi/v1/properties/{propertyId}/activations/{activationId}{?contractId,groupId}
+func cancelActivation(property *papi.Property, activation *papi.Activation) error {
+	req, err := client.NewRequest(
+		papi.Config,
+		"DELETE",
+		fmt.Sprintf(
+			"/papi/v1/properties/%s/activations/%s?contractId=%s&groupId=%s",
+			property.PropertyID,
+			activation.ActivationID,
+			property.ContractID,
+			property.GroupID,
+		),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return err
+	}
+
+	if client.IsError(res) {
+		return client.NewAPIError(res)
+	}
+
+	return nil
+}
+
+// activationFallbackInfo describes whether a production activation can still be
+// reverted with fast fallback, which papi.Activation does not expose
+type activationFallbackInfo struct {
+	FastFallbackAttempted      bool   `json:"fastFallbackAttempted"`
+	FallbackVersion            int    `json:"fallbackVersion"`
+	CanFastFallback            bool   `json:"canFastFallback"`
+	SteadyStateTime            int64  `json:"steadyStateTime"`
+	FastFallbackExpirationTime int64  `json:"fastFallbackExpirationTime"`
+	FastFallbackRecoveryState  string `json:"fastFallbackRecoveryState,omitempty"`
+}
+
+// activationDetails holds the activation fields not exposed by papi.Activation
+type activationDetails struct {
+	FallbackInfo *activationFallbackInfo `json:"fallbackInfo"`
+	FatalError   string                  `json:"fatalError,omitempty"`
+}
+
+// getActivationDetails fetches the fast fallback details and fatal error of an activation
+//
+// Endpoint: GET /papi/v1/properties/{propertyId}/activations/{activationId}{?contractId,groupId}
+func getActivationDetails(property *papi.Property, activationID string) (*activationDetails, error) {
+	req, err := client.NewRequest(
+		papi.Config,
+		"GET",
+		fmt.Sprintf(
+			"/papi/v1/properties/%s/activations/%s?contractId=%s&groupId=%s",
+			property.PropertyID,
+			activationID,
+			property.ContractID,
+			property.GroupID,
+		),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	activations := &struct {
+		Activations struct {
+			Items []*activationDetails `json:"items"`
+		} `json:"activations"`
+	}{}
+	if err = client.BodyJSON(res, activations); err != nil {
+		return nil, err
+	}
+
+	if len(activations.Activations.Items) == 0 {
+		return &activationDetails{}, nil
+	}
+
+	return activations.Activations.Items[0], nil
+}
+
+// getActivationFallbackInfo fetches the fast fallback details of an activation
+func getActivationFallbackInfo(property *papi.Property, activationID string) (*activationFallbackInfo, error) {
+	details, err := getActivationDetails(property, activationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if details.FallbackInfo == nil {
+		return &activationFallbackInfo{}, nil
+	}
+
+	return details.FallbackInfo, nil
+}
+
+// activationErrors returns the fatal error of a failed activation as a list, for activation_errors
+func activationErrors(property *papi.Property, activation *papi.Activation) ([]string, error) {
+	if !failedActivationStatuses[activation.Status] {
+		return []string{}, nil
+	}
+
+	details, err := getActivationDetails(property, activation.ActivationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if details.FatalError == "" {
+		return []string{string(activation.Status)}, nil
+	}
+
+	return []string{details.FatalError}, nil
+}
@@ -0,0 +1,217 @@
+package akamai
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func testActivation(network papi.NetworkValue, status papi.StatusValue, submitted, updated string) *papi.Activation {
+	activation := papi.NewActivation(papi.NewActivations())
+	activation.ActivationType = papi.ActivationTypeActivate
+	activation.Network = network
+	activation.Status = status
+	activation.SubmitDate = submitted
+	activation.UpdateDate = updated
+
+	return activation
+}
+
+func TestEstimateActivationDuration(t *testing.T) {
+	activations := papi.NewActivations()
+	activations.Activations.Items = []*papi.Activation{
+		testActivation(papi.NetworkProduction, papi.StatusActive, "2018-05-03T10:00:00Z", "2018-05-03T10:20:00Z"),
+		testActivation(papi.NetworkStaging, papi.StatusActive, "2018-05-02T10:00:00Z", "2018-05-02T10:05:00Z"),
+		testActivation(papi.NetworkProduction, papi.StatusFailed, "2018-05-01T10:00:00Z", "2018-05-01T12:00:00Z"),
+		testActivation(papi.NetworkProduction, papi.StatusActive, "2018-04-30T10:00:00Z", "2018-04-30T10:40:00Z"),
+		testActivation(papi.NetworkProduction, papi.StatusActive, "2018-04-29T10:00:00Z", "2018-04-29T12:00:00Z"),
+	}
+
+	estimate, samples := estimateActivationDuration(activations, papi.NetworkProduction, 2)
+	if samples != 2 || estimate != 30*time.Minute {
+		t.Fatalf("expected 30m0s from 2 samples, got %s from %d", estimate, samples)
+	}
+
+	if _, samples := estimateActivationDuration(papi.NewActivations(), papi.NetworkProduction, 5); samples != 0 {
+		t.Fatalf("expected no samples, got %d", samples)
+	}
+}
+
+func TestLatestActivation(t *testing.T) {
+	activations := papi.NewActivations()
+	activations.Activations.Items = []*papi.Activation{
+		testActivation(papi.NetworkStaging, papi.StatusActive, "2018-05-02T10:00:00Z", "2018-05-02T10:05:00Z"),
+		testActivation(papi.NetworkStaging, papi.StatusFailed, "2018-05-03T10:00:00Z", "2018-05-03T10:05:00Z"),
+		testActivation(papi.NetworkStaging, papi.StatusActive, "2018-05-01T10:00:00Z", "2018-05-01T10:05:00Z"),
+	}
+
+	if latest := latestActivation(activations, papi.NetworkStaging); latest == nil || latest.Status != papi.StatusFailed {
+		t.Fatalf("expected the failed activation, got %+v", latest)
+	}
+
+	if latest := latestActivation(activations, papi.NetworkProduction); latest != nil {
+		t.Fatalf("expected no production activation, got %+v", latest)
+	}
+}
+
+func TestCheckDeactivationPrevented(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceProperty().Schema, map[string]interface{}{
+		"prevent_deactivation_on": []interface{}{"production"},
+	})
+	d.SetId("prp_1")
+
+	if err := checkDeactivationPrevented(d, papi.NetworkStaging); err != nil {
+		t.Fatalf("expected staging deactivation to be allowed, got %s", err)
+	}
+	if err := checkDeactivationPrevented(d, papi.NetworkProduction); err == nil {
+		t.Fatal("expected production deactivation to be prevented")
+	}
+
+	d.Set("force_deactivation", true)
+	if err := checkDeactivationPrevented(d, papi.NetworkProduction); err != nil {
+		t.Fatalf("expected force_deactivation to override, got %s", err)
+	}
+}
+
+func TestActivationFailureTracker(t *testing.T) {
+	bestEffort := newActivationFailureTracker(false)
+	bestEffort.record(errors.New("failed"))
+	if err := bestEffort.err(); err != nil {
+		t.Fatalf("expected best_effort to carry on, got %s", err)
+	}
+
+	failFast := newActivationFailureTracker(true)
+	if err := failFast.err(); err != nil {
+		t.Fatalf("expected no error before a failure, got %s", err)
+	}
+	failFast.record(errors.New("first"))
+	failFast.record(errors.New("second"))
+	if err := failFast.err(); err == nil || !strings.Contains(err.Error(), "first") {
+		t.Fatalf("expected the first failure to be reported, got %v", err)
+	}
+}
+
+func TestProductionDeactivationAllowed(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceProperty().Schema, map[string]interface{}{})
+	d.SetId("prp_1")
+
+	if !productionDeactivationAllowed(d, papi.NetworkStaging) {
+		t.Fatal("expected staging deactivation to be allowed")
+	}
+	if productionDeactivationAllowed(d, papi.NetworkProduction) {
+		t.Fatal("expected production to be left active by default")
+	}
+
+	d.Set("allow_production_deactivation", true)
+	if !productionDeactivationAllowed(d, papi.NetworkProduction) {
+		t.Fatal("expected allow_production_deactivation to allow production deactivation")
+	}
+}
+
+func TestDeletePreventedOnProduction(t *testing.T) {
+	// Deactivating or deleting the property is an unexpected request
+	defer testPAPIServer(t, map[string]string{
+		"GET /papi/v1/groups":    `{"groups": {"items": [{"groupId": "grp_1", "contractIds": ["ctr_1"]}]}}`,
+		"GET /papi/v1/contracts": `{"contracts": {"items": [{"contractId": "ctr_1"}]}}`,
+		"GET /papi/v1/properties/prp_1": `{"properties": {"items": [{
+			"contractId": "ctr_1",
+			"groupId": "grp_1",
+			"propertyId": "prp_1",
+			"latestVersion": 1
+		}]}}`,
+		"GET /papi/v1/properties/prp_1/activations": `{"activations": {"items": [{
+			"activationId": "atv_1",
+			"propertyVersion": 1,
+			"network": "PRODUCTION",
+			"activationType": "ACTIVATE",
+			"status": "ACTIVE",
+			"submitDate": "2018-05-02T10:00:00Z",
+			"updateDate": "2018-05-02T10:05:00Z"
+		}]}}`,
+	})()
+
+	// prevent_deactivation_on fails the destroy with the default destroy settings,
+	// rather than leaving the property active and removing it from the state
+	property := schema.TestResourceDataRaw(t, resourceProperty().Schema, map[string]interface{}{
+		"contract_id":             "ctr_1",
+		"group_id":                "grp_1",
+		"network":                 "production",
+		"prevent_deactivation_on": []interface{}{"production"},
+	})
+	property.SetId("prp_1")
+	if err := resourcePropertyDelete(property, &Config{}); err == nil || property.Id() == "" {
+		t.Fatal("expected destroying akamai_property to be prevented")
+	}
+
+	activation := schema.TestResourceDataRaw(t, resourcePropertyActivation().Schema, map[string]interface{}{
+		"property_id":             "prp_1",
+		"version":                 1,
+		"network":                 "production",
+		"prevent_deactivation_on": []interface{}{"production"},
+	})
+	activation.SetId("atv_1")
+	if err := resourcePropertyActivationDelete(activation, &Config{}); err == nil || activation.Id() == "" {
+		t.Fatal("expected destroying akamai_property_activation to be prevented")
+	}
+}
+
+func TestOnlyDestroySettingsChanged(t *testing.T) {
+	tests := []struct {
+		keys     []string
+		expected bool
+	}{
+		{nil, false},
+		{[]string{"force_deactivation"}, true},
+		{[]string{"prevent_deactivation_on.#", "prevent_deactivation_on.1234", "allow_production_deactivation"}, true},
+		{[]string{"force_deactivation", "rules.#"}, false},
+		{[]string{"version"}, false},
+	}
+
+	for _, test := range tests {
+		if changed := onlyDestroySettingsChanged(test.keys); changed != test.expected {
+			t.Errorf("%v: expected %t, got %t", test.keys, test.expected, changed)
+		}
+	}
+}
+
+func TestWaitForActivation(t *testing.T) {
+	defer testPAPIServer(t, map[string]string{
+		"GET /papi/v1/properties/prp_1/activations/atv_1": `{"activations": {"items": [{
+			"activationId": "atv_1",
+			"propertyVersion": 1,
+			"network": "STAGING",
+			"activationType": "ACTIVATE",
+			"status": "PENDING"
+		}]}}`,
+		"GET /papi/v1/properties/prp_1/activations/atv_2": `{"activations": {"items": [{
+			"activationId": "atv_2",
+			"propertyVersion": 2,
+			"network": "STAGING",
+			"activationType": "ACTIVATE",
+			"status": "FAILED"
+		}]}}`,
+	})()
+
+	interval := activationPollInterval
+	activationPollInterval = 0
+	defer func() { activationPollInterval = interval }()
+
+	property := papi.NewProperty(papi.NewProperties())
+	property.PropertyID = "prp_1"
+
+	pending := testActivation(papi.NetworkStaging, papi.StatusPending, "", "")
+	pending.ActivationID = "atv_1"
+	if err := waitForActivation(property, pending, 0); err == nil || !strings.Contains(err.Error(), "timeout") {
+		t.Errorf("expected a timeout, got %v", err)
+	}
+
+	failed := testActivation(papi.NetworkStaging, papi.StatusPending, "", "")
+	failed.ActivationID = "atv_2"
+	if err := waitForActivation(property, failed, time.Minute); err == nil || strings.Contains(err.Error(), "timeout") {
+		t.Errorf("expected the activation to fail, got %v", err)
+	}
+}
@@ -0,0 +1,187 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+)
+
+// Bulk activations
+//
+// PAPI activates many property versions in one bulk activation job, which is
+// much faster than submitting and polling the activations one by one. The job
+// first submits the activations, reporting the properties it could not submit
+// with a task status other than COMPLETE, and then tracks each activation.
+//
+// https://developer.akamai.com/api/core_features/property_manager/v1.html#bulk
+
+type bulkActivationItem struct {
+	PropertyID           string `json:"propertyId"`
+	PropertyVersion      int    `json:"propertyVersion"`
+	Network              string `json:"network"`
+	Note                 string `json:"note,omitempty"`
+	PropertyActivationID string `json:"propertyActivationId,omitempty"`
+	ActivationStatus     string `json:"activationStatus,omitempty"`
+	TaskStatus           string `json:"taskStatus,omitempty"`
+	FatalError           string `json:"fatalError,omitempty"`
+}
+
+// failed reports whether the property could not be submitted, or its activation failed
+func (item *bulkActivationItem) failed() bool {
+	return (item.TaskStatus != "" && item.TaskStatus != "COMPLETE" && item.TaskStatus != "PENDING" && item.TaskStatus != "IN_PROGRESS") ||
+		failedActivationStatuses[papi.StatusValue(item.ActivationStatus)]
+}
+
+// done reports whether nothing more will happen to the property
+func (item *bulkActivationItem) done() bool {
+	return item.failed() || item.ActivationStatus == string(papi.StatusActive)
+}
+
+type bulkActivation struct {
+	BulkActivationID         string                `json:"bulkActivationId"`
+	BulkActivationStatus     string                `json:"bulkActivationStatus"`
+	ActivatePropertyVersions []*bulkActivationItem `json:"activatePropertyVersions"`
+}
+
+// done reports whether every property of the job has been activated or failed
+func (activation *bulkActivation) done() bool {
+	for _, item := range activation.ActivatePropertyVersions {
+		if !item.done() {
+			return false
+		}
+	}
+
+	return true
+}
+
+type bulkActivationSettings struct {
+	NotifyEmails           []string `json:"notifyEmails"`
+	AcknowledgeAllWarnings bool     `json:"acknowledgeAllWarnings"`
+}
+
+// submitBulkActivation submits the bulk activation job and returns its ID
+//
+// Endpoint: POST /papi/v1/bulk/activations{?contractId,groupId}
+func submitBulkActivation(contractID string, groupID string, settings bulkActivationSettings, items []*bulkActivationItem) (string, error) {
+	log.Printf("[DEBUG] Submitting bulk activation of %d properties\n", len(items))
+
+	req, err := client.NewJSONRequest(
+		papi.Config,
+		"POST",
+		fmt.Sprintf("/papi/v1/bulk/activations?contractId=%s&groupId=%s", contractID, groupID),
+		map[string]interface{}{
+			"defaultActivationSettings": settings,
+			"activatePropertyVersions":  items,
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return "", err
+	}
+
+	if client.IsError(res) {
+		return "", client.NewAPIError(res)
+	}
+
+	submitted := struct {
+		BulkActivationLink string `json:"bulkActivationLink"`
+	}{}
+	if err := client.BodyJSON(res, &submitted); err != nil {
+		return "", err
+	}
+
+	return linkID(submitted.BulkActivationLink), nil
+}
+
+// getBulkActivation returns the bulk activation job with the status of each property
+//
+// Endpoint: GET /papi/v1/bulk/activations/{bulkActivationId}{?contractId,groupId}
+func getBulkActivation(contractID string, groupID string, bulkActivationID string) (*bulkActivation, error) {
+	req, err := client.NewRequest(
+		papi.Config,
+		"GET",
+		fmt.Sprintf("/papi/v1/bulk/activations/%s?contractId=%s&groupId=%s", bulkActivationID, contractID, groupID),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	activation := &bulkActivation{}
+	if err := client.BodyJSON(res, activation); err != nil {
+		return nil, err
+	}
+
+	return activation, nil
+}
+
+// bulkActivationPollInterval is how often bulk activations are polled
+var bulkActivationPollInterval = 30 * time.Second
+
+// waitForBulkActivation polls the bulk activation job until every property has
+// been activated or failed
+func waitForBulkActivation(contractID string, groupID string, bulkActivationID string, timeout time.Duration) (*bulkActivation, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		activation, err := getBulkActivation(contractID, groupID, bulkActivationID)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("[DEBUG] Bulk activation %s: %s\n", bulkActivationID, activation.BulkActivationStatus)
+
+		if activation.done() {
+			return activation, nil
+		}
+
+		if time.Now().After(deadline) {
+			return activation, fmt.Errorf("timeout after %s waiting for bulk activation %s (status: %s)", timeout, bulkActivationID, activation.BulkActivationStatus)
+		}
+		time.Sleep(bulkActivationPollInterval)
+	}
+}
+
+// bulkActivationErrors reports each property that could not be activated on its
+// own line, or nil if all of them were
+func bulkActivationErrors(activation *bulkActivation) error {
+	var failures []string
+	for _, item := range activation.ActivatePropertyVersions {
+		if !item.failed() {
+			continue
+		}
+
+		status := item.ActivationStatus
+		if status == "" {
+			status = item.TaskStatus
+		}
+		failure := fmt.Sprintf("\n  %s v%d: %s", item.PropertyID, item.PropertyVersion, status)
+		if item.FatalError != "" {
+			failure += ": " + item.FatalError
+		}
+		failures = append(failures, failure)
+	}
+
+	if len(failures) == 0 {
+		return nil
+	}
+
+	sort.Strings(failures)
+	return fmt.Errorf("bulk activation %s failed for %d of %d properties:%s", activation.BulkActivationID, len(failures), len(activation.ActivatePropertyVersions), strings.Join(failures, ""))
+}
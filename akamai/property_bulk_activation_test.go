@@ -0,0 +1,45 @@
+package akamai
+
+import (
+	"testing"
+)
+
+func TestBulkActivationDone(t *testing.T) {
+	activation := &bulkActivation{
+		ActivatePropertyVersions: []*bulkActivationItem{
+			{PropertyID: "prp_1", TaskStatus: "COMPLETE", ActivationStatus: "ACTIVE"},
+			{PropertyID: "prp_2", TaskStatus: "SUBMISSION_ERROR"},
+			{PropertyID: "prp_3", TaskStatus: "COMPLETE", ActivationStatus: "PENDING"},
+		},
+	}
+
+	if activation.done() {
+		t.Fatal("expected the activation of prp_3 to be pending")
+	}
+
+	activation.ActivatePropertyVersions[2].ActivationStatus = "FAILED"
+	if !activation.done() {
+		t.Fatal("expected every property to be done")
+	}
+}
+
+func TestBulkActivationErrors(t *testing.T) {
+	activation := &bulkActivation{
+		BulkActivationID: "atvb_1",
+		ActivatePropertyVersions: []*bulkActivationItem{
+			{PropertyID: "prp_1", PropertyVersion: 3, TaskStatus: "COMPLETE", ActivationStatus: "ACTIVE"},
+			{PropertyID: "prp_3", PropertyVersion: 2, TaskStatus: "COMPLETE", ActivationStatus: "FAILED"},
+			{PropertyID: "prp_2", PropertyVersion: 5, TaskStatus: "SUBMISSION_ERROR", FatalError: "version is already active"},
+		},
+	}
+
+	expected := "bulk activation atvb_1 failed for 2 of 3 properties:\n  prp_2 v5: SUBMISSION_ERROR: version is already active\n  prp_3 v2: FAILED"
+	if err := bulkActivationErrors(activation); err == nil || err.Error() != expected {
+		t.Errorf("expected %q, got %v", expected, err)
+	}
+
+	activation.ActivatePropertyVersions = activation.ActivatePropertyVersions[:1]
+	if err := bulkActivationErrors(activation); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Certificate challenges
+//
+// Hostnames using Secure by Default certificates are validated, and renewed, with
+// a CNAME record Akamai asks for in the hostname's certificate status. The
+// certificate_challenges attribute is refreshed from it, so that DNS records
+// referencing it are updated in the next apply when Akamai issues a new challenge.
+
+var akpsCertificateChallenges = &schema.Schema{
+	Type:     schema.TypeList,
+	Computed: true,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"hostname": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			// The CNAME record to create
+			"cname_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"cname_target": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"staging_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"production_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	},
+}
+
+type certStatusEntry struct {
+	Status string `json:"status"`
+}
+
+type hostnameCertStatus struct {
+	CnameFrom            string `json:"cnameFrom"`
+	CnameTo              string `json:"cnameTo"`
+	EdgeHostnameID       string `json:"edgeHostnameId"`
+	CertProvisioningType string `json:"certProvisioningType,omitempty"`
+	CertStatus           *struct {
+		ValidationCname struct {
+			Hostname string `json:"hostname"`
+			Target   string `json:"target"`
+		} `json:"validationCname"`
+		Staging    []certStatusEntry `json:"staging"`
+		Production []certStatusEntry `json:"production"`
+	} `json:"certStatus,omitempty"`
+}
+
+// getHostnameCertStatuses fetches the hostnames of the version with their certificate status
+//
+// Endpoint: GET /papi/v1/properties/{propertyId}/versions/{propertyVersion}/hostnames{?contractId,groupId,includeCertStatus}
+func getHostnameCertStatuses(property *papi.Property, version int) ([]hostnameCertStatus, error) {
+	req, err := client.NewRequest(
+		papi.Config,
+		"GET",
+		fmt.Sprintf(
+			"/papi/v1/properties/%s/versions/%d/hostnames?contractId=%s&groupId=%s&includeCertStatus=true",
+			property.PropertyID,
+			version,
+			property.ContractID,
+			property.GroupID,
+		),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	hostnames := &struct {
+		Hostnames struct {
+			Items []hostnameCertStatus `json:"items"`
+		} `json:"hostnames"`
+	}{}
+	if err = client.BodyJSON(res, hostnames); err != nil {
+		return nil, err
+	}
+
+	return hostnames.Hostnames.Items, nil
+}
+
+// flattenCertChallenges returns the certificate_challenges of the hostnames that have one
+func flattenCertChallenges(statuses []hostnameCertStatus) []interface{} {
+	challenges := make([]interface{}, 0)
+	for _, hostname := range statuses {
+		if hostname.CertStatus == nil || hostname.CertStatus.ValidationCname.Hostname == "" {
+			continue
+		}
+
+		challenge := map[string]interface{}{
+			"hostname":          hostname.CnameFrom,
+			"cname_name":        hostname.CertStatus.ValidationCname.Hostname,
+			"cname_target":      hostname.CertStatus.ValidationCname.Target,
+			"staging_status":    "",
+			"production_status": "",
+		}
+		if len(hostname.CertStatus.Staging) > 0 {
+			challenge["staging_status"] = hostname.CertStatus.Staging[0].Status
+		}
+		if len(hostname.CertStatus.Production) > 0 {
+			challenge["production_status"] = hostname.CertStatus.Production[0].Status
+		}
+
+		challenges = append(challenges, challenge)
+	}
+
+	return challenges
+}
+
+// setCertChallenges refreshes certificate_challenges, warning about challenges
+// that changed since the last refresh
+func setCertChallenges(d *schema.ResourceData, property *papi.Property, version int) error {
+	statuses, err := getHostnameCertStatuses(property, version)
+	if err != nil {
+		return err
+	}
+
+	previous := make(map[string]string)
+	for _, c := range d.Get("certificate_challenges").([]interface{}) {
+		challenge := c.(map[string]interface{})
+		previous[challenge["hostname"].(string)] = challenge["cname_target"].(string)
+	}
+
+	challenges := flattenCertChallenges(statuses)
+	for _, c := range challenges {
+		challenge := c.(map[string]interface{})
+		if target, ok := previous[challenge["hostname"].(string)]; ok && target != challenge["cname_target"] {
+			log.Printf("[WARN] Akamai issued a new certificate challenge for %s: %s must now point to %s\n", challenge["hostname"], challenge["cname_name"], challenge["cname_target"])
+		}
+	}
+
+	d.Set("certificate_challenges", challenges)
+	return nil
+}
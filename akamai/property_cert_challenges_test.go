@@ -0,0 +1,30 @@
+package akamai
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFlattenCertChallenges(t *testing.T) {
+	var statuses []hostnameCertStatus
+	if err := json.Unmarshal([]byte(`[
+		{"cnameFrom": "www.example.com", "certProvisioningType": "DEFAULT", "certStatus": {
+			"validationCname": {"hostname": "_acme-challenge.www.example.com", "target": "ac.123.example.com.acme-validate.edgekey.net"},
+			"staging": [{"status": "PENDING"}],
+			"production": [{"status": "PENDING"}]
+		}},
+		{"cnameFrom": "cps.example.com", "certProvisioningType": "CPS_MANAGED"}
+	]`), &statuses); err != nil {
+		t.Fatal(err)
+	}
+
+	challenges := flattenCertChallenges(statuses)
+	if len(challenges) != 1 {
+		t.Fatalf("expected 1 challenge, got %d", len(challenges))
+	}
+
+	challenge := challenges[0].(map[string]interface{})
+	if challenge["cname_name"] != "_acme-challenge.www.example.com" || challenge["staging_status"] != "PENDING" {
+		t.Fatalf("unexpected challenge %v", challenge)
+	}
+}
@@ -0,0 +1,82 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// Out-of-band changes
+//
+// A property edited outside of Terraform, e.g. in Control Center, has a newer
+// latest version than the one Terraform last saved, or different rules on it.
+// conflict_strategy decides what happens during plan:
+//
+//   - fail fails the plan
+//   - overwrite saves the configuration onto the latest version, the default
+//   - new_version saves the configuration onto a new version created from the
+//     version Terraform last saved, leaving the external changes in their own version
+
+var akpsConflictStrategy = &schema.Schema{
+	Type:         schema.TypeString,
+	Optional:     true,
+	Default:      "overwrite",
+	ValidateFunc: validation.StringInSlice([]string{"fail", "overwrite", "new_version"}, false),
+}
+
+// propertyChangedOutOfBand describes how the property changed since Terraform last
+// saved the version with the rules etag, or returns "" if it did not
+func propertyChangedOutOfBand(property *papi.Property, version int, rulesEtag string) (string, error) {
+	if property.LatestVersion != version {
+		return fmt.Sprintf("its latest version is v%d, Terraform last saved v%d", property.LatestVersion, version), nil
+	}
+
+	if rulesEtag == "" {
+		return "", nil
+	}
+
+	digest, err := property.GetRulesDigest()
+	if err != nil {
+		return "", err
+	}
+
+	if strings.Trim(digest, `"`) != rulesEtag {
+		return fmt.Sprintf("the rules of v%d were edited since Terraform saved them", version), nil
+	}
+
+	return "", nil
+}
+
+// resourcePropertyCheckConflicts applies conflict_strategy to properties changed out-of-band
+func resourcePropertyCheckConflicts(d *schema.ResourceDiff) error {
+	version, _ := d.Get("version").(int)
+	if d.Id() == "" || version == 0 {
+		return nil
+	}
+
+	property := papi.NewProperty(papi.NewProperties())
+	property.PropertyID = d.Id()
+	if err := property.GetProperty(); err != nil {
+		return err
+	}
+
+	change, err := propertyChangedOutOfBand(property, version, d.Get("rules_etag").(string))
+	if err != nil || change == "" {
+		return err
+	}
+
+	switch d.Get("conflict_strategy").(string) {
+	case "fail":
+		return fmt.Errorf("%s was changed outside of Terraform: %s. Set conflict_strategy to overwrite or new_version to apply anyway", d.Id(), change)
+	case "new_version":
+		log.Printf("[WARN] %s was changed outside of Terraform: %s. A new version will be created from v%d", d.Id(), change, version)
+	default:
+		log.Printf("[WARN] %s was changed outside of Terraform: %s. The changes will be overwritten", d.Id(), change)
+	}
+
+	return d.SetNewComputed("version")
+}
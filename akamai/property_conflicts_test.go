@@ -0,0 +1,24 @@
+package akamai
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+)
+
+func TestPropertyChangedOutOfBand(t *testing.T) {
+	property := papi.NewProperty(papi.NewProperties())
+	property.PropertyID = "prp_1"
+	property.LatestVersion = 5
+
+	change, err := propertyChangedOutOfBand(property, 3, "")
+	if err != nil || !strings.Contains(change, "v5") {
+		t.Fatalf("expected a newer latest version to be reported, got %q (%v)", change, err)
+	}
+
+	// Without an etag, only the version number is compared
+	if change, err := propertyChangedOutOfBand(property, 5, ""); err != nil || change != "" {
+		t.Fatalf("expected no change, got %q (%v)", change, err)
+	}
+}
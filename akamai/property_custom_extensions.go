@@ -0,0 +1,122 @@
+package akamai
+
+import (
+	"fmt"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+)
+
+// Custom behaviors and custom overrides
+//
+// Custom behaviors and overrides are account level XML extensions set up by
+// Akamai. Rules reference custom behaviors by ID with the customBehavior
+// behavior, and the default rule references a custom override with its
+// customOverride member. PAPI lists both in the same shape, under different keys.
+
+// customExtensionKind describes the PAPI endpoint of custom behaviors or overrides
+type customExtensionKind struct {
+	path    string
+	listKey string
+	noun    string
+}
+
+var (
+	customBehaviorKind = customExtensionKind{path: "custom-behaviors", listKey: "customBehaviors", noun: "custom behavior"}
+	customOverrideKind = customExtensionKind{path: "custom-overrides", listKey: "customOverrides", noun: "custom override"}
+)
+
+type customExtension struct {
+	BehaviorID    string `json:"behaviorId"`
+	OverrideID    string `json:"overrideId"`
+	Name          string `json:"name"`
+	DisplayName   string `json:"displayName"`
+	Status        string `json:"status"`
+	Description   string `json:"description"`
+	UpdatedDate   string `json:"updatedDate"`
+	UpdatedByUser string `json:"updatedByUser"`
+	XML           string `json:"xml"`
+}
+
+// ID returns the behavior or override ID
+func (extension *customExtension) ID() string {
+	if extension.BehaviorID != "" {
+		return extension.BehaviorID
+	}
+
+	return extension.OverrideID
+}
+
+// getCustomExtensions lists the custom behaviors or overrides of the account,
+// or fetches the one with the ID, which includes its XML
+//
+// Endpoint: GET /papi/v1/custom-behaviors{/behaviorId}
+// Endpoint: GET /papi/v1/custom-overrides{/overrideId}
+func getCustomExtensions(kind customExtensionKind, id string) ([]*customExtension, error) {
+	path := "/papi/v1/" + kind.path
+	if id != "" {
+		path += "/" + id
+	}
+
+	req, err := client.NewRequest(papi.Config, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	lists := map[string]struct {
+		Items []*customExtension `json:"items"`
+	}{}
+	if err := client.BodyJSON(res, &lists); err != nil {
+		return nil, err
+	}
+
+	return lists[kind.listKey].Items, nil
+}
+
+// findCustomExtension returns the custom behavior or override with the ID or
+// name, or nil if there is none. Display names are not unique, names are.
+func findCustomExtension(extensions []*customExtension, id string, name string) *customExtension {
+	for _, extension := range extensions {
+		if (id != "" && extension.ID() == id) || (id == "" && extension.Name == name) {
+			return extension
+		}
+	}
+
+	return nil
+}
+
+// getCustomExtension looks up the custom behavior or override by ID or name,
+// and fetches it again by ID for its XML
+func getCustomExtension(kind customExtensionKind, id string, name string) (*customExtension, error) {
+	extensions, err := getCustomExtensions(kind, "")
+	if err != nil {
+		return nil, err
+	}
+
+	found := findCustomExtension(extensions, id, name)
+	if found == nil {
+		if id != "" {
+			return nil, fmt.Errorf("%s %s not found", kind.noun, id)
+		}
+		return nil, fmt.Errorf("%s \"%s\" not found", kind.noun, name)
+	}
+
+	detailed, err := getCustomExtensions(kind, found.ID())
+	if err != nil {
+		return nil, err
+	}
+	if len(detailed) > 0 {
+		return detailed[0], nil
+	}
+
+	return found, nil
+}
@@ -0,0 +1,27 @@
+package akamai
+
+import (
+	"testing"
+)
+
+func TestFindCustomExtension(t *testing.T) {
+	extensions := []*customExtension{
+		{BehaviorID: "cbe_1", Name: "dl_origin", DisplayName: "Download origin"},
+		{BehaviorID: "cbe_2", Name: "geo_block", DisplayName: "Download origin"},
+	}
+
+	if found := findCustomExtension(extensions, "cbe_2", ""); found == nil || found.Name != "geo_block" {
+		t.Errorf("expected cbe_2, got %v", found)
+	}
+	if found := findCustomExtension(extensions, "", "dl_origin"); found == nil || found.ID() != "cbe_1" {
+		t.Errorf("expected cbe_1, got %v", found)
+	}
+	if found := findCustomExtension(extensions, "", "Download origin"); found != nil {
+		t.Errorf("expected display names not to match, got %v", found)
+	}
+
+	override := &customExtension{OverrideID: "cbo_1"}
+	if override.ID() != "cbo_1" {
+		t.Errorf("expected cbo_1, got %s", override.ID())
+	}
+}
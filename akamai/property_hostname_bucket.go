@@ -0,0 +1,361 @@
+package akamai
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Hostname buckets
+//
+// Properties created with useHostnameBucket keep their hostnames in a bucket
+// outside the property versions, and PAPI rejects the version hostname
+// operations for them. akamai_property creates such properties with
+// use_hostname_bucket, detects them when adopting or reading existing ones, and
+// skips its version hostname handling for them.
+//
+// https://techdocs.akamai.com/property-mgr/reference/hostname-buckets
+
+const propertyTypeHostnameBucket = "HOSTNAME_BUCKET"
+
+var akpsUseHostnameBucket = &schema.Schema{
+	Type:     schema.TypeBool,
+	Optional: true,
+	Computed: true,
+	ForceNew: true,
+}
+
+// isHostnameBucketProperty reports whether the property keeps its hostnames in a
+// hostname bucket. The edgegrid client does not return the property type.
+//
+// Endpoint: GET /papi/v1/properties/{propertyId}{?contractId,groupId}
+func isHostnameBucketProperty(property *papi.Property) (bool, error) {
+	req, err := client.NewRequest(
+		papi.Config,
+		"GET",
+		fmt.Sprintf("/papi/v1/properties/%s?contractId=%s&groupId=%s", property.PropertyID, property.ContractID, property.GroupID),
+		nil,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return false, err
+	}
+
+	if client.IsError(res) {
+		return false, client.NewAPIError(res)
+	}
+
+	properties := struct {
+		Properties struct {
+			Items []struct {
+				PropertyType string `json:"propertyType"`
+			} `json:"items"`
+		} `json:"properties"`
+	}{}
+	if err := client.BodyJSON(res, &properties); err != nil {
+		return false, err
+	}
+
+	if len(properties.Properties.Items) == 0 {
+		return false, fmt.Errorf("property %s not found", property.PropertyID)
+	}
+
+	return properties.Properties.Items[0].PropertyType == propertyTypeHostnameBucket, nil
+}
+
+// createHostnameBucketProperty creates the property with a hostname bucket,
+// which the edgegrid client cannot request
+//
+// Endpoint: POST /papi/v1/properties{?contractId,groupId}
+func createHostnameBucketProperty(property *papi.Property) error {
+	body := map[string]interface{}{
+		"productId":         property.ProductID,
+		"propertyName":      property.PropertyName,
+		"ruleFormat":        property.RuleFormat,
+		"useHostnameBucket": true,
+	}
+	if property.CloneFrom != nil {
+		body["cloneFrom"] = property.CloneFrom
+	}
+
+	req, err := client.NewJSONRequest(
+		papi.Config,
+		"POST",
+		fmt.Sprintf("/papi/v1/properties?contractId=%s&groupId=%s", property.Contract.ContractID, property.Group.GroupID),
+		body,
+	)
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return err
+	}
+
+	if client.IsError(res) {
+		return client.NewAPIError(res)
+	}
+
+	created := struct {
+		PropertyLink string `json:"propertyLink"`
+	}{}
+	if err := client.BodyJSON(res, &created); err != nil {
+		return err
+	}
+
+	property.PropertyID = linkID(created.PropertyLink)
+	return property.GetProperty()
+}
+
+// resourcePropertyCheckHostnameBucket requires hostnames unless the property
+// uses a hostname bucket, whose hostnames cannot be set on the property
+func resourcePropertyCheckHostnameBucket(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("use_hostname_bucket") || !d.NewValueKnown("hostname") {
+		return nil
+	}
+
+	hostnames := d.Get("hostname").(*schema.Set).Len()
+	if d.Get("use_hostname_bucket").(bool) {
+		if hostnames > 0 {
+			return errors.New("hostname cannot be set when use_hostname_bucket is enabled, the hostnames of hostname bucket properties are not versioned")
+		}
+		return nil
+	}
+
+	if hostnames == 0 {
+		return errors.New("hostname is required unless use_hostname_bucket is enabled")
+	}
+
+	return nil
+}
+
+// usesHostnameBucket reports whether the property uses a hostname bucket,
+// recording it in use_hostname_bucket
+func usesHostnameBucket(d *schema.ResourceData, property *papi.Property) (bool, error) {
+	if d.Get("use_hostname_bucket").(bool) {
+		return true, nil
+	}
+
+	bucket, err := isHostnameBucketProperty(property)
+	if err != nil {
+		return false, err
+	}
+	if bucket {
+		log.Printf("[DEBUG] %s uses a hostname bucket\n", property.PropertyID)
+	}
+
+	d.Set("use_hostname_bucket", bucket)
+	return bucket, nil
+}
+
+type bucketHostname struct {
+	CnameFrom            string `json:"cnameFrom"`
+	CnameType            string `json:"cnameType,omitempty"`
+	CnameTo              string `json:"cnameTo,omitempty"`
+	EdgeHostnameID       string `json:"edgeHostnameId,omitempty"`
+	CertProvisioningType string `json:"certProvisioningType,omitempty"`
+}
+
+// getBucketHostnames lists the hostnames of the property's bucket on the network
+//
+// Endpoint: GET /papi/v1/properties/{propertyId}/hostnames{?contractId,groupId,network}
+func getBucketHostnames(property *papi.Property, network string) ([]*bucketHostname, error) {
+	req, err := client.NewRequest(
+		papi.Config,
+		"GET",
+		fmt.Sprintf(
+			"/papi/v1/properties/%s/hostnames?contractId=%s&groupId=%s&network=%s",
+			property.PropertyID,
+			property.ContractID,
+			property.GroupID,
+			strings.ToUpper(network),
+		),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	hostnames := struct {
+		Hostnames struct {
+			Items []*bucketHostname `json:"items"`
+		} `json:"hostnames"`
+	}{}
+	if err := client.BodyJSON(res, &hostnames); err != nil {
+		return nil, err
+	}
+
+	return hostnames.Hostnames.Items, nil
+}
+
+// patchBucketHostnames adds and removes hostnames of the property's bucket on
+// the network in one hostname activation, and returns its ID
+//
+// Endpoint: PATCH /papi/v1/properties/{propertyId}/hostnames{?contractId,groupId}
+func patchBucketHostnames(property *papi.Property, network string, add []*bucketHostname, remove []string, note string, notifyEmails []string) (string, error) {
+	log.Printf("[DEBUG] Adding %d and removing %d hostnames of %s on %s\n", len(add), len(remove), property.PropertyID, network)
+
+	body := map[string]interface{}{
+		"network": strings.ToUpper(network),
+		"add":     add,
+		"remove":  remove,
+	}
+	if note != "" {
+		body["note"] = note
+	}
+	if len(notifyEmails) > 0 {
+		body["notifyEmails"] = notifyEmails
+	}
+
+	req, err := client.NewJSONRequest(
+		papi.Config,
+		"PATCH",
+		fmt.Sprintf("/papi/v1/properties/%s/hostnames?contractId=%s&groupId=%s", property.PropertyID, property.ContractID, property.GroupID),
+		body,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return "", err
+	}
+
+	if client.IsError(res) {
+		return "", client.NewAPIError(res)
+	}
+
+	patched := struct {
+		ActivationLink string `json:"activationLink"`
+	}{}
+	if err := client.BodyJSON(res, &patched); err != nil {
+		return "", err
+	}
+
+	return linkID(patched.ActivationLink), nil
+}
+
+// hostnameActivationPollInterval is how often hostname activations are polled
+var hostnameActivationPollInterval = 30 * time.Second
+
+// waitForHostnameActivation polls the hostname activation until it is active
+//
+// Endpoint: GET /papi/v1/properties/{propertyId}/hostname-activations/{hostnameActivationId}{?contractId,groupId}
+func waitForHostnameActivation(property *papi.Property, activationID string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		status, err := getHostnameActivationStatus(property, activationID)
+		if err != nil {
+			return err
+		}
+		log.Printf("[DEBUG] Hostname activation %s of %s: %s\n", activationID, property.PropertyID, status)
+
+		switch status {
+		case string(papi.StatusActive):
+			return nil
+		case string(papi.StatusFailed), string(papi.StatusAborted), string(papi.StatusDeactivated):
+			return fmt.Errorf("hostname activation %s of %s failed (status: %s)", activationID, property.PropertyID, status)
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout after %s waiting for hostname activation %s of %s (status: %s)", timeout, activationID, property.PropertyID, status)
+		}
+		time.Sleep(hostnameActivationPollInterval)
+	}
+}
+
+func getHostnameActivationStatus(property *papi.Property, activationID string) (string, error) {
+	req, err := client.NewRequest(
+		papi.Config,
+		"GET",
+		fmt.Sprintf(
+			"/papi/v1/properties/%s/hostname-activations/%s?contractId=%s&groupId=%s",
+			property.PropertyID,
+			activationID,
+			property.ContractID,
+			property.GroupID,
+		),
+		nil,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return "", err
+	}
+
+	if client.IsError(res) {
+		return "", client.NewAPIError(res)
+	}
+
+	activations := struct {
+		HostnameActivations struct {
+			Items []struct {
+				Status string `json:"status"`
+			} `json:"items"`
+		} `json:"hostnameActivations"`
+	}{}
+	if err := client.BodyJSON(res, &activations); err != nil {
+		return "", err
+	}
+
+	if len(activations.HostnameActivations.Items) == 0 {
+		return "", fmt.Errorf("hostname activation %s of %s not found", activationID, property.PropertyID)
+	}
+
+	return activations.HostnameActivations.Items[0].Status, nil
+}
+
+// diffBucketHostnames returns the hostnames to add, including those whose
+// settings changed, and the hostnames to remove
+func diffBucketHostnames(current []*bucketHostname, desired []*bucketHostname) ([]*bucketHostname, []string) {
+	existing := make(map[string]*bucketHostname)
+	for _, hostname := range current {
+		existing[strings.ToLower(hostname.CnameFrom)] = hostname
+	}
+
+	wanted := make(map[string]bool)
+	var add []*bucketHostname
+	for _, hostname := range desired {
+		key := strings.ToLower(hostname.CnameFrom)
+		wanted[key] = true
+		if e, ok := existing[key]; ok && e.EdgeHostnameID == hostname.EdgeHostnameID && e.CertProvisioningType == hostname.CertProvisioningType {
+			continue
+		}
+		add = append(add, hostname)
+	}
+
+	remove := []string{}
+	for _, hostname := range current {
+		if !wanted[strings.ToLower(hostname.CnameFrom)] {
+			remove = append(remove, hostname.CnameFrom)
+		}
+	}
+	sort.Strings(remove)
+
+	return add, remove
+}
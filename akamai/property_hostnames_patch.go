@@ -0,0 +1,108 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+)
+
+// Hostname patches
+//
+// The edgegrid client replaces the hostnames of a version with a PUT of the
+// complete list, which takes very long for properties with thousands of
+// hostnames. The change computed by diffHostnames is sent as a single PATCH of
+// the hostnames to add and remove instead, with hostname validation, so that
+// PAPI reports the hostnames it rejects individually.
+
+type hostnamesPatch struct {
+	Add    []*papi.Hostname `json:"add"`
+	Remove []string         `json:"remove"`
+}
+
+func (patch *hostnamesPatch) empty() bool {
+	return len(patch.Add) == 0 && len(patch.Remove) == 0
+}
+
+// hostnameError is a problem PAPI found with one of the hostnames
+type hostnameError struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Detail    string `json:"detail"`
+	CnameFrom string `json:"cnameFrom"`
+}
+
+type hostnamesPatchResponse struct {
+	Hostnames struct {
+		Items []*papi.Hostname `json:"items"`
+	} `json:"hostnames"`
+	Errors []*hostnameError `json:"errors"`
+}
+
+// patchHostnames applies the patch to the latest version of the property and
+// returns its resulting hostnames
+//
+// Endpoint: PATCH /papi/v1/properties/{propertyId}/versions/{propertyVersion}/hostnames{?contractId,groupId,validateHostnames}
+func patchHostnames(property *papi.Property, patch *hostnamesPatch) ([]*papi.Hostname, error) {
+	log.Printf("[DEBUG] Patching hostnames of %s v%d: %d added or updated, %d removed\n", property.PropertyID, property.LatestVersion, len(patch.Add), len(patch.Remove))
+
+	req, err := client.NewJSONRequest(
+		papi.Config,
+		"PATCH",
+		fmt.Sprintf(
+			"/papi/v1/properties/%s/versions/%d/hostnames?contractId=%s&groupId=%s&validateHostnames=true",
+			property.PropertyID,
+			property.LatestVersion,
+			property.Contract.ContractID,
+			property.Group.GroupID,
+		),
+		patch,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	patched := &hostnamesPatchResponse{}
+	if err := client.BodyJSON(res, patched); err != nil {
+		return nil, err
+	}
+
+	if len(patched.Errors) > 0 {
+		return nil, hostnameErrors(patched.Errors)
+	}
+
+	return patched.Hostnames.Items, nil
+}
+
+// hostnameErrors reports the rejected hostnames one per line, sorted, followed
+// by any errors that do not concern a single hostname
+func hostnameErrors(errs []*hostnameError) error {
+	var hostnames, general []string
+	for _, e := range errs {
+		message := e.Title
+		if e.Detail != "" {
+			message = fmt.Sprintf("%s: %s", e.Title, e.Detail)
+		}
+
+		if e.CnameFrom != "" {
+			hostnames = append(hostnames, fmt.Sprintf("\n  %s: %s", e.CnameFrom, message))
+		} else {
+			general = append(general, fmt.Sprintf("\n  %s", message))
+		}
+	}
+	sort.Strings(hostnames)
+
+	return fmt.Errorf("%d hostname errors:%s", len(errs), strings.Join(append(hostnames, general...), ""))
+}
@@ -0,0 +1,52 @@
+package akamai
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+)
+
+func TestDiffHostnames(t *testing.T) {
+	hostnames := papi.NewHostnames()
+	hostnames.Hostnames.Items = []*papi.Hostname{
+		{CnameFrom: "www.example.com", EdgeHostnameID: "ehn_1"},
+		{CnameFrom: "api.example.com", EdgeHostnameID: "ehn_1"},
+		{CnameFrom: "old.example.com", EdgeHostnameID: "ehn_1"},
+	}
+
+	patch := diffHostnames(hostnames, map[string]*papi.EdgeHostname{
+		"www.example.com": {EdgeHostnameID: "ehn_1"},
+		"api.example.com": {EdgeHostnameID: "ehn_2"},
+		"new.example.com": {EdgeHostnameID: "ehn_1"},
+	})
+
+	if len(patch.Add) != 2 || patch.Add[0].CnameFrom != "api.example.com" || patch.Add[1].CnameFrom != "new.example.com" {
+		t.Errorf("expected api and new to be added, got %v", patch.Add)
+	}
+	if !reflect.DeepEqual(patch.Remove, []string{"old.example.com"}) {
+		t.Errorf("expected old to be removed, got %v", patch.Remove)
+	}
+
+	unchanged := diffHostnames(hostnames, map[string]*papi.EdgeHostname{
+		"www.example.com": {EdgeHostnameID: "ehn_1"},
+		"api.example.com": {EdgeHostnameID: "ehn_1"},
+		"old.example.com": {EdgeHostnameID: "ehn_1"},
+	})
+	if !unchanged.empty() {
+		t.Errorf("expected no change, got %v", unchanged)
+	}
+}
+
+func TestHostnameErrors(t *testing.T) {
+	err := hostnameErrors([]*hostnameError{
+		{Title: "Invalid hostname", Detail: "contains spaces", CnameFrom: "w ww.example.com"},
+		{Title: "Duplicate hostname", CnameFrom: "api.example.com"},
+		{Title: "Too many hostnames"},
+	})
+
+	expected := "3 hostname errors:\n  api.example.com: Duplicate hostname\n  w ww.example.com: Invalid hostname: contains spaces\n  Too many hostnames"
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+}
@@ -0,0 +1,393 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+)
+
+// Includes
+//
+// Includes are rule fragments with their own versions and activations, which
+// properties reference with the include behavior. The edgegrid client does not
+// support them, so the PAPI endpoints are called directly.
+//
+// https://developer.akamai.com/api/core_features/property_manager/v1.html#includes
+
+var includeTypes = []string{"MICROSERVICES", "COMMON_SETTINGS"}
+
+type papiInclude struct {
+	AccountID         string `json:"accountId"`
+	ContractID        string `json:"contractId"`
+	GroupID           string `json:"groupId"`
+	IncludeID         string `json:"includeId"`
+	IncludeName       string `json:"includeName"`
+	IncludeType       string `json:"includeType"`
+	LatestVersion     int    `json:"latestVersion"`
+	StagingVersion    int    `json:"stagingVersion"`
+	ProductionVersion int    `json:"productionVersion"`
+}
+
+type papiIncludes struct {
+	Includes struct {
+		Items []*papiInclude `json:"items"`
+	} `json:"includes"`
+}
+
+// getIncludes lists the includes in a contract and group
+//
+// Endpoint: GET /papi/v1/includes{?contractId,groupId}
+func getIncludes(contractID string, groupID string) ([]*papiInclude, error) {
+	log.Printf("[DEBUG] Listing includes in %s, %s\n", contractID, groupID)
+
+	req, err := client.NewRequest(
+		papi.Config,
+		"GET",
+		fmt.Sprintf("/papi/v1/includes?contractId=%s&groupId=%s", contractID, groupID),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	includes := &papiIncludes{}
+	if err := client.BodyJSON(res, includes); err != nil {
+		return nil, err
+	}
+
+	return includes.Includes.Items, nil
+}
+
+// getInclude returns the include, or nil if it does not exist
+//
+// Endpoint: GET /papi/v1/includes/{includeId}{?contractId,groupId}
+func getInclude(contractID string, groupID string, includeID string) (*papiInclude, error) {
+	req, err := client.NewRequest(
+		papi.Config,
+		"GET",
+		fmt.Sprintf("/papi/v1/includes/%s?contractId=%s&groupId=%s", includeID, contractID, groupID),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.IsError(res) {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, client.NewAPIError(res)
+	}
+
+	includes := &papiIncludes{}
+	if err := client.BodyJSON(res, includes); err != nil {
+		return nil, err
+	}
+
+	if len(includes.Includes.Items) == 0 {
+		return nil, nil
+	}
+
+	return includes.Includes.Items[0], nil
+}
+
+// createInclude creates an include and returns its ID
+//
+// Endpoint: POST /papi/v1/includes{?contractId,groupId}
+func createInclude(contractID string, groupID string, productID string, name string, includeType string, ruleFormat string) (string, error) {
+	log.Printf("[DEBUG] Creating %s include %s\n", includeType, name)
+
+	body := map[string]interface{}{
+		"includeName": name,
+		"includeType": includeType,
+		"productId":   productID,
+	}
+	if ruleFormat != "" {
+		body["ruleFormat"] = ruleFormat
+	}
+
+	req, err := client.NewJSONRequest(
+		papi.Config,
+		"POST",
+		fmt.Sprintf("/papi/v1/includes?contractId=%s&groupId=%s", contractID, groupID),
+		body,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return "", err
+	}
+
+	if client.IsError(res) {
+		return "", client.NewAPIError(res)
+	}
+
+	created := struct {
+		IncludeLink string `json:"includeLink"`
+	}{}
+	if err := client.BodyJSON(res, &created); err != nil {
+		return "", err
+	}
+
+	return linkID(created.IncludeLink), nil
+}
+
+// deleteInclude deletes an include, which PAPI only allows while none of its
+// versions is active
+//
+// Endpoint: DELETE /papi/v1/includes/{includeId}{?contractId,groupId}
+func deleteInclude(include *papiInclude) error {
+	log.Printf("[DEBUG] Deleting include %s\n", include.IncludeID)
+
+	req, err := client.NewRequest(
+		papi.Config,
+		"DELETE",
+		fmt.Sprintf("/papi/v1/includes/%s?contractId=%s&groupId=%s", include.IncludeID, include.ContractID, include.GroupID),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return err
+	}
+
+	if client.IsError(res) && res.StatusCode != 404 {
+		return client.NewAPIError(res)
+	}
+
+	return nil
+}
+
+type papiIncludeVersion struct {
+	IncludeVersion   int    `json:"includeVersion"`
+	ProductID        string `json:"productId"`
+	RuleFormat       string `json:"ruleFormat"`
+	StagingStatus    string `json:"stagingStatus"`
+	ProductionStatus string `json:"productionStatus"`
+}
+
+// editable reports whether the version has never been activated, so that its
+// rules can still be changed
+func (version *papiIncludeVersion) editable() bool {
+	return version.StagingStatus == string(papi.StatusInactive) && version.ProductionStatus == string(papi.StatusInactive)
+}
+
+// getIncludeVersion returns a version of the include
+//
+// Endpoint: GET /papi/v1/includes/{includeId}/versions/{includeVersion}{?contractId,groupId}
+func getIncludeVersion(include *papiInclude, version int) (*papiIncludeVersion, error) {
+	req, err := client.NewRequest(
+		papi.Config,
+		"GET",
+		fmt.Sprintf(
+			"/papi/v1/includes/%s/versions/%d?contractId=%s&groupId=%s",
+			include.IncludeID,
+			version,
+			include.ContractID,
+			include.GroupID,
+		),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	versions := struct {
+		Versions struct {
+			Items []*papiIncludeVersion `json:"items"`
+		} `json:"versions"`
+	}{}
+	if err := client.BodyJSON(res, &versions); err != nil {
+		return nil, err
+	}
+
+	if len(versions.Versions.Items) == 0 {
+		return nil, fmt.Errorf("version %d of %s does not exist", version, include.IncludeID)
+	}
+
+	return versions.Versions.Items[0], nil
+}
+
+// ensureEditableIncludeVersion returns the latest version of the include, after
+// creating a new one from it if it has been activated
+//
+// Endpoint: POST /papi/v1/includes/{includeId}/versions{?contractId,groupId}
+func ensureEditableIncludeVersion(include *papiInclude) (int, error) {
+	latest, err := getIncludeVersion(include, include.LatestVersion)
+	if err != nil {
+		return 0, err
+	}
+
+	if latest.editable() {
+		return latest.IncludeVersion, nil
+	}
+
+	req, err := client.NewJSONRequest(
+		papi.Config,
+		"POST",
+		fmt.Sprintf("/papi/v1/includes/%s/versions?contractId=%s&groupId=%s", include.IncludeID, include.ContractID, include.GroupID),
+		map[string]interface{}{
+			"createFromVersion": latest.IncludeVersion,
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return 0, err
+	}
+
+	if client.IsError(res) {
+		return 0, client.NewAPIError(res)
+	}
+
+	created := struct {
+		VersionLink string `json:"versionLink"`
+	}{}
+	if err := client.BodyJSON(res, &created); err != nil {
+		return 0, err
+	}
+
+	version, err := strconv.Atoi(linkID(created.VersionLink))
+	if err != nil {
+		return 0, fmt.Errorf("unexpected version link \"%s\"", created.VersionLink)
+	}
+
+	log.Printf("[DEBUG] Created version %d of %s from version %d\n", version, include.IncludeID, latest.IncludeVersion)
+	return version, nil
+}
+
+// getIncludeRules fetches the rules of an include version, converted to the rule
+// format if one is given. The include rules have the same shape as property rules.
+//
+// Endpoint: GET /papi/v1/includes/{includeId}/versions/{includeVersion}/rules{?contractId,groupId}
+func getIncludeRules(include *papiInclude, version int, format string) (*papi.Rules, error) {
+	req, err := client.NewRequest(
+		papi.Config,
+		"GET",
+		fmt.Sprintf(
+			"/papi/v1/includes/%s/versions/%d/rules?contractId=%s&groupId=%s",
+			include.IncludeID,
+			version,
+			include.ContractID,
+			include.GroupID,
+		),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if format != "" {
+		req.Header.Set("Accept", ruleFormatMediaType(format))
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	rules := papi.NewRules()
+	if err := client.BodyJSON(res, rules); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// saveIncludeRules saves the rule tree of an include version in the rule format
+// if one is given, reporting any validation errors. With dryRun the rules are
+// only validated.
+//
+// Endpoint: PUT /papi/v1/includes/{includeId}/versions/{includeVersion}/rules{?contractId,groupId,validateRules,dryRun}
+func saveIncludeRules(include *papiInclude, version int, rules *papi.Rules, format string, dryRun bool) error {
+	req, err := client.NewJSONRequest(
+		papi.Config,
+		"PUT",
+		fmt.Sprintf(
+			"/papi/v1/includes/%s/versions/%d/rules?contractId=%s&groupId=%s&validateRules=true&dryRun=%t",
+			include.IncludeID,
+			version,
+			include.ContractID,
+			include.GroupID,
+			dryRun,
+		),
+		map[string]interface{}{
+			"rules": rules.Rule,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if format != "" {
+		req.Header.Set("Content-Type", ruleFormatMediaType(format))
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return err
+	}
+
+	if client.IsError(res) {
+		return client.NewAPIError(res)
+	}
+
+	saved := papi.NewRules()
+	if err := client.BodyJSON(res, saved); err != nil {
+		return err
+	}
+
+	if len(saved.Errors) > 0 {
+		return rulesValidationError(saved)
+	}
+
+	return nil
+}
+
+// linkID returns the last path segment of a PAPI link, e.g. the include ID of
+// /papi/v1/includes/inc_123?contractId=ctr_1&groupId=grp_2
+func linkID(link string) string {
+	if i := strings.Index(link, "?"); i >= 0 {
+		link = link[:i]
+	}
+
+	return link[strings.LastIndex(link, "/")+1:]
+}
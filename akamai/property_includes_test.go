@@ -0,0 +1,38 @@
+package akamai
+
+import (
+	"testing"
+)
+
+func TestLinkID(t *testing.T) {
+	tests := map[string]string{
+		"/papi/v1/includes/inc_123?contractId=ctr_1&groupId=grp_2":            "inc_123",
+		"/papi/v1/includes/inc_123/versions/4?contractId=ctr_1&groupId=grp_2": "4",
+		"/papi/v1/includes/inc_123":                                           "inc_123",
+	}
+
+	for link, expected := range tests {
+		if id := linkID(link); id != expected {
+			t.Errorf("expected %s for %s, got %s", expected, link, id)
+		}
+	}
+}
+
+func TestIncludeVersionEditable(t *testing.T) {
+	tests := []struct {
+		staging    string
+		production string
+		editable   bool
+	}{
+		{"INACTIVE", "INACTIVE", true},
+		{"ACTIVE", "INACTIVE", false},
+		{"INACTIVE", "DEACTIVATED", false},
+	}
+
+	for _, test := range tests {
+		version := &papiIncludeVersion{StagingStatus: test.staging, ProductionStatus: test.production}
+		if version.editable() != test.editable {
+			t.Errorf("expected editable to be %t for %s/%s", test.editable, test.staging, test.production)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package akamai
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Rule formats
+//
+// rule_format pins a property to a frozen rule format such as v2018-02-27, or to
+// latest. Changing it upgrades the rules: a new version is created, and its rules
+// are fetched converted to the new format by PAPI and saved in that format. The
+// conversion is tried during plan, so that behaviors or criteria that are not
+// available in the new format are reported before apply.
+
+var ruleFormatRegexp = regexp.MustCompile(`^(latest|v[0-9]{4}-[0-9]{2}-[0-9]{2})$`)
+
+func ruleFormatMediaType(format string) string {
+	return fmt.Sprintf("application/vnd.akamai.papirules.%s+json", format)
+}
+
+// getRulesInFormat fetches the rules of the property's latest version, converted to
+// the rule format if one is given
+//
+// Endpoint: GET /papi/v1/properties/{propertyId}/versions/{propertyVersion}/rules{?contractId,groupId}
+func getRulesInFormat(property *papi.Property, format string) (*papi.Rules, error) {
+	if format == "" {
+		return property.GetRules()
+	}
+
+	req, err := client.NewRequest(
+		papi.Config,
+		"GET",
+		fmt.Sprintf(
+			"/papi/v1/properties/%s/versions/%d/rules?contractId=%s&groupId=%s",
+			property.PropertyID,
+			property.LatestVersion,
+			property.ContractID,
+			property.GroupID,
+		),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", ruleFormatMediaType(format))
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	rules := papi.NewRules()
+	if err = client.BodyJSON(res, rules); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// resourceCheckRuleFormat checks that a changed rule_format exists and, for existing
+// properties, that the rules of the current version can be converted to it
+func resourceCheckRuleFormat(d *schema.ResourceDiff) error {
+	format := d.Get("rule_format").(string)
+	if !d.HasChange("rule_format") || format == "" {
+		return nil
+	}
+
+	if format != "latest" {
+		formats := papi.NewRuleFormats()
+		if err := formats.GetRuleFormats(); err != nil {
+			return err
+		}
+
+		if !stringInSlice(format, formats.RuleFormats.Items) {
+			return fmt.Errorf("rule format %s does not exist, available rule formats: %s", format, strings.Join(formats.RuleFormats.Items, ", "))
+		}
+	}
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	property := papi.NewProperty(papi.NewProperties())
+	property.PropertyID = d.Id()
+	if err := property.GetProperty(); err != nil {
+		return err
+	}
+	if version, ok := d.Get("version").(int); ok && version > 0 {
+		property.LatestVersion = version
+	}
+
+	if _, err := getRulesInFormat(property, format); err != nil {
+		return fmt.Errorf("the rules of %s v%d cannot be upgraded to rule format %s: %s", property.PropertyID, property.LatestVersion, format, err)
+	}
+
+	return nil
+}
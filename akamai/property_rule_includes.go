@@ -0,0 +1,144 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Include references
+//
+// An include block in a rule adds the include behavior, which composes the
+// version of the include active on the network the property is activated on.
+// The optional version is not sent to PAPI; it is checked during plan to exist,
+// so that a property is not planned against an include version that was never
+// created.
+
+var akpsInclude = &schema.Schema{
+	Type:     schema.TypeSet,
+	Optional: true,
+	MaxItems: 1,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"include_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+		},
+	},
+}
+
+type includeReference struct {
+	IncludeID string
+	Version   int
+}
+
+// mergeIncludeBehavior adds the include behavior for the rule's include block, if any
+func mergeIncludeBehavior(rule *papi.Rule, ruleTree map[string]interface{}) {
+	include, ok := ruleTree["include"]
+	if !ok {
+		return
+	}
+
+	for _, i := range include.(*schema.Set).List() {
+		behavior := papi.NewBehavior()
+		behavior.Name = "include"
+		behavior.Options = papi.OptionValue{
+			"id": i.(map[string]interface{})["include_id"].(string),
+		}
+		rule.MergeBehavior(behavior)
+	}
+}
+
+// includeReferences returns the include blocks anywhere in the rule tree
+func includeReferences(rules []interface{}) []includeReference {
+	var references []includeReference
+	for _, r := range rules {
+		ruleTree, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if include, ok := ruleTree["include"].(*schema.Set); ok {
+			for _, i := range include.List() {
+				i := i.(map[string]interface{})
+				references = append(references, includeReference{
+					IncludeID: i["include_id"].(string),
+					Version:   i["version"].(int),
+				})
+			}
+		}
+
+		if childRules, ok := ruleTree["rule"].(*schema.Set); ok {
+			references = append(references, includeReferences(childRules.List())...)
+		}
+	}
+
+	return references
+}
+
+// resourceCheckIncludeReferences checks that the includes referenced by changed
+// rules exist, as do the versions given
+func resourceCheckIncludeReferences(d *schema.ResourceDiff) error {
+	if !d.HasChange("rules") || !d.NewValueKnown("rules") {
+		return nil
+	}
+
+	references := includeReferences(d.Get("rules").(*schema.Set).List())
+	if len(references) == 0 {
+		return nil
+	}
+
+	contractID, groupID, err := includeReferenceScope(d)
+	if err != nil {
+		return err
+	}
+	if contractID == "" || groupID == "" {
+		log.Println("[DEBUG] Contract and group are not known until apply, skipping include checks")
+		return nil
+	}
+
+	for _, reference := range references {
+		include, err := getInclude(contractID, groupID, reference.IncludeID)
+		if err != nil {
+			return err
+		}
+		if include == nil {
+			return fmt.Errorf("include %s does not exist in %s, %s", reference.IncludeID, contractID, groupID)
+		}
+		if reference.Version > include.LatestVersion {
+			return fmt.Errorf("version %d of include %s does not exist, the latest version is %d", reference.Version, reference.IncludeID, include.LatestVersion)
+		}
+	}
+
+	return nil
+}
+
+// includeReferenceScope returns the contract and group the rules belong to,
+// looking up the property for resources that only reference one
+func includeReferenceScope(d *schema.ResourceDiff) (string, string, error) {
+	contractID, _ := d.Get("contract_id").(string)
+	groupID, _ := d.Get("group_id").(string)
+	if contractID != "" && groupID != "" {
+		return contractID, groupID, nil
+	}
+
+	propertyID, _ := d.Get("property_id").(string)
+	if propertyID == "" || !d.NewValueKnown("property_id") {
+		return "", "", nil
+	}
+
+	property := papi.NewProperty(papi.NewProperties())
+	property.PropertyID = propertyID
+	if err := property.GetProperty(); err != nil {
+		return "", "", err
+	}
+
+	return property.ContractID, property.GroupID, nil
+}
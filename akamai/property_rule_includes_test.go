@@ -0,0 +1,41 @@
+package akamai
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func testIncludeSet(includeID string, version int) *schema.Set {
+	return schema.NewSet(schema.HashResource(akpsInclude.Elem.(*schema.Resource)), []interface{}{
+		map[string]interface{}{"include_id": includeID, "version": version},
+	})
+}
+
+func TestMergeIncludeBehavior(t *testing.T) {
+	rule := papi.NewRule()
+	mergeIncludeBehavior(rule, map[string]interface{}{"include": testIncludeSet("inc_1", 2)})
+
+	if len(rule.Behaviors) != 1 || rule.Behaviors[0].Name != "include" || rule.Behaviors[0].Options["id"] != "inc_1" {
+		t.Fatalf("expected an include behavior for inc_1, got %v", rule.Behaviors)
+	}
+}
+
+func TestIncludeReferences(t *testing.T) {
+	childRules := schema.NewSet(func(v interface{}) int {
+		return len(v.(map[string]interface{})["name"].(string))
+	}, []interface{}{
+		map[string]interface{}{"name": "checkout", "include": testIncludeSet("inc_2", 0)},
+	})
+
+	references := includeReferences([]interface{}{
+		map[string]interface{}{"include": testIncludeSet("inc_1", 3), "rule": childRules},
+	})
+
+	expected := []includeReference{{"inc_1", 3}, {"inc_2", 0}}
+	if !reflect.DeepEqual(references, expected) {
+		t.Fatalf("expected %v, got %v", expected, references)
+	}
+}
@@ -0,0 +1,182 @@
+package akamai
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Property rule linting
+//
+// Lint policies are evaluated during plan against the rule tree described by
+// the `rules` block, so that violations are reported before a new property
+// version is created.
+type ruleLintPolicy struct {
+	description string
+	check       func(rules *papi.Rules) []string
+}
+
+var ruleLintPolicies = map[string]ruleLintPolicy{
+	"require_https_redirect": {
+		description: "an HTTP to HTTPS redirect is required",
+		check: func(rules *papi.Rules) []string {
+			var found bool
+			walkRules(rules.Rule, "default", func(rule *papi.Rule, path string) {
+				for _, behavior := range rule.Behaviors {
+					if behavior.Name == "redirect" && behavior.Options["destinationProtocol"] == "HTTPS" {
+						found = true
+					}
+				}
+			})
+
+			if !found {
+				return []string{"no redirect behavior with destinationProtocol HTTPS found"}
+			}
+			return nil
+		},
+	},
+	"forbid_set_cookie_caching": {
+		description: "responses to cookie-bearing requests (which may carry Set-Cookie) must not be cached",
+		check: func(rules *papi.Rules) []string {
+			var violations []string
+			walkRules(rules.Rule, "default", func(rule *papi.Rule, path string) {
+				matchesCookie := false
+				for _, criteria := range rule.Criteria {
+					if criteria.Name == "cookie" {
+						matchesCookie = true
+					}
+				}
+
+				if !matchesCookie {
+					return
+				}
+
+				for _, behavior := range rule.Behaviors {
+					if behavior.Name != "caching" {
+						continue
+					}
+
+					switch behavior.Options["behavior"] {
+					case "NO_STORE", "BYPASS_CACHE":
+					default:
+						violations = append(violations, fmt.Sprintf("rule %q caches requests matched on a cookie", path))
+					}
+				}
+			})
+			return violations
+		},
+	},
+	"require_hsts": {
+		description: "HTTP Strict Transport Security must be enabled",
+		check: func(rules *papi.Rules) []string {
+			var found bool
+			walkRules(rules.Rule, "default", func(rule *papi.Rule, path string) {
+				for _, behavior := range rule.Behaviors {
+					if behavior.Name == "httpStrictTransportSecurity" && behavior.Options["enable"] == true {
+						found = true
+					}
+				}
+			})
+
+			if !found {
+				return []string{"no enabled httpStrictTransportSecurity behavior found"}
+			}
+			return nil
+		},
+	},
+}
+
+var akpsRulesLint = &schema.Schema{
+	Type:     schema.TypeSet,
+	Optional: true,
+	MaxItems: 1,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"require_https_redirect": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"forbid_set_cookie_caching": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"require_hsts": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"enforce": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	},
+}
+
+// walkRules calls fn for the given rule and all of its descendants
+func walkRules(rule *papi.Rule, path string, fn func(rule *papi.Rule, path string)) {
+	if rule == nil {
+		return
+	}
+
+	fn(rule, path)
+	for _, child := range rule.Children {
+		walkRules(child, path+"/"+child.Name, fn)
+	}
+}
+
+// lintRules runs the enabled policies against the rule tree, returning a
+// sorted list of violations
+func lintRules(rules *papi.Rules, enabled map[string]bool) []string {
+	var violations []string
+	for name, policy := range ruleLintPolicies {
+		if !enabled[name] {
+			continue
+		}
+
+		for _, violation := range policy.check(rules) {
+			violations = append(violations, fmt.Sprintf("%s (%s): %s", name, policy.description, violation))
+		}
+	}
+
+	sort.Strings(violations)
+	return violations
+}
+
+func resourcePropertyLintRules(d *schema.ResourceDiff) error {
+	lint, ok := d.GetOk("rules_lint")
+	if !ok || lint.(*schema.Set).Len() == 0 {
+		return nil
+	}
+
+	config := lint.(*schema.Set).List()[0].(map[string]interface{})
+	enabled := make(map[string]bool)
+	for name := range ruleLintPolicies {
+		enabled[name] = config[name].(bool)
+	}
+
+	rules := papi.NewRules()
+	unmarshalRules(d, rules)
+
+	violations := lintRules(rules, enabled)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	if !config["enforce"].(bool) {
+		for _, violation := range violations {
+			log.Printf("[WARN] Property rules lint: %s\n", violation)
+		}
+		return nil
+	}
+
+	return errors.New("Error - Property rules lint failed:\n " + strings.Join(violations, "\n "))
+}
@@ -0,0 +1,67 @@
+package akamai
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+)
+
+func testLintRules() *papi.Rules {
+	rules := papi.NewRules()
+
+	redirect := papi.NewBehavior()
+	redirect.Name = "redirect"
+	redirect.Options = papi.OptionValue{"destinationProtocol": "HTTPS"}
+	rules.Rule.MergeBehavior(redirect)
+
+	cookieRule := papi.NewRule()
+	cookieRule.Name = "Logged In"
+	cookie := papi.NewCriteria()
+	cookie.Name = "cookie"
+	cookieRule.MergeCriteria(cookie)
+	caching := papi.NewBehavior()
+	caching.Name = "caching"
+	caching.Options = papi.OptionValue{"behavior": "MAX_AGE"}
+	cookieRule.MergeBehavior(caching)
+	rules.Rule.MergeChildRule(cookieRule)
+
+	return rules
+}
+
+func TestLintRules_disabled(t *testing.T) {
+	if violations := lintRules(testLintRules(), map[string]bool{}); len(violations) != 0 {
+		t.Fatalf("expected no violations, got %v", violations)
+	}
+}
+
+func TestLintRules_requireHTTPSRedirect(t *testing.T) {
+	if violations := lintRules(testLintRules(), map[string]bool{"require_https_redirect": true}); len(violations) != 0 {
+		t.Fatalf("expected no violations, got %v", violations)
+	}
+
+	if violations := lintRules(papi.NewRules(), map[string]bool{"require_https_redirect": true}); len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %v", violations)
+	}
+}
+
+func TestLintRules_forbidSetCookieCaching(t *testing.T) {
+	violations := lintRules(testLintRules(), map[string]bool{"forbid_set_cookie_caching": true})
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %v", violations)
+	}
+}
+
+func TestLintRules_requireHSTS(t *testing.T) {
+	rules := testLintRules()
+	if violations := lintRules(rules, map[string]bool{"require_hsts": true}); len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %v", violations)
+	}
+
+	hsts := papi.NewBehavior()
+	hsts.Name = "httpStrictTransportSecurity"
+	hsts.Options = papi.OptionValue{"enable": true}
+	rules.Rule.MergeBehavior(hsts)
+	if violations := lintRules(rules, map[string]bool{"require_hsts": true}); len(violations) != 0 {
+		t.Fatalf("expected no violations, got %v", violations)
+	}
+}
@@ -0,0 +1,114 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Rule validation during plan
+//
+// With validate_rules_on_plan, changed rules of existing properties are saved with
+// PAPI's dryRun during plan, which validates them against the product and rule
+// format without persisting them or creating a version. This reports the rule
+// validation errors that would otherwise only appear halfway through the apply.
+
+var akpsValidateRulesOnPlan = &schema.Schema{
+	Type:     schema.TypeBool,
+	Optional: true,
+	Default:  false,
+}
+
+// ruleValidationDiff is the part of schema.ResourceDiff used by the rule validation
+type ruleValidationDiff interface {
+	resourceGetter
+	Id() string
+	Get(string) interface{}
+	HasChange(string) bool
+	NewValueKnown(string) bool
+}
+
+func resourcePropertyValidateRules(d ruleValidationDiff) error {
+	if d.Id() == "" || !d.Get("validate_rules_on_plan").(bool) || !d.HasChange("rules") {
+		return nil
+	}
+
+	if !d.NewValueKnown("rules") {
+		log.Println("[DEBUG] Rules are not known until apply, skipping validation")
+		return nil
+	}
+
+	property := papi.NewProperty(papi.NewProperties())
+	// not in the akamai_property schema
+	property.PropertyID, _ = d.Get("property_id").(string)
+	if property.PropertyID == "" {
+		property.PropertyID = d.Id()
+	}
+	if err := property.GetProperty(); err != nil {
+		return err
+	}
+	if version, ok := d.Get("version").(int); ok && version > 0 {
+		property.LatestVersion = version
+	}
+
+	// not in the akamai_property_rules schema
+	ruleFormat, _ := d.Get("rule_format").(string)
+	rules, err := getRulesInFormat(property, ruleFormat)
+	if err != nil {
+		return err
+	}
+
+	unmarshalRules(d, rules)
+	if err := substituteSensitiveOptions(d, rules); err != nil {
+		return err
+	}
+
+	return validateRules(rules, ruleFormat)
+}
+
+// validateRules validates the rules without saving them
+//
+// Endpoint: PUT /papi/v1/properties/{propertyId}/versions/{propertyVersion}/rules{?contractId,groupId,validateRules,dryRun}
+func validateRules(rules *papi.Rules, format string) error {
+	req, err := client.NewJSONRequest(
+		papi.Config,
+		"PUT",
+		fmt.Sprintf(
+			"/papi/v1/properties/%s/versions/%d/rules?contractId=%s&groupId=%s&validateRules=true&dryRun=true",
+			rules.PropertyID,
+			rules.PropertyVersion,
+			rules.ContractID,
+			rules.GroupID,
+		),
+		rules,
+	)
+	if err != nil {
+		return err
+	}
+	if format != "" {
+		req.Header.Set("Content-Type", ruleFormatMediaType(format))
+	}
+
+	res, err := client.Do(papi.Config, req)
+	if err != nil {
+		return err
+	}
+
+	if client.IsError(res) {
+		return client.NewAPIError(res)
+	}
+
+	validated := papi.NewRules()
+	if err = client.BodyJSON(res, validated); err != nil {
+		return err
+	}
+
+	if len(validated.Errors) > 0 {
+		return rulesValidationError(validated)
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package akamai
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// testRuleValidationDiff stands in for the plan's schema.ResourceDiff
+type testRuleValidationDiff struct {
+	*schema.ResourceData
+}
+
+func (testRuleValidationDiff) NewValueKnown(string) bool {
+	return true
+}
+
+func TestResourcePropertyValidateRules(t *testing.T) {
+	property := `{"properties": {"items": [{
+		"contractId": "ctr_1",
+		"groupId": "grp_1",
+		"propertyId": "prp_1",
+		"latestVersion": 1
+	}]}}`
+	rules := `{
+		"propertyId": "prp_1",
+		"propertyVersion": 1,
+		"contractId": "ctr_1",
+		"groupId": "grp_1",
+		"rules": {"name": "default"}
+	}`
+	invalid := `{
+		"propertyId": "prp_1",
+		"propertyVersion": 1,
+		"errors": [{"type": "/papi/v1/errors/validation.required_behavior", "title": "Missing required behavior", "errorLocation": "#/rules"}]
+	}`
+
+	caching := papi.NewBehavior()
+	caching.Name = "caching"
+	caching.Options = papi.OptionValue{"behavior": "MAX_AGE", "ttl": "1d"}
+	tree := papi.NewRules()
+	tree.Rule.MergeBehavior(caching)
+
+	for validated, expectErr := range map[string]bool{rules: false, invalid: true} {
+		stop := testPAPIServer(t, map[string]string{
+			"GET /papi/v1/groups":                            `{"groups": {"items": [{"groupId": "grp_1", "contractIds": ["ctr_1"]}]}}`,
+			"GET /papi/v1/contracts":                         `{"contracts": {"items": [{"contractId": "ctr_1"}]}}`,
+			"GET /papi/v1/properties/prp_1":                  property,
+			"GET /papi/v1/properties/prp_1/versions/1/rules": rules,
+			"PUT /papi/v1/properties/prp_1/versions/1/rules": validated,
+		})
+
+		d := schema.TestResourceDataRaw(t, resourceProperty().Schema, map[string]interface{}{
+			"validate_rules_on_plan": true,
+			"rules":                  []interface{}{flattenRuleTree(tree.Rule)},
+		})
+		d.SetId("prp_1")
+
+		err := resourcePropertyValidateRules(testRuleValidationDiff{d})
+		stop()
+		if expectErr && err == nil {
+			t.Error("expected the rule validation errors to be reported")
+		} else if !expectErr && err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	}
+}
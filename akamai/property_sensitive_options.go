@@ -0,0 +1,228 @@
+package akamai
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Sensitive options
+//
+// Rule options holding secrets (origin authentication headers, token keys)
+// reference a sensitive_option by name, e.g. value = "sensitive:origin_auth",
+// instead of holding the secret themselves. The secret is substituted when the
+// rule tree is saved, and is kept in the state sealed with the provider's
+// sensitive_options_key rather than in plaintext.
+//
+// Sealing is deterministic so that the sealed value in the state can be compared
+// with the configuration during plan, and can be opened again when the secret is
+// unchanged but the rule tree has to be saved.
+
+const (
+	sensitiveOptionPrefix = "sensitive:"
+	sealedValuePrefix     = "sealed:"
+)
+
+// sensitiveOptionsKey is set from the provider's sensitive_options_key, as StateFunc
+// has no access to the provider configuration
+var sensitiveOptionsKey []byte
+
+var akpsSensitiveOption = &schema.Schema{
+	Type:     schema.TypeList,
+	Optional: true,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"value": &schema.Schema{
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+				StateFunc: func(v interface{}) string {
+					return sealSensitiveValue(v.(string))
+				},
+			},
+		},
+	},
+}
+
+// resourceCheckSensitiveOptions fails the plan when sensitive options are used without a key
+func resourceCheckSensitiveOptions(d *schema.ResourceDiff) error {
+	options, ok := d.GetOk("sensitive_option")
+	if !ok || len(options.([]interface{})) == 0 {
+		return nil
+	}
+
+	if len(sensitiveOptionsKey) == 0 {
+		return errors.New("sensitive_option requires sensitive_options_key to be set on the provider")
+	}
+
+	seen := make(map[string]bool)
+	for _, o := range options.([]interface{}) {
+		name := o.(map[string]interface{})["name"].(string)
+		if seen[name] {
+			return fmt.Errorf("sensitive_option \"%s\" is declared more than once", name)
+		}
+		seen[name] = true
+	}
+
+	return nil
+}
+
+func sensitiveOptionKeys() (cipher.AEAD, []byte, error) {
+	encryptionKey := sha256.Sum256(append([]byte("encryption:"), sensitiveOptionsKey...))
+	macKey := sha256.Sum256(append([]byte("nonce:"), sensitiveOptionsKey...))
+
+	block, err := aes.NewCipher(encryptionKey[:])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return aead, macKey[:], nil
+}
+
+// sealSensitiveValue encrypts the value with AES-GCM, using a nonce derived from the
+// value so that sealing the same value twice gives the same result
+func sealSensitiveValue(value string) string {
+	if strings.HasPrefix(value, sealedValuePrefix) {
+		return value
+	}
+
+	// Only reached when planning fails in resourceCheckSensitiveOptions
+	if len(sensitiveOptionsKey) == 0 {
+		sum := sha256.Sum256([]byte(value))
+		return "sha256:" + hex.EncodeToString(sum[:])
+	}
+
+	aead, macKey, err := sensitiveOptionKeys()
+	if err != nil {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write([]byte(value))
+	nonce := mac.Sum(nil)[:aead.NonceSize()]
+
+	sealed := aead.Seal(nonce, nonce, []byte(value), nil)
+	return sealedValuePrefix + base64.StdEncoding.EncodeToString(sealed)
+}
+
+// openSensitiveValue decrypts a value read from the state, values read from the
+// configuration are returned as is
+func openSensitiveValue(value string) (string, error) {
+	if !strings.HasPrefix(value, sealedValuePrefix) {
+		return value, nil
+	}
+
+	aead, _, err := sensitiveOptionKeys()
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, sealedValuePrefix))
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", errors.New("malformed sealed value")
+	}
+
+	opened, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", errors.New("the value was sealed with a different sensitive_options_key")
+	}
+
+	return string(opened), nil
+}
+
+// substituteSensitiveOptions replaces "sensitive:<name>" option values in the rule tree
+// with the value of the named sensitive_option
+func substituteSensitiveOptions(d resourceGetter, rules *papi.Rules) error {
+	values := make(map[string]string)
+	if options, ok := d.GetOk("sensitive_option"); ok {
+		for _, o := range options.([]interface{}) {
+			option := o.(map[string]interface{})
+			value, err := openSensitiveValue(option["value"].(string))
+			if err != nil {
+				return fmt.Errorf("sensitive_option \"%s\": %s", option["name"], err)
+			}
+			values[option["name"].(string)] = value
+		}
+	}
+
+	return substituteRuleSensitiveOptions(rules.Rule, values)
+}
+
+func substituteRuleSensitiveOptions(rule *papi.Rule, values map[string]string) error {
+	for _, behavior := range rule.Behaviors {
+		if err := substituteOptionValues(behavior.Options, values); err != nil {
+			return fmt.Errorf("rule \"%s\", behavior \"%s\": %s", rule.Name, behavior.Name, err)
+		}
+	}
+
+	for _, criteria := range rule.Criteria {
+		if err := substituteOptionValues(criteria.Options, values); err != nil {
+			return fmt.Errorf("rule \"%s\", criteria \"%s\": %s", rule.Name, criteria.Name, err)
+		}
+	}
+
+	for _, child := range rule.Children {
+		if err := substituteRuleSensitiveOptions(child, values); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func substituteOptionValues(options papi.OptionValue, values map[string]string) error {
+	for key, option := range options {
+		switch v := option.(type) {
+		case string:
+			value, err := sensitiveOptionValue(v, values)
+			if err != nil {
+				return err
+			}
+			options[key] = value
+		case []interface{}:
+			for i, item := range v {
+				if s, ok := item.(string); ok {
+					value, err := sensitiveOptionValue(s, values)
+					if err != nil {
+						return err
+					}
+					v[i] = value
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func sensitiveOptionValue(value string, values map[string]string) (string, error) {
+	if !strings.HasPrefix(value, sensitiveOptionPrefix) {
+		return value, nil
+	}
+
+	name := strings.TrimPrefix(value, sensitiveOptionPrefix)
+	secret, ok := values[name]
+	if !ok {
+		return "", fmt.Errorf("no sensitive_option named \"%s\"", name)
+	}
+
+	return secret, nil
+}
@@ -0,0 +1,58 @@
+package akamai
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+)
+
+func TestSealSensitiveValue(t *testing.T) {
+	sensitiveOptionsKey = []byte("test key")
+	defer func() { sensitiveOptionsKey = nil }()
+
+	sealed := sealSensitiveValue("secret")
+	if !strings.HasPrefix(sealed, sealedValuePrefix) || strings.Contains(sealed, "secret") {
+		t.Fatalf("expected a sealed value, got %s", sealed)
+	}
+	if again := sealSensitiveValue("secret"); again != sealed {
+		t.Fatalf("expected sealing to be deterministic, got %s and %s", sealed, again)
+	}
+	if sealSensitiveValue(sealed) != sealed {
+		t.Fatal("expected a sealed value to be kept as is")
+	}
+
+	opened, err := openSensitiveValue(sealed)
+	if err != nil || opened != "secret" {
+		t.Fatalf("expected secret, got %q (%v)", opened, err)
+	}
+
+	sensitiveOptionsKey = []byte("other key")
+	if _, err := openSensitiveValue(sealed); err == nil {
+		t.Fatal("expected opening with a different key to fail")
+	}
+}
+
+func TestSubstituteSensitiveOptions(t *testing.T) {
+	rules := papi.NewRules()
+	child := papi.NewRule()
+	child.Name = "Origin Auth"
+	header := papi.NewBehavior()
+	header.Name = "modifyOutgoingRequestHeader"
+	header.Options = papi.OptionValue{"headerName": "X-Auth", "newHeaderValue": "sensitive:origin_auth"}
+	child.MergeBehavior(header)
+	rules.Rule.MergeChildRule(child)
+
+	values := map[string]string{"origin_auth": "secret"}
+	if err := substituteRuleSensitiveOptions(rules.Rule, values); err != nil {
+		t.Fatal(err)
+	}
+	if value := rules.Rule.Children[0].Behaviors[0].Options["newHeaderValue"]; value != "secret" {
+		t.Fatalf("expected secret, got %v", value)
+	}
+
+	header.Options["headerName"] = "sensitive:missing"
+	if err := substituteRuleSensitiveOptions(rules.Rule, values); err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Fatalf("expected an error for the missing option, got %v", err)
+	}
+}
@@ -0,0 +1,364 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Property state
+//
+// readPropertyState populates the hostnames, edge hostnames, cp_code and origin
+// of akamai_property from the property version in the state. On import the whole
+// rule tree is populated too, so that a plan after importing is a no-op when the
+// configuration matches. Afterwards only the rules in the rules block are
+// refreshed, see refreshRuleTree, as it is merged onto the product's default rule
+// tree rather than replacing it.
+
+// ruleTreeMaxDepth is the number of nested rule levels in the rules schema
+const ruleTreeMaxDepth = 4
+
+func readPropertyState(d *schema.ResourceData, property *papi.Property, importing bool) error {
+	version := d.Get("version").(int)
+	if version == 0 {
+		version = property.LatestVersion
+	}
+
+	versioned := *property
+	versioned.LatestVersion = version
+
+	rules, err := versioned.GetRules()
+	if err != nil {
+		return err
+	}
+
+	if cpCode, ok := flattenCPCode(rules, d.Get("cp_code").(string)); ok {
+		// A cp_code name is kept unless the CP code of the rules has changed
+		cpCodeID := "cpc_" + strings.TrimPrefix(cpCode, "cpc_")
+		if current := d.Get("cp_code").(string); current == "" || isCPCodeID(current) || d.Get("cp_code_id").(string) != cpCodeID {
+			d.Set("cp_code", cpCode)
+		}
+		d.Set("cp_code_id", cpCodeID)
+	}
+
+	if _, ok := d.GetOk("origin"); ok || importing {
+		if origin, ok := flattenOrigin(rules, d); ok {
+			d.Set("origin", []interface{}{origin})
+		}
+	}
+
+	if importing {
+		d.Set("rules", []interface{}{flattenRuleTree(rules.Rule)})
+	} else if current, ok := d.GetOk("rules"); ok && current.(*schema.Set).Len() > 0 {
+		ruleTree := current.(*schema.Set).List()[0].(map[string]interface{})
+		d.Set("rules", []interface{}{refreshRuleTree(ruleTree, flattenRuleTree(rules.Rule))})
+	}
+
+	// The hostnames of hostname bucket properties are not versioned
+	if d.Get("use_hostname_bucket").(bool) {
+		return nil
+	}
+
+	hostnames, err := property.GetHostnames(&papi.Version{PropertyVersion: version})
+	if err != nil {
+		return err
+	}
+
+	var hostnameList []interface{}
+	edgeHostnames := make(map[string]interface{})
+	for _, hostname := range hostnames.Hostnames.Items {
+		hostnameList = append(hostnameList, hostname.CnameFrom)
+		edgeHostnames[strings.Replace(hostname.CnameFrom, ".", "-", -1)] = hostname.CnameTo
+	}
+	d.Set("hostname", hostnameList)
+	if _, ok := d.GetOk("edge_hostname"); ok || importing {
+		d.Set("edge_hostname", edgeHostnames)
+	}
+
+	return setCertChallenges(d, property, version)
+}
+
+// flattenCPCode returns the CP code of the default rule, in the same format as current
+func flattenCPCode(rules *papi.Rules, current string) (string, bool) {
+	for _, behavior := range rules.Rule.Behaviors {
+		if behavior.Name != "cpCode" {
+			continue
+		}
+
+		value, ok := behavior.Options["value"].(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+
+		id, ok := value["id"].(float64)
+		if !ok {
+			return "", false
+		}
+
+		cpCode := strconv.Itoa(int(id))
+		if strings.HasPrefix(current, "cpc_") {
+			cpCode = "cpc_" + cpCode
+		}
+
+		return cpCode, true
+	}
+
+	return "", false
+}
+
+// flattenOrigin returns the origin block from the origin behavior of the default rule, see createOrigin
+func flattenOrigin(rules *papi.Rules, d *schema.ResourceData) (map[string]interface{}, bool) {
+	for _, behavior := range rules.Rule.Behaviors {
+		if behavior.Name != "origin" {
+			continue
+		}
+
+		// is_secure is not sent to the API
+		origin := map[string]interface{}{
+			"is_secure":                     "false",
+			"port":                          80,
+			"https_port":                    443,
+			"forward_hostname":              "ORIGIN_HOSTNAME",
+			"cache_key_hostname":            "ORIGIN_HOSTNAME",
+			"compress":                      false,
+			"enable_true_client_ip":         false,
+			"true_client_ip_header":         "True-Client-IP",
+			"true_client_ip_client_setting": false,
+		}
+		if current, ok := d.GetOk("origin"); ok && current.(*schema.Set).Len() > 0 {
+			origin["is_secure"] = current.(*schema.Set).List()[0].(map[string]interface{})["is_secure"]
+		}
+
+		options := behavior.Options
+		if v, ok := options["hostname"].(string); ok {
+			origin["hostname"] = v
+		}
+		if v, ok := options["httpPort"].(float64); ok {
+			origin["port"] = int(v)
+		}
+		if v, ok := options["httpsPort"].(float64); ok {
+			origin["https_port"] = int(v)
+		}
+		if v, ok := options["forwardHostHeader"].(string); ok {
+			origin["forward_hostname"] = v
+			if custom, ok := options["customForwardHostHeader"].(string); ok && v == "CUSTOM" {
+				origin["forward_hostname"] = custom
+			}
+		}
+		if v, ok := options["cacheKeyHostname"].(string); ok {
+			origin["cache_key_hostname"] = v
+		}
+		if v, ok := options["compress"].(bool); ok {
+			origin["compress"] = v
+		}
+		if v, ok := options["enableTrueClientIp"].(bool); ok {
+			origin["enable_true_client_ip"] = v
+		}
+		if v, ok := options["trueClientIpHeader"].(string); ok {
+			origin["true_client_ip_header"] = v
+		}
+		if v, ok := options["trueClientIpClientSetting"].(bool); ok {
+			origin["true_client_ip_client_setting"] = v
+		}
+
+		return origin, true
+	}
+
+	return nil, false
+}
+
+// flattenRuleTree returns the rules block for the default rule. The cpCode and origin
+// behaviors are left out, as they are managed by cp_code and origin.
+func flattenRuleTree(rule *papi.Rule) map[string]interface{} {
+	var behaviors []interface{}
+	for _, behavior := range rule.Behaviors {
+		if behavior.Name == "cpCode" || behavior.Name == "origin" {
+			continue
+		}
+		if b, ok := flattenRuleItem(behavior.Name, behavior.Options, rule.Name); ok {
+			behaviors = append(behaviors, b)
+		}
+	}
+
+	var children []interface{}
+	for _, child := range rule.Children {
+		children = append(children, flattenRule(child, 1))
+	}
+
+	return map[string]interface{}{
+		"criteria_match": criteriaMatch(rule),
+		"behavior":       behaviors,
+		"rule":           children,
+	}
+}
+
+func flattenRule(rule *papi.Rule, depth int) map[string]interface{} {
+	var behaviors []interface{}
+	for _, behavior := range rule.Behaviors {
+		if b, ok := flattenRuleItem(behavior.Name, behavior.Options, rule.Name); ok {
+			behaviors = append(behaviors, b)
+		}
+	}
+
+	var criteria []interface{}
+	for _, c := range rule.Criteria {
+		if c, ok := flattenRuleItem(c.Name, c.Options, rule.Name); ok {
+			criteria = append(criteria, c)
+		}
+	}
+
+	flattened := map[string]interface{}{
+		"name":           rule.Name,
+		"comment":        rule.Comments,
+		"criteria_match": criteriaMatch(rule),
+		"criteria":       criteria,
+		"behavior":       behaviors,
+	}
+
+	if depth == ruleTreeMaxDepth {
+		if len(rule.Children) > 0 {
+			log.Printf("[WARN] Rule \"%s\" has child rules nested deeper than %d levels, which are not imported\n", rule.Name, ruleTreeMaxDepth)
+		}
+		return flattened
+	}
+
+	var children []interface{}
+	for _, child := range rule.Children {
+		children = append(children, flattenRule(child, depth+1))
+	}
+	flattened["rule"] = children
+
+	return flattened
+}
+
+func criteriaMatch(rule *papi.Rule) string {
+	if rule.CriteriaMustSatisfy == "" {
+		return "all"
+	}
+
+	return string(rule.CriteriaMustSatisfy)
+}
+
+// flattenRuleItem returns a behavior or criteria block, see extractOptions. Options
+// holding objects cannot be represented, so such behaviors and criteria are left
+// out and remain untouched on the property.
+func flattenRuleItem(name string, options papi.OptionValue, ruleName string) (map[string]interface{}, bool) {
+	var flattened []interface{}
+	for key, value := range options {
+		option := map[string]interface{}{"key": key, "value": "", "values": []interface{}{}}
+
+		switch v := value.(type) {
+		case nil:
+		case map[string]interface{}:
+			log.Printf("[WARN] \"%s\" in rule \"%s\" has object options, which are not imported\n", name, ruleName)
+			return nil, false
+		case []interface{}:
+			var values []interface{}
+			for _, item := range v {
+				if _, ok := item.(map[string]interface{}); ok {
+					log.Printf("[WARN] \"%s\" in rule \"%s\" has object options, which are not imported\n", name, ruleName)
+					return nil, false
+				}
+				values = append(values, fmt.Sprint(item))
+			}
+			option["values"] = values
+		default:
+			option["value"] = fmt.Sprint(v)
+		}
+
+		flattened = append(flattened, option)
+	}
+
+	return map[string]interface{}{"name": name, "option": flattened}, true
+}
+
+// refreshRuleTree returns the rules block in the state refreshed from the flattened
+// rule tree of the property, see flattenRuleTree. As the rules block is merged onto
+// the rule tree, only the behaviors, criteria, options and child rules it holds are
+// refreshed, so that those changed or removed outside of Terraform show as a diff.
+// Options referencing a sensitive_option are kept.
+func refreshRuleTree(current map[string]interface{}, remote map[string]interface{}) map[string]interface{} {
+	refreshed := make(map[string]interface{}, len(current))
+	for key, value := range current {
+		refreshed[key] = value
+	}
+
+	if _, ok := current["comment"]; ok {
+		refreshed["comment"] = remote["comment"]
+	}
+	refreshed["behavior"] = refreshRuleItems(blockList(current["behavior"]), blockList(remote["behavior"]))
+	if _, ok := current["criteria"]; ok {
+		refreshed["criteria"] = refreshRuleItems(blockList(current["criteria"]), blockList(remote["criteria"]))
+	}
+
+	if _, ok := current["rule"]; ok {
+		var children []interface{}
+		for _, child := range blockList(current["rule"]) {
+			child := child.(map[string]interface{})
+			if remoteChild := findBlock(blockList(remote["rule"]), child["name"]); remoteChild != nil {
+				children = append(children, refreshRuleTree(child, remoteChild))
+			}
+		}
+		refreshed["rule"] = children
+	}
+
+	return refreshed
+}
+
+// refreshRuleItems refreshes the options of the behavior or criteria blocks found in remote
+func refreshRuleItems(current []interface{}, remote []interface{}) []interface{} {
+	var refreshed []interface{}
+	for _, item := range current {
+		item := item.(map[string]interface{})
+		remoteItem := findBlock(remote, item["name"])
+		if remoteItem == nil {
+			continue
+		}
+
+		remoteOptions := make(map[string]interface{})
+		for _, option := range blockList(remoteItem["option"]) {
+			remoteOptions[option.(map[string]interface{})["key"].(string)] = option
+		}
+
+		var options []interface{}
+		for _, option := range blockList(item["option"]) {
+			option := option.(map[string]interface{})
+			if value, _ := option["value"].(string); strings.HasPrefix(value, sensitiveOptionPrefix) {
+				options = append(options, option)
+			} else if remoteOption, ok := remoteOptions[option["key"].(string)]; ok {
+				options = append(options, remoteOption)
+			}
+		}
+
+		refreshed = append(refreshed, map[string]interface{}{"name": item["name"], "option": options})
+	}
+
+	return refreshed
+}
+
+// findBlock returns the block with the name, if any
+func findBlock(blocks []interface{}, name interface{}) map[string]interface{} {
+	for _, block := range blocks {
+		if block := block.(map[string]interface{}); block["name"] == name {
+			return block
+		}
+	}
+
+	return nil
+}
+
+// blockList returns the blocks of a list or set read from the state
+func blockList(v interface{}) []interface{} {
+	switch v := v.(type) {
+	case *schema.Set:
+		return v.List()
+	case []interface{}:
+		return v
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package akamai
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestFlattenRuleTree(t *testing.T) {
+	rules := papi.NewRules()
+
+	cpCode := papi.NewBehavior()
+	cpCode.Name = "cpCode"
+	cpCode.Options = papi.OptionValue{"value": map[string]interface{}{"id": float64(12345)}}
+	rules.Rule.MergeBehavior(cpCode)
+
+	caching := papi.NewBehavior()
+	caching.Name = "caching"
+	caching.Options = papi.OptionValue{"behavior": "MAX_AGE", "ttl": "1d"}
+	rules.Rule.MergeBehavior(caching)
+
+	parent := rules.Rule
+	for i := 0; i < ruleTreeMaxDepth+1; i++ {
+		child := papi.NewRule()
+		child.Name = "Level"
+		extensions := papi.NewCriteria()
+		extensions.Name = "fileExtension"
+		extensions.Options = papi.OptionValue{"matchOperator": "IS_ONE_OF", "values": []interface{}{"jpg", "png"}}
+		child.MergeCriteria(extensions)
+		parent.MergeChildRule(child)
+		parent = child
+	}
+
+	if cpCode, ok := flattenCPCode(rules, "cpc_1"); !ok || cpCode != "cpc_12345" {
+		t.Fatalf("expected cpc_12345, got %s", cpCode)
+	}
+
+	tree := flattenRuleTree(rules.Rule)
+	if behaviors := tree["behavior"].([]interface{}); len(behaviors) != 1 {
+		t.Fatalf("expected only the caching behavior, got %v", behaviors)
+	}
+
+	d := schema.TestResourceDataRaw(t, akamaiPropertySchema, map[string]interface{}{})
+	if err := d.Set("rules", []interface{}{tree}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	imported := papi.NewRules()
+	unmarshalRules(d, imported)
+
+	depth := 0
+	for rule := imported.Rule; len(rule.Children) > 0; rule = rule.Children[0] {
+		depth++
+	}
+	if depth != ruleTreeMaxDepth {
+		t.Fatalf("expected %d levels of child rules, got %d", ruleTreeMaxDepth, depth)
+	}
+
+	if values := imported.Rule.Children[0].Criteria[0].Options["values"].([]interface{}); len(values) != 2 {
+		t.Fatalf("expected 2 criteria values, got %v", values)
+	}
+}
+
+func TestRefreshRuleTree(t *testing.T) {
+	rules := papi.NewRules()
+
+	caching := papi.NewBehavior()
+	caching.Name = "caching"
+	caching.Options = papi.OptionValue{"behavior": "MAX_AGE", "ttl": "7d"}
+	rules.Rule.MergeBehavior(caching)
+
+	originAuth := papi.NewBehavior()
+	originAuth.Name = "modifyOutgoingRequestHeader"
+	originAuth.Options = papi.OptionValue{"headerName": "Authorization", "newHeaderValue": "secret"}
+	rules.Rule.MergeBehavior(originAuth)
+
+	// Not in the rules block, so not refreshed
+	allowPost := papi.NewBehavior()
+	allowPost.Name = "allowPost"
+	allowPost.Options = papi.OptionValue{"enabled": true}
+	rules.Rule.MergeBehavior(allowPost)
+
+	images := papi.NewRule()
+	images.Name = "Images"
+	images.Comments = "Changed outside of Terraform"
+	rules.Rule.MergeChildRule(images)
+
+	option := func(key string, value string) map[string]interface{} {
+		return map[string]interface{}{"key": key, "value": value, "values": []interface{}{}}
+	}
+	current := map[string]interface{}{
+		"criteria_match": "all",
+		"behavior": []interface{}{
+			map[string]interface{}{"name": "caching", "option": []interface{}{option("behavior", "MAX_AGE"), option("ttl", "1d")}},
+			map[string]interface{}{"name": "modifyOutgoingRequestHeader", "option": []interface{}{option("headerName", "Authorization"), option("newHeaderValue", "sensitive:origin_auth")}},
+			map[string]interface{}{"name": "gzipResponse", "option": []interface{}{option("behavior", "ALWAYS")}},
+		},
+		"rule": []interface{}{
+			map[string]interface{}{"name": "Images", "comment": "", "behavior": []interface{}{}},
+			map[string]interface{}{"name": "Removed", "comment": "", "behavior": []interface{}{}},
+		},
+	}
+
+	expected := map[string]interface{}{
+		"criteria_match": "all",
+		"behavior": []interface{}{
+			map[string]interface{}{"name": "caching", "option": []interface{}{option("behavior", "MAX_AGE"), option("ttl", "7d")}},
+			map[string]interface{}{"name": "modifyOutgoingRequestHeader", "option": []interface{}{option("headerName", "Authorization"), option("newHeaderValue", "sensitive:origin_auth")}},
+		},
+		"rule": []interface{}{
+			map[string]interface{}{"name": "Images", "comment": "Changed outside of Terraform", "behavior": []interface{}(nil)},
+		},
+	}
+
+	if refreshed := refreshRuleTree(current, flattenRuleTree(rules.Rule)); !reflect.DeepEqual(refreshed, expected) {
+		t.Fatalf("expected %v, got %v", expected, refreshed)
+	}
+}
+
+func TestReadPropertyStateRefreshesRules(t *testing.T) {
+	defer testPAPIServer(t, map[string]string{
+		"GET /papi/v1/properties/prp_1/versions/1/rules": `{"rules": {"name": "default", "behaviors": [
+			{"name": "caching", "options": {"behavior": "MAX_AGE", "ttl": "7d"}}
+		]}}`,
+		"GET /papi/v1/properties/prp_1/versions/1/hostnames/": `{"hostnames": {"items": []}}`,
+		"GET /papi/v1/properties/prp_1/versions/1/hostnames":  `{"hostnames": {"items": []}}`,
+	})()
+
+	d := schema.TestResourceDataRaw(t, akamaiPropertySchema, map[string]interface{}{
+		"version": 1,
+		"rules": []interface{}{map[string]interface{}{
+			"behavior": []interface{}{map[string]interface{}{
+				"name": "caching",
+				"option": []interface{}{
+					map[string]interface{}{"key": "behavior", "value": "MAX_AGE"},
+					map[string]interface{}{"key": "ttl", "value": "1d"},
+				},
+			}},
+		}},
+	})
+	d.SetId("prp_1")
+
+	property := papi.NewProperty(papi.NewProperties())
+	property.PropertyID = "prp_1"
+	property.LatestVersion = 1
+	property.Contract = &papi.Contract{ContractID: "ctr_1"}
+	property.Group = &papi.Group{GroupID: "grp_1"}
+
+	if err := readPropertyState(d, property, false); err != nil {
+		t.Fatal(err)
+	}
+
+	ruleTree := d.Get("rules").(*schema.Set).List()[0].(map[string]interface{})
+	caching := ruleTree["behavior"].(*schema.Set).List()[0].(map[string]interface{})
+	ttl := ""
+	for _, option := range caching["option"].(*schema.Set).List() {
+		if option := option.(map[string]interface{}); option["key"] == "ttl" {
+			ttl = option["value"].(string)
+		}
+	}
+	if ttl != "7d" {
+		t.Fatalf("expected the ttl changed outside of Terraform, got %q", ttl)
+	}
+}
@@ -0,0 +1,165 @@
+package akamai
+
+import (
+	"log"
+	"regexp"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// Token protected delivery
+//
+// The token_auth and jwt_auth blocks configure the verifyTokenAuthorization
+// (EdgeAuth) and verifyJsonWebToken behaviors on the default rule.
+//
+// https://developer.akamai.com/api/luna/papi/behaviors.html#verifytokenauthorization
+// https://developer.akamai.com/api/luna/papi/behaviors.html#verifyjsonwebtoken
+var akpsTokenAuth = &schema.Schema{
+	Type:     schema.TypeSet,
+	Optional: true,
+	MaxItems: 1,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^([0-9a-fA-F]{2})+$"), "must be an even number of hexadecimal characters"),
+			},
+			// The previous key, accepted alongside key while tokens are rotated.
+			// May be empty, as akamai_token_auth_key has no transition key until it is rotated
+			"transition_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^([0-9a-fA-F]{2})*$"), "must be an even number of hexadecimal characters"),
+			},
+			"location": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "COOKIE",
+				ValidateFunc: validation.StringInSlice([]string{"COOKIE", "QUERY_STRING", "CLIENT_REQUEST_HEADER"}, false),
+			},
+			"location_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "__token__",
+			},
+			"algorithm": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "SHA256",
+				ValidateFunc: validation.StringInSlice([]string{"SHA256", "SHA1", "MD5"}, false),
+			},
+			"escape_hmac_inputs": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"ignore_query_string": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"failure_response": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	},
+}
+
+var akpsJWTAuth = &schema.Schema{
+	Type:     schema.TypeSet,
+	Optional: true,
+	MaxItems: 1,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			// The name of the JSON Web Token key set uploaded to the account
+			"jwt": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"location": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "CLIENT_REQUEST_HEADER",
+				ValidateFunc: validation.StringInSlice([]string{"CLIENT_REQUEST_HEADER", "QUERY_STRING"}, false),
+			},
+			"parameter_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Authorization",
+			},
+			"enable_rs256": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"enable_es256": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	},
+}
+
+func updateTokenAuthBehaviors(rules *papi.Rules, d *schema.ResourceData) {
+	if tokenAuth, ok := d.GetOk("token_auth"); ok && tokenAuth.(*schema.Set).Len() > 0 {
+		log.Println("[DEBUG] Setting token authorization")
+		config := tokenAuth.(*schema.Set).List()[0].(map[string]interface{})
+
+		b := papi.NewBehavior()
+		b.Name = "verifyTokenAuthorization"
+		b.Options = papi.OptionValue{
+			"useAdvanced":       false,
+			"location":          config["location"].(string),
+			"locationId":        config["location_id"].(string),
+			"algorithm":         config["algorithm"].(string),
+			"escapeHmacInputs":  config["escape_hmac_inputs"].(bool),
+			"ignoreQueryString": config["ignore_query_string"].(bool),
+			"key":               config["key"].(string),
+			"failureResponse":   config["failure_response"].(bool),
+		}
+		if transitionKey := config["transition_key"].(string); transitionKey != "" {
+			b.Options["transitionKey"] = transitionKey
+		}
+		replaceBehavior(rules.Rule, b)
+	}
+
+	if jwtAuth, ok := d.GetOk("jwt_auth"); ok && jwtAuth.(*schema.Set).Len() > 0 {
+		log.Println("[DEBUG] Setting JSON Web Token verification")
+		config := jwtAuth.(*schema.Set).List()[0].(map[string]interface{})
+
+		b := papi.NewBehavior()
+		b.Name = "verifyJsonWebToken"
+		b.Options = papi.OptionValue{
+			"extractLocation": config["location"].(string),
+			"jwt":             config["jwt"].(string),
+			"enableRS256":     config["enable_rs256"].(bool),
+			"enableES256":     config["enable_es256"].(bool),
+		}
+		if config["location"].(string) == "QUERY_STRING" {
+			b.Options["queryParameterName"] = config["parameter_name"].(string)
+		} else {
+			b.Options["headerName"] = config["parameter_name"].(string)
+		}
+		replaceBehavior(rules.Rule, b)
+	}
+}
+
+// replaceBehavior replaces any behavior of the same name on the rule, or adds it
+func replaceBehavior(rule *papi.Rule, behavior *papi.Behavior) {
+	for key, existingBehavior := range rule.Behaviors {
+		if existingBehavior.Name == behavior.Name {
+			rule.Behaviors[key] = behavior
+			return
+		}
+	}
+
+	rule.Behaviors = append(rule.Behaviors, behavior)
+}
@@ -2,16 +2,28 @@ package akamai
 
 import (
 	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v1"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/hashicorp/terraform/terraform"
 )
 
-// Config contains the Akamai provider configuration (unused).
+// Config contains the Akamai provider configuration.
 type Config struct {
+	// ReadOnly rejects every Create, Update and Delete call.
+	ReadOnly bool
+
+	// StagingContact and ProductionContact are the default activation
+	// notification emails for each network.
+	StagingContact    []string
+	ProductionContact []string
 }
 
 // Provider returns the Akamai terraform.Resource provider.
@@ -32,11 +44,143 @@ func Provider() terraform.ResourceProvider {
 				Type:     schema.TypeString,
 				Default:  "default",
 			},
+			// Defaults to papi_section
+			"gtm_section": &schema.Schema{
+				Optional: true,
+				Type:     schema.TypeString,
+			},
+			// Defaults to papi_section
+			"cps_section": &schema.Schema{
+				Optional: true,
+				Type:     schema.TypeString,
+			},
+			// Defaults to papi_section
+			"appsec_section": &schema.Schema{
+				Optional: true,
+				Type:     schema.TypeString,
+			},
+			// Defaults to papi_section
+			"alerts_section": &schema.Schema{
+				Optional: true,
+				Type:     schema.TypeString,
+			},
+			"fastdns_host": &schema.Schema{
+				Optional: true,
+				Type:     schema.TypeString,
+			},
+			"papi_host": &schema.Schema{
+				Optional: true,
+				Type:     schema.TypeString,
+			},
+			"gtm_host": &schema.Schema{
+				Optional: true,
+				Type:     schema.TypeString,
+			},
+			"cps_host": &schema.Schema{
+				Optional: true,
+				Type:     schema.TypeString,
+			},
+			"appsec_host": &schema.Schema{
+				Optional: true,
+				Type:     schema.TypeString,
+			},
+			"alerts_host": &schema.Schema{
+				Optional: true,
+				Type:     schema.TypeString,
+			},
+			"read_only": &schema.Schema{
+				Optional: true,
+				Type:     schema.TypeBool,
+				Default:  false,
+			},
+			"staging_contact": &schema.Schema{
+				Optional: true,
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"production_contact": &schema.Schema{
+				Optional: true,
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"activation_failure_mode": &schema.Schema{
+				Optional:     true,
+				Type:         schema.TypeString,
+				Default:      "best_effort",
+				ValidateFunc: validation.StringInSlice([]string{"best_effort", "fail_fast"}, false),
+			},
+			// Seals the sensitive_option values of property resources in the state
+			"sensitive_options_key": &schema.Schema{
+				Optional:    true,
+				Type:        schema.TypeString,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("AKAMAI_SENSITIVE_OPTIONS_KEY", nil),
+			},
+			"trace_file": &schema.Schema{
+				Optional: true,
+				Type:     schema.TypeString,
+			},
 		},
-		ResourcesMap: map[string]*schema.Resource{
-			"akamai_cp_code":      resourceCPCode(),
-			"akamai_fastdns_zone": resourceFastDNSZone(),
-			"akamai_property":     resourceProperty(),
+		ResourcesMap: readOnlyResources(map[string]*schema.Resource{
+			"akamai_alert_definition":             resourceAlertDefinition(),
+			"akamai_alert_subscription":           resourceAlertSubscription(),
+			"akamai_appsec_configuration_version": resourceAppSecConfigurationVersion(),
+			"akamai_appsec_custom_rule":           resourceAppSecCustomRule(),
+			"akamai_appsec_custom_rule_action":    resourceAppSecCustomRuleAction(),
+			"akamai_appsec_match_target":          resourceAppSecMatchTarget(),
+			"akamai_appsec_rate_policy":           resourceAppSecRatePolicy(),
+			"akamai_appsec_rate_policy_action":    resourceAppSecRatePolicyAction(),
+			"akamai_appsec_selected_hostnames":    resourceAppSecSelectedHostnames(),
+			"akamai_appsec_waf_mode":              resourceAppSecWAFMode(),
+			"akamai_bulk_property_activation":     resourceBulkPropertyActivation(),
+			"akamai_cp_code":                      resourceCPCode(),
+			"akamai_cps_change_acknowledgement":   resourceCPSChangeAcknowledgement(),
+			"akamai_cps_dv_validation":            resourceCPSDVValidation(),
+			"akamai_cps_third_party_enrollment":   resourceCPSThirdPartyEnrollment(),
+			"akamai_dns_record":                   resourceDNSRecord(),
+			"akamai_dns_tsig_key":                 resourceDNSTSIGKey(),
+			"akamai_dns_zone":                     resourceDNSZone(),
+			"akamai_edge_hostname":                resourceEdgeHostname(),
+			"akamai_fastdns_zone":                 resourceFastDNSZone(),
+			"akamai_gtm_resource":                 resourceGTMResource(),
+			"akamai_property":                     resourceProperty(),
+			"akamai_property_activation":          resourcePropertyActivation(),
+			"akamai_property_bootstrap":           resourcePropertyBootstrap(),
+			"akamai_property_hostname_bucket":     resourcePropertyHostnameBucket(),
+			"akamai_property_hostnames":           resourcePropertyHostnames(),
+			"akamai_property_include":             resourcePropertyInclude(),
+			"akamai_property_rules":               resourcePropertyRules(),
+			"akamai_property_version":             resourcePropertyVersion(),
+			"akamai_token_auth_key":               resourceTokenAuthKey(),
+		}),
+		DataSourcesMap: map[string]*schema.Resource{
+			"akamai_appsec_configuration":   dataSourceAppSecConfiguration(),
+			"akamai_authorities_set":        dataSourceAuthoritiesSet(),
+			"akamai_contract":               dataSourceContract(),
+			"akamai_cp_code":                dataSourceCPCode(),
+			"akamai_cps_deployments":        dataSourceCPSDeployments(),
+			"akamai_cps_dv_challenges":      dataSourceCPSDVChallenges(),
+			"akamai_cps_enrollments":        dataSourceCPSEnrollments(),
+			"akamai_cps_pending_change":     dataSourceCPSPendingChange(),
+			"akamai_custom_behavior":        dataSourceCustomBehavior(),
+			"akamai_custom_override":        dataSourceCustomOverride(),
+			"akamai_dns_record_set":         dataSourceDNSRecordSet(),
+			"akamai_dns_zone_records":       dataSourceDNSZoneRecords(),
+			"akamai_edge_hostname_set":      dataSourceEdgeHostnameSet(),
+			"akamai_edge_hostnames":         dataSourceEdgeHostnames(),
+			"akamai_group":                  dataSourceGroup(),
+			"akamai_gtm_datacenters":        dataSourceGTMDatacenters(),
+			"akamai_gtm_default_datacenter": dataSourceGTMDefaultDatacenter(),
+			"akamai_gtm_domain":             dataSourceGTMDomain(),
+			"akamai_products":               dataSourceProducts(),
+			"akamai_properties":             dataSourceProperties(),
+			"akamai_property":               dataSourceProperty(),
+			"akamai_property_activation":    dataSourcePropertyActivation(),
+			"akamai_property_hostnames":     dataSourcePropertyHostnames(),
+			"akamai_property_includes":      dataSourcePropertyIncludes(),
+			"akamai_property_rules":         dataSourcePropertyRules(),
+			"akamai_rule_behaviors_catalog": dataSourceRuleBehaviorsCatalog(),
+			"akamai_rule_formats":           dataSourceRuleFormats(),
 		},
 		ConfigureFunc: providerConfigure,
 	}
@@ -57,7 +201,76 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		return nil, fmt.Errorf("at least one edgerc section must be defined")
 	}
 
-	return &Config{}, nil
+	if gtmConfig, err = getDirectAPIConfig(d, "gtm_section", "gtm_host"); err != nil {
+		return nil, err
+	}
+
+	if cpsConfig, err = getDirectAPIConfig(d, "cps_section", "cps_host"); err != nil {
+		return nil, err
+	}
+
+	if appsecConfig, err = getDirectAPIConfig(d, "appsec_section", "appsec_host"); err != nil {
+		return nil, err
+	}
+
+	if alertsConfig, err = getDirectAPIConfig(d, "alerts_section", "alerts_host"); err != nil {
+		return nil, err
+	}
+
+	if traceFile, ok := d.GetOk("trace_file"); ok {
+		transport, err := newTracingTransport(traceFile.(string), http.DefaultTransport)
+		if err != nil {
+			return nil, err
+		}
+		client.Client = &http.Client{Transport: transport}
+	}
+
+	activationFailures = newActivationFailureTracker(d.Get("activation_failure_mode").(string) == "fail_fast")
+
+	if key, ok := d.GetOk("sensitive_options_key"); ok {
+		sensitiveOptionsKey = []byte(key.(string))
+	}
+
+	return &Config{
+		ReadOnly:          d.Get("read_only").(bool),
+		StagingContact:    setToStringSlice(d.Get("staging_contact").(*schema.Set)),
+		ProductionContact: setToStringSlice(d.Get("production_contact").(*schema.Set)),
+	}, nil
+}
+
+func setToStringSlice(set *schema.Set) []string {
+	var values []string
+	for _, v := range set.List() {
+		values = append(values, v.(string))
+	}
+
+	return values
+}
+
+// readOnlyResources guards the write operations of every resource so that a
+// provider configured with read_only = true can only refresh and import.
+func readOnlyResources(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	for name, resource := range resources {
+		resource.Create = readOnlyGuard(name, "create", resource.Create)
+		resource.Update = readOnlyGuard(name, "update", resource.Update)
+		resource.Delete = readOnlyGuard(name, "delete", resource.Delete)
+	}
+
+	return resources
+}
+
+func readOnlyGuard(name string, operation string, f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	if f == nil {
+		return nil
+	}
+
+	return func(d *schema.ResourceData, meta interface{}) error {
+		if config, ok := meta.(*Config); ok && config.ReadOnly {
+			return fmt.Errorf("%s: %s is not permitted, the provider is configured with read_only = true", name, operation)
+		}
+
+		return f(d, meta)
+	}
 }
 
 func getConfigDNSV1Service(d *schema.ResourceData) (*edgegrid.Config, error) {
@@ -69,6 +282,11 @@ func getConfigDNSV1Service(d *schema.ResourceData) (*edgegrid.Config, error) {
 		return nil, err
 	}
 
+	fastDNSConfig.Host, err = getHost(d, "fastdns_host", fastDNSConfig.Host)
+	if err != nil {
+		return nil, err
+	}
+
 	dns.Init(fastDNSConfig)
 
 	return &fastDNSConfig, nil
@@ -83,7 +301,69 @@ func getPAPIV1Service(d *schema.ResourceData) (*edgegrid.Config, error) {
 		return nil, err
 	}
 
+	papiConfig.Host, err = getHost(d, "papi_host", papiConfig.Host)
+	if err != nil {
+		return nil, err
+	}
+
 	papi.Init(papiConfig)
 
 	return &papiConfig, nil
 }
+
+// getDirectAPIConfig returns the credentials of an API the edgegrid client has
+// no package for, e.g. GTM, which are kept for its request function. The
+// section defaults to papi_section.
+func getDirectAPIConfig(d *schema.ResourceData, sectionKey string, hostKey string) (edgegrid.Config, error) {
+	edgerc := d.Get("edgerc").(string)
+	section := d.Get("papi_section").(string)
+	if v, ok := d.GetOk(sectionKey); ok {
+		section = v.(string)
+	}
+
+	config, err := edgegrid.Init(edgerc, section)
+	if err != nil {
+		return config, err
+	}
+
+	config.Host, err = getHost(d, hostKey, config.Host)
+	return config, err
+}
+
+// getHost returns the API host for a service, preferring the given provider
+// argument over the host from the credentials. Hosts may include a scheme and
+// a base path, e.g. for beta or partner gateways.
+func getHost(d *schema.ResourceData, key string, host string) (string, error) {
+	if v, ok := d.GetOk(key); ok {
+		host = v.(string)
+	}
+
+	host, err := normalizeHost(host)
+	if err != nil {
+		return "", fmt.Errorf("%s: %s", key, err)
+	}
+
+	return host, nil
+}
+
+func normalizeHost(host string) (string, error) {
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+
+	u, err := url.Parse(host)
+	if err != nil {
+		return "", fmt.Errorf("invalid API host %q: %s", host, err)
+	}
+
+	if u.Scheme != "https" || u.Host == "" || u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("invalid API host %q, expected a hostname with an optional https:// scheme and base path", host)
+	}
+
+	if u.Path == "" || u.Path == "/" {
+		return u.Host, nil
+	}
+
+	// A trailing slash keeps the base path when API paths are resolved against it
+	return "https://" + u.Host + "/" + strings.Trim(u.Path, "/") + "/", nil
+}
@@ -1,8 +1,15 @@
 package akamai
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -23,6 +30,88 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+func TestNormalizeHost(t *testing.T) {
+	cases := map[string]string{
+		"akab-xxx.luna.akamaiapis.net":               "akab-xxx.luna.akamaiapis.net",
+		"https://akab-xxx.luna.akamaiapis.net/":      "akab-xxx.luna.akamaiapis.net",
+		"https://gateway.example.com/partner/akamai": "https://gateway.example.com/partner/akamai/",
+		"gateway.example.com:8443/beta/":             "https://gateway.example.com:8443/beta/",
+	}
+
+	for host, expected := range cases {
+		actual, err := normalizeHost(host)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", host, err)
+		}
+		if actual != expected {
+			t.Fatalf("%s: expected %s, got %s", host, expected, actual)
+		}
+	}
+
+	for _, host := range []string{"", "http://akab-xxx.luna.akamaiapis.net", "https://akab-xxx.luna.akamaiapis.net/?q=1"} {
+		if _, err := normalizeHost(host); err == nil {
+			t.Fatalf("%s: expected an error", host)
+		}
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 
 }
+
+// testAPIServer serves the JSON responses keyed by "<method> <path>", without
+// the query string, returning the credentials to call it with
+func testAPIServer(t *testing.T, responses map[string]string) (*httptest.Server, edgegrid.Config) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := responses[r.Method+" "+r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "POST" {
+			w.WriteHeader(http.StatusCreated)
+		}
+		w.Write([]byte(response))
+	}))
+
+	return server, edgegrid.Config{
+		Host:         strings.TrimPrefix(server.URL, "https://"),
+		ClientToken:  "test",
+		ClientSecret: "test",
+		AccessToken:  "test",
+		MaxBody:      131072,
+	}
+}
+
+// testPAPIServer serves the responses to the PAPI client until the returned
+// func is called
+func testPAPIServer(t *testing.T, responses map[string]string) func() {
+	server, config := testAPIServer(t, responses)
+
+	httpClient, papiConfig := client.Client, papi.Config
+	client.Client = server.Client()
+	papi.Init(config)
+
+	return func() {
+		server.Close()
+		client.Client, papi.Config = httpClient, papiConfig
+	}
+}
+
+// testEdgeDNSServer serves the responses to the Edge DNS client until the
+// returned func is called
+func testEdgeDNSServer(t *testing.T, responses map[string]string) func() {
+	server, config := testAPIServer(t, responses)
+
+	httpClient, dnsConfig := client.Client, dns.Config
+	client.Client = server.Client()
+	dns.Init(config)
+
+	return func() {
+		server.Close()
+		client.Client, dns.Config = httpClient, dnsConfig
+	}
+}
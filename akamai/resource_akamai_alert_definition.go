@@ -0,0 +1,146 @@
+package akamai
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// akamai_alert_definition creates an alert from an alert template for CP
+// codes. Who is notified is managed by akamai_alert_subscription, so the
+// emails of the definition are kept on update.
+func resourceAlertDefinition() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAlertDefinitionCreate,
+		Read:   resourceAlertDefinitionRead,
+		Update: resourceAlertDefinitionUpdate,
+		Delete: resourceAlertDefinitionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"template_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"cp_codes": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			// The parameters of the template, e.g. its thresholds
+			"parameters": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"definition_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAlertDefinitionCreate(d *schema.ResourceData, meta interface{}) error {
+	definition, err := saveAlertDefinition(expandAlertDefinition(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(definition.DefinitionID))
+	return resourceAlertDefinitionRead(d, meta)
+}
+
+func resourceAlertDefinitionRead(d *schema.ResourceData, meta interface{}) error {
+	definitionID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+
+	definition, err := getAlertDefinition(definitionID)
+	if err != nil {
+		return err
+	}
+	if definition == nil {
+		log.Printf("[WARN] Alert definition %d not found, removing from state\n", definitionID)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("definition_id", definition.DefinitionID)
+	d.Set("template_id", definition.TemplateID)
+	d.Set("name", definition.Name)
+	d.Set("cp_codes", definition.CPCodes)
+	d.Set("parameters", definition.Fields)
+	return nil
+}
+
+func resourceAlertDefinitionUpdate(d *schema.ResourceData, meta interface{}) error {
+	definitionID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+
+	alertSubscriptionLock.Lock()
+	defer alertSubscriptionLock.Unlock()
+
+	current, err := getAlertDefinition(definitionID)
+	if err != nil {
+		return err
+	}
+
+	definition := expandAlertDefinition(d)
+	definition.DefinitionID = definitionID
+	if current != nil {
+		definition.Emails = current.Emails
+	}
+	if _, err := saveAlertDefinition(definition); err != nil {
+		return err
+	}
+
+	return resourceAlertDefinitionRead(d, meta)
+}
+
+func resourceAlertDefinitionDelete(d *schema.ResourceData, meta interface{}) error {
+	definitionID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if err := deleteAlertDefinition(definitionID); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func expandAlertDefinition(d *schema.ResourceData) *alertDefinition {
+	definition := &alertDefinition{
+		TemplateID: d.Get("template_id").(string),
+		Name:       d.Get("name").(string),
+		CPCodes:    []int{},
+		Emails:     []string{},
+	}
+
+	for _, cpCode := range d.Get("cp_codes").(*schema.Set).List() {
+		definition.CPCodes = append(definition.CPCodes, cpCode.(int))
+	}
+
+	if parameters := d.Get("parameters").(map[string]interface{}); len(parameters) > 0 {
+		definition.Fields = map[string]string{}
+		for name, value := range parameters {
+			definition.Fields[name] = value.(string)
+		}
+	}
+
+	return definition
+}
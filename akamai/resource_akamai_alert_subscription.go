@@ -0,0 +1,79 @@
+package akamai
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// akamai_alert_subscription subscribes an email to the notifications of an
+// alert definition
+func resourceAlertSubscription() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAlertSubscriptionCreate,
+		Read:   resourceAlertSubscriptionRead,
+		Delete: resourceAlertSubscriptionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"definition_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"email": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAlertSubscriptionCreate(d *schema.ResourceData, meta interface{}) error {
+	definitionID := d.Get("definition_id").(int)
+	email := d.Get("email").(string)
+
+	if err := updateAlertSubscriptions(definitionID, email, true); err != nil {
+		return err
+	}
+
+	d.SetId(alertSubscriptionID(definitionID, email))
+	return resourceAlertSubscriptionRead(d, meta)
+}
+
+func resourceAlertSubscriptionRead(d *schema.ResourceData, meta interface{}) error {
+	definitionID, email, err := parseAlertSubscriptionID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	definition, err := getAlertDefinition(definitionID)
+	if err != nil {
+		return err
+	}
+	if definition == nil || !hasEmail(definition.Emails, email) {
+		log.Printf("[WARN] Subscription of %s to alert definition %d not found, removing from state\n", email, definitionID)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("definition_id", definitionID)
+	d.Set("email", email)
+	return nil
+}
+
+func resourceAlertSubscriptionDelete(d *schema.ResourceData, meta interface{}) error {
+	definitionID, email, err := parseAlertSubscriptionID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if err := updateAlertSubscriptions(definitionID, email, false); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
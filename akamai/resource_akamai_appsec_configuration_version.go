@@ -0,0 +1,142 @@
+package akamai
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// akamai_appsec_configuration_version creates an editable version of a security
+// configuration from a base version. The other AppSec resources change it.
+func resourceAppSecConfigurationVersion() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAppSecConfigurationVersionCreate,
+		Read:   resourceAppSecConfigurationVersionRead,
+		Update: resourceAppSecConfigurationVersionUpdate,
+		Delete: resourceAppSecConfigurationVersionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"config_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			// Defaults to the latest version
+			"create_from_version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"version_notes": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"staging_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"production_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAppSecConfigurationVersionCreate(d *schema.ResourceData, meta interface{}) error {
+	configID := d.Get("config_id").(int)
+
+	baseVersion, ok := d.GetOk("create_from_version")
+	if !ok {
+		configuration, err := getAppSecConfiguration(configID)
+		if err != nil {
+			return err
+		}
+		baseVersion = configuration.LatestVersion
+	}
+
+	version, err := createAppSecVersion(configID, baseVersion.(int))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(appsecID(configID, version.Version))
+	d.Set("create_from_version", baseVersion)
+	if notes, ok := d.GetOk("version_notes"); ok {
+		if err := updateAppSecVersionNotes(configID, version.Version, notes.(string)); err != nil {
+			return err
+		}
+	}
+
+	return resourceAppSecConfigurationVersionRead(d, meta)
+}
+
+func resourceAppSecConfigurationVersionRead(d *schema.ResourceData, meta interface{}) error {
+	configID, versionNumber, _, err := parseAppSecID(d.Id(), 0)
+	if err != nil {
+		return err
+	}
+
+	version, err := getAppSecVersion(configID, versionNumber)
+	if err != nil {
+		return err
+	}
+	if version == nil {
+		log.Printf("[WARN] Security configuration %d version %d not found, removing from state\n", configID, versionNumber)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("config_id", configID)
+	d.Set("version", version.Version)
+	if version.BasedOn != 0 {
+		d.Set("create_from_version", version.BasedOn)
+	}
+	d.Set("version_notes", version.VersionNotes)
+	d.Set("staging_status", version.Staging.Status)
+	d.Set("production_status", version.Production.Status)
+	return nil
+}
+
+func resourceAppSecConfigurationVersionUpdate(d *schema.ResourceData, meta interface{}) error {
+	configID, versionNumber, _, err := parseAppSecID(d.Id(), 0)
+	if err != nil {
+		return err
+	}
+
+	if err := updateAppSecVersionNotes(configID, versionNumber, d.Get("version_notes").(string)); err != nil {
+		return err
+	}
+
+	return resourceAppSecConfigurationVersionRead(d, meta)
+}
+
+// Versions that were activated are kept, only removed from the state
+func resourceAppSecConfigurationVersionDelete(d *schema.ResourceData, meta interface{}) error {
+	configID, versionNumber, _, err := parseAppSecID(d.Id(), 0)
+	if err != nil {
+		return err
+	}
+
+	version, err := getAppSecVersion(configID, versionNumber)
+	if err != nil {
+		return err
+	}
+	if version != nil && version.editable() {
+		if err := deleteAppSecVersion(configID, versionNumber); err != nil {
+			return err
+		}
+	} else if version != nil {
+		log.Printf("[INFO] Keeping security configuration %d version %d, which was activated\n", configID, versionNumber)
+	}
+
+	d.SetId("")
+	return nil
+}
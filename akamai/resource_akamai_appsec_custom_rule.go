@@ -0,0 +1,160 @@
+package akamai
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// akamai_appsec_custom_rule manages a custom rule of a security configuration
+// from its JSON, e.g. a virtual patch. Security policies apply it with
+// akamai_appsec_custom_rule_action.
+func resourceAppSecCustomRule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAppSecCustomRuleCreate,
+		Read:   resourceAppSecCustomRuleRead,
+		Update: resourceAppSecCustomRuleUpdate,
+		Delete: resourceAppSecCustomRuleDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"config_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			// The fields the API adds to the rule don't show as a diff
+			"custom_rule": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: func(v interface{}, k string) ([]string, []error) {
+					if _, err := parseAppSecCustomRule(v.(string)); err != nil {
+						return nil, []error{fmt.Errorf("%s: %s", k, err)}
+					}
+					return nil, nil
+				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					returned, err := parseAppSecCustomRule(old)
+					if err != nil {
+						return false
+					}
+					configured, err := parseAppSecCustomRule(new)
+					if err != nil {
+						return false
+					}
+					return appsecJSONSubset(configured, returned)
+				},
+			},
+			"custom_rule_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAppSecCustomRuleCreate(d *schema.ResourceData, meta interface{}) error {
+	configID := d.Get("config_id").(int)
+
+	rule, err := parseAppSecCustomRule(d.Get("custom_rule").(string))
+	if err != nil {
+		return err
+	}
+
+	ruleID, err := createAppSecCustomRule(configID, rule)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%d:%d", configID, ruleID))
+	return resourceAppSecCustomRuleRead(d, meta)
+}
+
+func resourceAppSecCustomRuleRead(d *schema.ResourceData, meta interface{}) error {
+	configID, ruleID, err := parseAppSecCustomRuleID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	rule, err := getAppSecCustomRule(configID, ruleID)
+	if err != nil {
+		return err
+	}
+	if rule == nil {
+		log.Printf("[WARN] Custom rule %d not found, removing from state\n", ruleID)
+		d.SetId("")
+		return nil
+	}
+
+	delete(rule, "id")
+	body, err := json.Marshal(rule)
+	if err != nil {
+		return err
+	}
+
+	d.Set("config_id", configID)
+	d.Set("custom_rule_id", ruleID)
+	d.Set("name", rule["name"])
+	d.Set("custom_rule", string(body))
+	return nil
+}
+
+func resourceAppSecCustomRuleUpdate(d *schema.ResourceData, meta interface{}) error {
+	configID, ruleID, err := parseAppSecCustomRuleID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	rule, err := parseAppSecCustomRule(d.Get("custom_rule").(string))
+	if err != nil {
+		return err
+	}
+
+	if err := updateAppSecCustomRule(configID, ruleID, rule); err != nil {
+		return err
+	}
+
+	return resourceAppSecCustomRuleRead(d, meta)
+}
+
+func resourceAppSecCustomRuleDelete(d *schema.ResourceData, meta interface{}) error {
+	configID, ruleID, err := parseAppSecCustomRuleID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if err := deleteAppSecCustomRule(configID, ruleID); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// parseAppSecCustomRuleID parses the ID of a custom rule, <config_id>:<rule_id>
+func parseAppSecCustomRuleID(id string) (int, int, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid ID %q, expected <config_id>:<custom_rule_id>", id)
+	}
+
+	configID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid config ID %q", parts[0])
+	}
+	ruleID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid custom rule ID %q", parts[1])
+	}
+
+	return configID, ruleID, nil
+}
@@ -0,0 +1,130 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// akamai_appsec_custom_rule_action sets what a security policy does with the
+// requests matching a custom rule.
+func resourceAppSecCustomRuleAction() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAppSecCustomRuleActionUpdate,
+		Read:   resourceAppSecCustomRuleActionRead,
+		Update: resourceAppSecCustomRuleActionUpdate,
+		Delete: resourceAppSecCustomRuleActionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"config_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"security_policy_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"custom_rule_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"action": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"alert", "deny", "none"}, false),
+			},
+		},
+	}
+}
+
+// The action always exists, so Create sets it like Update
+func resourceAppSecCustomRuleActionUpdate(d *schema.ResourceData, meta interface{}) error {
+	configID := d.Get("config_id").(int)
+	version := d.Get("version").(int)
+	policyID := d.Get("security_policy_id").(string)
+	ruleID := d.Get("custom_rule_id").(int)
+
+	if err := checkAppSecVersionEditable(configID, version); err != nil {
+		return err
+	}
+
+	if err := updateAppSecCustomRuleAction(configID, version, policyID, ruleID, d.Get("action").(string)); err != nil {
+		return err
+	}
+
+	d.SetId(appsecID(configID, version, policyID, strconv.Itoa(ruleID)))
+	return resourceAppSecCustomRuleActionRead(d, meta)
+}
+
+func resourceAppSecCustomRuleActionRead(d *schema.ResourceData, meta interface{}) error {
+	configID, version, policyID, ruleID, err := parseAppSecCustomRuleActionID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	action, err := getAppSecCustomRuleAction(configID, version, policyID, ruleID)
+	if err != nil {
+		return err
+	}
+	if action == "" {
+		log.Printf("[WARN] Custom rule %d of security policy %s not found, removing from state\n", ruleID, policyID)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("config_id", configID)
+	d.Set("version", version)
+	d.Set("security_policy_id", policyID)
+	d.Set("custom_rule_id", ruleID)
+	d.Set("action", action)
+	return nil
+}
+
+// Deleting the action sets it to none, unless the version was activated, in
+// which case it's only removed from the state
+func resourceAppSecCustomRuleActionDelete(d *schema.ResourceData, meta interface{}) error {
+	configID, version, policyID, ruleID, err := parseAppSecCustomRuleActionID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	editable, err := appsecVersionEditable(configID, version)
+	if err != nil {
+		return err
+	}
+	if editable {
+		if err := updateAppSecCustomRuleAction(configID, version, policyID, ruleID, "none"); err != nil && !isNotFound(err) {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func parseAppSecCustomRuleActionID(id string) (int, int, string, int, error) {
+	configID, version, names, err := parseAppSecID(id, 2)
+	if err != nil {
+		return 0, 0, "", 0, err
+	}
+
+	ruleID, err := strconv.Atoi(names[1])
+	if err != nil {
+		return 0, 0, "", 0, fmt.Errorf("invalid custom rule ID %q", names[1])
+	}
+
+	return configID, version, names[0], ruleID, nil
+}
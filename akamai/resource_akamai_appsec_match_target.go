@@ -0,0 +1,247 @@
+package akamai
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// akamai_appsec_match_target binds requests matching the hostnames, paths and
+// file extensions, or API endpoints, to a security policy. Targets of a type
+// are evaluated in sequence, the first matching one applies.
+func resourceAppSecMatchTarget() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAppSecMatchTargetCreate,
+		Read:   resourceAppSecMatchTargetRead,
+		Update: resourceAppSecMatchTargetUpdate,
+		Delete: resourceAppSecMatchTargetDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"config_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"website", "api"}, false),
+			},
+			"security_policy_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"hostnames": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"file_paths": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"file_extensions": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"is_negative_path_match": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"is_negative_file_extension_match": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"default_file": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "NO_MATCH",
+				ValidateFunc: validation.StringInSlice([]string{"NO_MATCH", "BASE_MATCH", "RECURSIVE_MATCH"}, false),
+			},
+			"bypass_network_lists": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// The API endpoints of an api target
+			"api_endpoint_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			// The position in the evaluation order, starting at 1. Defaults to last.
+			"sequence": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"target_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAppSecMatchTargetCreate(d *schema.ResourceData, meta interface{}) error {
+	configID := d.Get("config_id").(int)
+	version := d.Get("version").(int)
+
+	if err := checkAppSecVersionEditable(configID, version); err != nil {
+		return err
+	}
+
+	target, err := saveAppSecMatchTarget(configID, version, expandAppSecMatchTarget(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(appsecID(configID, version, strconv.Itoa(target.TargetID)))
+	if sequence, ok := d.GetOk("sequence"); ok && sequence.(int) != target.Sequence {
+		if err := updateAppSecMatchTargetSequence(configID, version, target.Type, target.TargetID, sequence.(int)); err != nil {
+			return err
+		}
+	}
+
+	return resourceAppSecMatchTargetRead(d, meta)
+}
+
+func resourceAppSecMatchTargetRead(d *schema.ResourceData, meta interface{}) error {
+	configID, version, targetID, err := parseAppSecMatchTargetID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	target, err := getAppSecMatchTarget(configID, version, targetID)
+	if err != nil {
+		return err
+	}
+	if target == nil {
+		log.Printf("[WARN] Match target %d not found, removing from state\n", targetID)
+		d.SetId("")
+		return nil
+	}
+
+	var bypassNetworkLists []string
+	for _, list := range target.BypassNetworkLists {
+		bypassNetworkLists = append(bypassNetworkLists, list.ID)
+	}
+	var apiEndpointIDs []int
+	for _, api := range target.APIs {
+		apiEndpointIDs = append(apiEndpointIDs, api.ID)
+	}
+
+	d.Set("config_id", configID)
+	d.Set("version", version)
+	d.Set("target_id", target.TargetID)
+	d.Set("type", target.Type)
+	d.Set("security_policy_id", target.SecurityPolicy.PolicyID)
+	d.Set("hostnames", target.Hostnames)
+	d.Set("file_paths", target.FilePaths)
+	d.Set("file_extensions", target.FileExtensions)
+	d.Set("is_negative_path_match", target.IsNegativePathMatch)
+	d.Set("is_negative_file_extension_match", target.IsNegativeFileExtensionMatch)
+	d.Set("default_file", target.DefaultFile)
+	d.Set("bypass_network_lists", bypassNetworkLists)
+	d.Set("api_endpoint_ids", apiEndpointIDs)
+	d.Set("sequence", target.Sequence)
+	return nil
+}
+
+func resourceAppSecMatchTargetUpdate(d *schema.ResourceData, meta interface{}) error {
+	configID, version, targetID, err := parseAppSecMatchTargetID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if err := checkAppSecVersionEditable(configID, version); err != nil {
+		return err
+	}
+
+	target := expandAppSecMatchTarget(d)
+	target.TargetID = targetID
+	if _, err := saveAppSecMatchTarget(configID, version, target); err != nil {
+		return err
+	}
+
+	if d.HasChange("sequence") {
+		if err := updateAppSecMatchTargetSequence(configID, version, target.Type, targetID, d.Get("sequence").(int)); err != nil {
+			return err
+		}
+	}
+
+	return resourceAppSecMatchTargetRead(d, meta)
+}
+
+// Targets in an activated version can't be deleted, so they're only removed
+// from the state
+func resourceAppSecMatchTargetDelete(d *schema.ResourceData, meta interface{}) error {
+	configID, version, targetID, err := parseAppSecMatchTargetID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	editable, err := appsecVersionEditable(configID, version)
+	if err != nil {
+		return err
+	}
+	if editable {
+		if err := deleteAppSecMatchTarget(configID, version, targetID); err != nil {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func parseAppSecMatchTargetID(id string) (int, int, int, error) {
+	configID, version, names, err := parseAppSecID(id, 1)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	targetID, err := strconv.Atoi(names[0])
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	return configID, version, targetID, nil
+}
+
+func expandAppSecMatchTarget(d *schema.ResourceData) *appsecMatchTarget {
+	target := &appsecMatchTarget{
+		Type:                         d.Get("type").(string),
+		Hostnames:                    setToStringSlice(d.Get("hostnames").(*schema.Set)),
+		FilePaths:                    setToStringSlice(d.Get("file_paths").(*schema.Set)),
+		FileExtensions:               setToStringSlice(d.Get("file_extensions").(*schema.Set)),
+		IsNegativePathMatch:          d.Get("is_negative_path_match").(bool),
+		IsNegativeFileExtensionMatch: d.Get("is_negative_file_extension_match").(bool),
+		DefaultFile:                  d.Get("default_file").(string),
+		SecurityPolicy:               appsecSecurityPolicyRef{PolicyID: d.Get("security_policy_id").(string)},
+	}
+
+	for _, id := range setToStringSlice(d.Get("bypass_network_lists").(*schema.Set)) {
+		target.BypassNetworkLists = append(target.BypassNetworkLists, appsecNetworkListRef{ID: id})
+	}
+	for _, id := range d.Get("api_endpoint_ids").(*schema.Set).List() {
+		target.APIs = append(target.APIs, appsecAPIEndpointRef{ID: id.(int)})
+	}
+
+	return target
+}
@@ -0,0 +1,317 @@
+package akamai
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// akamai_appsec_rate_policy defines the requests counted by a rate policy and
+// the thresholds of their rate per client. What happens to clients exceeding
+// them is set per security policy by akamai_appsec_rate_policy_action.
+func resourceAppSecRatePolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAppSecRatePolicyCreate,
+		Read:   resourceAppSecRatePolicyRead,
+		Update: resourceAppSecRatePolicyUpdate,
+		Delete: resourceAppSecRatePolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"config_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"match_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "path",
+				ValidateFunc: validation.StringInSlice([]string{"path", "regex"}, false),
+			},
+			"request_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "ClientRequest",
+				ValidateFunc: validation.StringInSlice([]string{"ClientRequest", "ClientResponse", "ForwardRequest", "ForwardResponse"}, false),
+			},
+			"path_match_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "Custom",
+				ValidateFunc: validation.StringInSlice([]string{"AllRequests", "TopLevel", "Custom"}, false),
+			},
+			// The paths of a Custom path_match_type
+			"paths": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"path_positive_match": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"file_extensions": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"file_extensions_positive_match": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"hostnames": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// Requests per second, averaged over 2 minutes
+			"average_threshold": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			// Requests per second, averaged over 5 seconds
+			"burst_threshold": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"client_identifier": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "ip",
+				ValidateFunc: validation.StringInSlice([]string{"ip", "api-key", "ip-useragent", "cookie:value"}, false),
+			},
+			"same_action_on_ipv6": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"use_x_forward_for_headers": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"match_condition": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						// e.g. IpAddressCondition or RequestMethodCondition
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"positive_match": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"values": &schema.Schema{
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"rate_policy_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAppSecRatePolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	configID := d.Get("config_id").(int)
+	version := d.Get("version").(int)
+
+	if err := checkAppSecVersionEditable(configID, version); err != nil {
+		return err
+	}
+
+	policy, err := saveAppSecRatePolicy(configID, version, expandAppSecRatePolicy(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(appsecID(configID, version, strconv.Itoa(policy.ID)))
+	return resourceAppSecRatePolicyRead(d, meta)
+}
+
+func resourceAppSecRatePolicyRead(d *schema.ResourceData, meta interface{}) error {
+	configID, version, ratePolicyID, err := parseAppSecRatePolicyID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	policy, err := getAppSecRatePolicy(configID, version, ratePolicyID)
+	if err != nil {
+		return err
+	}
+	if policy == nil {
+		log.Printf("[WARN] Rate policy %d not found, removing from state\n", ratePolicyID)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("config_id", configID)
+	d.Set("version", version)
+	d.Set("rate_policy_id", policy.ID)
+	d.Set("name", policy.Name)
+	d.Set("description", policy.Description)
+	d.Set("match_type", policy.MatchType)
+	d.Set("request_type", policy.RequestType)
+	d.Set("path_match_type", policy.PathMatchType)
+	d.Set("path_positive_match", policy.PathURIPositiveMatch)
+	if policy.Path != nil {
+		d.Set("paths", policy.Path.Values)
+	} else {
+		d.Set("paths", nil)
+	}
+	if policy.FileExtensions != nil {
+		d.Set("file_extensions", policy.FileExtensions.Values)
+		d.Set("file_extensions_positive_match", policy.FileExtensions.PositiveMatch)
+	} else {
+		d.Set("file_extensions", nil)
+	}
+	d.Set("hostnames", policy.Hostnames)
+	d.Set("average_threshold", policy.AverageThreshold)
+	d.Set("burst_threshold", policy.BurstThreshold)
+	d.Set("client_identifier", policy.ClientIdentifier)
+	d.Set("same_action_on_ipv6", policy.SameActionOnIPv6)
+	d.Set("use_x_forward_for_headers", policy.UseXForwardForHeaders)
+
+	var conditions []interface{}
+	for _, condition := range policy.AdditionalMatchOptions {
+		conditions = append(conditions, map[string]interface{}{
+			"type":           condition.Type,
+			"positive_match": condition.PositiveMatch,
+			"values":         condition.Values,
+		})
+	}
+	d.Set("match_condition", conditions)
+	return nil
+}
+
+func resourceAppSecRatePolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	configID, version, ratePolicyID, err := parseAppSecRatePolicyID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if err := checkAppSecVersionEditable(configID, version); err != nil {
+		return err
+	}
+
+	policy := expandAppSecRatePolicy(d)
+	policy.ID = ratePolicyID
+	if _, err := saveAppSecRatePolicy(configID, version, policy); err != nil {
+		return err
+	}
+
+	return resourceAppSecRatePolicyRead(d, meta)
+}
+
+// Rate policies in an activated version can't be deleted, so they're only
+// removed from the state
+func resourceAppSecRatePolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	configID, version, ratePolicyID, err := parseAppSecRatePolicyID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	editable, err := appsecVersionEditable(configID, version)
+	if err != nil {
+		return err
+	}
+	if editable {
+		if err := deleteAppSecRatePolicy(configID, version, ratePolicyID); err != nil {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func parseAppSecRatePolicyID(id string) (int, int, int, error) {
+	configID, version, names, err := parseAppSecID(id, 1)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	ratePolicyID, err := strconv.Atoi(names[0])
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	return configID, version, ratePolicyID, nil
+}
+
+func expandAppSecRatePolicy(d *schema.ResourceData) *appsecRatePolicy {
+	policy := &appsecRatePolicy{
+		Type:                  "WAF",
+		Name:                  d.Get("name").(string),
+		Description:           d.Get("description").(string),
+		MatchType:             d.Get("match_type").(string),
+		RequestType:           d.Get("request_type").(string),
+		PathMatchType:         d.Get("path_match_type").(string),
+		PathURIPositiveMatch:  d.Get("path_positive_match").(bool),
+		Hostnames:             setToStringSlice(d.Get("hostnames").(*schema.Set)),
+		AverageThreshold:      d.Get("average_threshold").(int),
+		BurstThreshold:        d.Get("burst_threshold").(int),
+		ClientIdentifier:      d.Get("client_identifier").(string),
+		SameActionOnIPv6:      d.Get("same_action_on_ipv6").(bool),
+		UseXForwardForHeaders: d.Get("use_x_forward_for_headers").(bool),
+	}
+
+	if paths := setToStringSlice(d.Get("paths").(*schema.Set)); len(paths) > 0 {
+		policy.Path = &appsecMatchValues{
+			PositiveMatch: policy.PathURIPositiveMatch,
+			Values:        paths,
+		}
+	}
+	if extensions := setToStringSlice(d.Get("file_extensions").(*schema.Set)); len(extensions) > 0 {
+		policy.FileExtensions = &appsecMatchValues{
+			PositiveMatch: d.Get("file_extensions_positive_match").(bool),
+			Values:        extensions,
+		}
+	}
+
+	for _, c := range d.Get("match_condition").([]interface{}) {
+		condition := c.(map[string]interface{})
+		var values []string
+		for _, value := range condition["values"].([]interface{}) {
+			values = append(values, value.(string))
+		}
+		policy.AdditionalMatchOptions = append(policy.AdditionalMatchOptions, &appsecMatchCondition{
+			Type:          condition["type"].(string),
+			PositiveMatch: condition["positive_match"].(bool),
+			Values:        values,
+		})
+	}
+
+	return policy
+}
@@ -0,0 +1,147 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+var appsecRatePolicyActions = []string{"alert", "deny", "none"}
+
+// akamai_appsec_rate_policy_action sets what a security policy does with the
+// clients exceeding the thresholds of a rate policy.
+func resourceAppSecRatePolicyAction() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAppSecRatePolicyActionUpdate,
+		Read:   resourceAppSecRatePolicyActionRead,
+		Update: resourceAppSecRatePolicyActionUpdate,
+		Delete: resourceAppSecRatePolicyActionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"config_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"security_policy_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"rate_policy_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"ipv4_action": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(appsecRatePolicyActions, false),
+			},
+			"ipv6_action": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(appsecRatePolicyActions, false),
+			},
+		},
+	}
+}
+
+// The action always exists, so Create sets it like Update
+func resourceAppSecRatePolicyActionUpdate(d *schema.ResourceData, meta interface{}) error {
+	configID := d.Get("config_id").(int)
+	version := d.Get("version").(int)
+	policyID := d.Get("security_policy_id").(string)
+	ratePolicyID := d.Get("rate_policy_id").(int)
+
+	if err := checkAppSecVersionEditable(configID, version); err != nil {
+		return err
+	}
+
+	if err := updateAppSecRatePolicyAction(configID, version, policyID, &appsecRatePolicyAction{
+		ID:         ratePolicyID,
+		IPv4Action: d.Get("ipv4_action").(string),
+		IPv6Action: d.Get("ipv6_action").(string),
+	}); err != nil {
+		return err
+	}
+
+	d.SetId(appsecID(configID, version, policyID, strconv.Itoa(ratePolicyID)))
+	return resourceAppSecRatePolicyActionRead(d, meta)
+}
+
+func resourceAppSecRatePolicyActionRead(d *schema.ResourceData, meta interface{}) error {
+	configID, version, policyID, ratePolicyID, err := parseAppSecRatePolicyActionID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	action, err := getAppSecRatePolicyAction(configID, version, policyID, ratePolicyID)
+	if err != nil {
+		return err
+	}
+	if action == nil {
+		log.Printf("[WARN] Rate policy %d of security policy %s not found, removing from state\n", ratePolicyID, policyID)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("config_id", configID)
+	d.Set("version", version)
+	d.Set("security_policy_id", policyID)
+	d.Set("rate_policy_id", ratePolicyID)
+	d.Set("ipv4_action", action.IPv4Action)
+	d.Set("ipv6_action", action.IPv6Action)
+	return nil
+}
+
+// Deleting the action sets it to none, unless the version was activated, in
+// which case it's only removed from the state
+func resourceAppSecRatePolicyActionDelete(d *schema.ResourceData, meta interface{}) error {
+	configID, version, policyID, ratePolicyID, err := parseAppSecRatePolicyActionID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	editable, err := appsecVersionEditable(configID, version)
+	if err != nil {
+		return err
+	}
+	if editable {
+		err := updateAppSecRatePolicyAction(configID, version, policyID, &appsecRatePolicyAction{
+			ID:         ratePolicyID,
+			IPv4Action: "none",
+			IPv6Action: "none",
+		})
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func parseAppSecRatePolicyActionID(id string) (int, int, string, int, error) {
+	configID, version, names, err := parseAppSecID(id, 2)
+	if err != nil {
+		return 0, 0, "", 0, err
+	}
+
+	ratePolicyID, err := strconv.Atoi(names[1])
+	if err != nil {
+		return 0, 0, "", 0, fmt.Errorf("invalid rate policy ID %q", names[1])
+	}
+
+	return configID, version, names[0], ratePolicyID, nil
+}
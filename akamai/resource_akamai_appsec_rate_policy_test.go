@@ -0,0 +1,57 @@
+package akamai
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestExpandAppSecRatePolicy(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceAppSecRatePolicy().Schema, map[string]interface{}{
+		"config_id":         12345,
+		"version":           3,
+		"name":              "Login",
+		"paths":             []interface{}{"/login"},
+		"average_threshold": 5,
+		"burst_threshold":   10,
+		"match_condition": []interface{}{map[string]interface{}{
+			"type":   "RequestMethodCondition",
+			"values": []interface{}{"POST"},
+		}},
+	})
+
+	expected := &appsecRatePolicy{
+		Type:                 "WAF",
+		Name:                 "Login",
+		MatchType:            "path",
+		RequestType:          "ClientRequest",
+		PathMatchType:        "Custom",
+		PathURIPositiveMatch: true,
+		Path:                 &appsecMatchValues{PositiveMatch: true, Values: []string{"/login"}},
+		AverageThreshold:     5,
+		BurstThreshold:       10,
+		ClientIdentifier:     "ip",
+		SameActionOnIPv6:     true,
+		AdditionalMatchOptions: []*appsecMatchCondition{
+			{Type: "RequestMethodCondition", PositiveMatch: true, Values: []string{"POST"}},
+		},
+	}
+	if policy := expandAppSecRatePolicy(d); !reflect.DeepEqual(policy, expected) {
+		t.Errorf("expected %+v, got %+v", expected, policy)
+	}
+}
+
+func TestParseAppSecRatePolicyActionID(t *testing.T) {
+	configID, version, policyID, ratePolicyID, err := parseAppSecRatePolicyActionID("12345:3:exam_12345:678")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if configID != 12345 || version != 3 || policyID != "exam_12345" || ratePolicyID != 678 {
+		t.Errorf("unexpected ID parts %d, %d, %s, %d", configID, version, policyID, ratePolicyID)
+	}
+
+	if _, _, _, _, err := parseAppSecRatePolicyActionID("12345:3:exam_12345:login"); err == nil {
+		t.Error("expected an error for an invalid rate policy ID")
+	}
+}
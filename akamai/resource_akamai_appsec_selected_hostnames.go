@@ -0,0 +1,113 @@
+package akamai
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// akamai_appsec_selected_hostnames sets the hostnames protected by an editable
+// version of a security configuration.
+func resourceAppSecSelectedHostnames() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAppSecSelectedHostnamesCreate,
+		Read:   resourceAppSecSelectedHostnamesRead,
+		Update: resourceAppSecSelectedHostnamesUpdate,
+		Delete: resourceAppSecSelectedHostnamesDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"config_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"hostnames": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceAppSecSelectedHostnamesCreate(d *schema.ResourceData, meta interface{}) error {
+	configID := d.Get("config_id").(int)
+	version := d.Get("version").(int)
+
+	if err := checkAppSecVersionEditable(configID, version); err != nil {
+		return err
+	}
+	if err := updateAppSecSelectedHostnames(configID, version, setToStringSlice(d.Get("hostnames").(*schema.Set))); err != nil {
+		return err
+	}
+
+	d.SetId(appsecID(configID, version))
+	return resourceAppSecSelectedHostnamesRead(d, meta)
+}
+
+func resourceAppSecSelectedHostnamesRead(d *schema.ResourceData, meta interface{}) error {
+	configID, version, _, err := parseAppSecID(d.Id(), 0)
+	if err != nil {
+		return err
+	}
+
+	hostnames, err := getAppSecSelectedHostnames(configID, version)
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] Security configuration %d version %d not found, removing from state\n", configID, version)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	d.Set("config_id", configID)
+	d.Set("version", version)
+	d.Set("hostnames", hostnames)
+	return nil
+}
+
+func resourceAppSecSelectedHostnamesUpdate(d *schema.ResourceData, meta interface{}) error {
+	configID, version, _, err := parseAppSecID(d.Id(), 0)
+	if err != nil {
+		return err
+	}
+
+	if err := checkAppSecVersionEditable(configID, version); err != nil {
+		return err
+	}
+	if err := updateAppSecSelectedHostnames(configID, version, setToStringSlice(d.Get("hostnames").(*schema.Set))); err != nil {
+		return err
+	}
+
+	return resourceAppSecSelectedHostnamesRead(d, meta)
+}
+
+// The hostnames of an activated version can't be removed, so they're only
+// removed from the state
+func resourceAppSecSelectedHostnamesDelete(d *schema.ResourceData, meta interface{}) error {
+	configID, version, _, err := parseAppSecID(d.Id(), 0)
+	if err != nil {
+		return err
+	}
+
+	editable, err := appsecVersionEditable(configID, version)
+	if err != nil {
+		return err
+	}
+	if editable {
+		if err := updateAppSecSelectedHostnames(configID, version, nil); err != nil {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
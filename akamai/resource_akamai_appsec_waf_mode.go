@@ -0,0 +1,152 @@
+package akamai
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// akamai_appsec_waf_mode sets the WAF mode of a security policy and optionally
+// upgrades its Kona rule set, which shows as a change of current_ruleset in the
+// plan.
+func resourceAppSecWAFMode() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceAppSecWAFModeUpdate,
+		Read:          resourceAppSecWAFModeRead,
+		Update:        resourceAppSecWAFModeUpdate,
+		Delete:        resourceAppSecWAFModeDelete,
+		CustomizeDiff: resourceAppSecWAFModeCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"config_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"security_policy_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"mode": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(appsecWAFModes, false),
+			},
+			// Upgrade to the latest Kona rule set, unless ASE_AUTO does
+			"upgrade_rules": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"current_ruleset": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"latest_ruleset": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// resourceAppSecWAFModeCustomizeDiff plans the rule upgrade, if any
+func resourceAppSecWAFModeCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	upgrade := appsecRuleUpgrade(d.Get("upgrade_rules").(bool), d.Get("mode").(string), d.Get("current_ruleset").(string), d.Get("latest_ruleset").(string))
+	if upgrade == "" {
+		return nil
+	}
+
+	return d.SetNew("current_ruleset", upgrade)
+}
+
+// appsecRuleUpgrade returns the rule set to upgrade to, or "" if none
+func appsecRuleUpgrade(upgradeRules bool, mode string, current string, latest string) string {
+	if !upgradeRules || mode == "ASE_AUTO" || latest == "" || latest == current {
+		return ""
+	}
+	return latest
+}
+
+// The mode always exists, so Create sets it like Update
+func resourceAppSecWAFModeUpdate(d *schema.ResourceData, meta interface{}) error {
+	configID := d.Get("config_id").(int)
+	version := d.Get("version").(int)
+	policyID := d.Get("security_policy_id").(string)
+	mode := d.Get("mode").(string)
+
+	if err := checkAppSecVersionEditable(configID, version); err != nil {
+		return err
+	}
+
+	if d.IsNewResource() || d.HasChange("mode") {
+		if err := updateAppSecWAFMode(configID, version, policyID, mode); err != nil {
+			return err
+		}
+	}
+
+	if d.Get("upgrade_rules").(bool) && mode != "ASE_AUTO" {
+		details, err := getAppSecRuleUpgradeDetails(configID, version, policyID)
+		if err != nil {
+			return err
+		}
+		if appsecRuleUpgrade(true, mode, details.Current, details.Latest) != "" {
+			if err := upgradeAppSecRules(configID, version, policyID); err != nil {
+				return err
+			}
+		}
+	}
+
+	d.SetId(appsecID(configID, version, policyID))
+	return resourceAppSecWAFModeRead(d, meta)
+}
+
+func resourceAppSecWAFModeRead(d *schema.ResourceData, meta interface{}) error {
+	configID, version, names, err := parseAppSecID(d.Id(), 1)
+	if err != nil {
+		return err
+	}
+	policyID := names[0]
+
+	mode, err := getAppSecWAFMode(configID, version, policyID)
+	if err != nil {
+		return err
+	}
+	if mode == nil {
+		log.Printf("[WARN] Security policy %s not found, removing from state\n", policyID)
+		d.SetId("")
+		return nil
+	}
+
+	details, err := getAppSecRuleUpgradeDetails(configID, version, policyID)
+	if err != nil {
+		return err
+	}
+
+	d.Set("config_id", configID)
+	d.Set("version", version)
+	d.Set("security_policy_id", policyID)
+	d.Set("mode", mode.Mode)
+	d.Set("current_ruleset", details.Current)
+	d.Set("latest_ruleset", details.Latest)
+	return nil
+}
+
+// The security policy always has a WAF mode, so it's only removed from the state
+func resourceAppSecWAFModeDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,195 @@
+package akamai
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// akamai_bulk_property_activation activates versions of many properties on a
+// network with one bulk activation job. A change submits a new job; destroying
+// it leaves the properties active.
+func resourceBulkPropertyActivation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBulkPropertyActivationCreate,
+		Read:   resourceBulkPropertyActivationRead,
+		Update: resourceBulkPropertyActivationCreate,
+		Delete: resourceBulkPropertyActivationDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(90 * time.Minute),
+			Update: schema.DefaultTimeout(90 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"contract_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"network": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "staging",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"staging", "production"}, false),
+			},
+			"property": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"property_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"version": &schema.Schema{
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						// Defaults to activation_note
+						"note": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"notify_emails":                  akamaiPropertySchema["notify_emails"],
+			"activation_note":                akamaiPropertySchema["activation_note"],
+			"auto_acknowledge_rule_warnings": akamaiPropertySchema["auto_acknowledge_rule_warnings"],
+			"bulk_activation_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			// The outcome for each property
+			"results": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"property_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"activation_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"error": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceBulkPropertyActivationCreate(d *schema.ResourceData, meta interface{}) error {
+	contractID := d.Get("contract_id").(string)
+	groupID := d.Get("group_id").(string)
+	network := strings.ToUpper(d.Get("network").(string))
+
+	settings := bulkActivationSettings{
+		NotifyEmails:           []string{},
+		AcknowledgeAllWarnings: d.Get("auto_acknowledge_rule_warnings").(bool),
+	}
+	for _, email := range d.Get("notify_emails").(*schema.Set).List() {
+		settings.NotifyEmails = append(settings.NotifyEmails, email.(string))
+	}
+
+	var items []*bulkActivationItem
+	for _, p := range d.Get("property").([]interface{}) {
+		p := p.(map[string]interface{})
+		note := p["note"].(string)
+		if note == "" {
+			note = d.Get("activation_note").(string)
+		}
+		items = append(items, &bulkActivationItem{
+			PropertyID:      p["property_id"].(string),
+			PropertyVersion: p["version"].(int),
+			Network:         network,
+			Note:            note,
+		})
+	}
+
+	bulkActivationID, err := submitBulkActivation(contractID, groupID, settings, items)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(bulkActivationID)
+	d.Set("bulk_activation_id", bulkActivationID)
+
+	timeout := d.Timeout(schema.TimeoutCreate)
+	if !d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutUpdate)
+	}
+
+	activation, err := waitForBulkActivation(contractID, groupID, bulkActivationID, timeout)
+	if activation != nil {
+		setBulkActivationResults(d, activation)
+	}
+	if err != nil {
+		return err
+	}
+
+	return bulkActivationErrors(activation)
+}
+
+func resourceBulkPropertyActivationRead(d *schema.ResourceData, meta interface{}) error {
+	activation, err := getBulkActivation(d.Get("contract_id").(string), d.Get("group_id").(string), d.Id())
+	if err != nil {
+		return err
+	}
+
+	setBulkActivationResults(d, activation)
+	return nil
+}
+
+// The activations are not reverted, as that would deactivate every property
+func resourceBulkPropertyActivationDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Leaving the properties of bulk activation %s active\n", d.Id())
+	d.SetId("")
+	return nil
+}
+
+func setBulkActivationResults(d *schema.ResourceData, activation *bulkActivation) {
+	var results []interface{}
+	for _, item := range activation.ActivatePropertyVersions {
+		status := item.ActivationStatus
+		if status == "" {
+			status = item.TaskStatus
+		}
+		results = append(results, map[string]interface{}{
+			"property_id":   item.PropertyID,
+			"version":       item.PropertyVersion,
+			"activation_id": item.PropertyActivationID,
+			"status":        status,
+			"error":         item.FatalError,
+		})
+	}
+
+	d.Set("status", activation.BulkActivationStatus)
+	d.Set("results", results)
+}
@@ -14,11 +14,12 @@ import (
 // https://developer.akamai.com/api/luna/papi/resources.html#cpcodesapi
 func resourceCPCode() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCPCodeCreate,
-		Read:   resourceCPCodeRead,
-		Update: resourceCPCodeUpdate,
-		Delete: resourceCPCodeDelete,
-		Exists: resourceCPCodeExists,
+		Create:        resourceCPCodeCreate,
+		Read:          resourceCPCodeRead,
+		Update:        resourceCPCodeUpdate,
+		Delete:        resourceCPCodeDelete,
+		Exists:        resourceCPCodeExists,
+		CustomizeDiff: resourceCPCodeCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:     schema.TypeString,
@@ -43,6 +44,10 @@ func resourceCPCode() *schema.Resource {
 	}
 }
 
+func resourceCPCodeCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return resourceCheckContractGroup(d)
+}
+
 func resourceCPCodeCreate(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[DEBUG] Creating CP Code")
 
@@ -0,0 +1,121 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// akamai_cps_change_acknowledgement acknowledges the staging deployment of an
+// enrollment with change management, which deploys the certificate to production.
+func resourceCPSChangeAcknowledgement() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCPSChangeAcknowledgementCreate,
+		Read:   resourceCPSChangeAcknowledgementRead,
+		Delete: resourceCPSChangeAcknowledgementDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(120 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"enrollment_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			// Only acknowledge this change, defaults to the pending change
+			"change_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"wait_for_production": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				ForceNew: true,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCPSChangeAcknowledgementCreate(d *schema.ResourceData, meta interface{}) error {
+	enrollmentID := d.Get("enrollment_id").(int)
+
+	change, err := getCPSPendingChange(enrollmentID)
+	if err != nil {
+		return err
+	}
+	if changeID, ok := d.GetOk("change_id"); ok && cpsChangeID(change) != changeID.(int) {
+		return fmt.Errorf("change %d is not pending for enrollment %d", changeID.(int), enrollmentID)
+	}
+
+	d.SetId(strconv.Itoa(enrollmentID))
+	if change == "" {
+		log.Printf("[INFO] Enrollment %d has no pending change to acknowledge\n", enrollmentID)
+		d.Set("status", "complete")
+		return nil
+	}
+	d.Set("change_id", cpsChangeID(change))
+
+	status, err := waitForCPSChangeManagement(enrollmentID, change, d.Get("wait_for_production").(bool), d.Timeout(schema.TimeoutCreate))
+	if status != nil {
+		d.Set("status", status.StatusInfo.Status)
+	}
+	return err
+}
+
+// waitForCPSChangeManagement waits for the change to be deployed to staging,
+// acknowledges it, and optionally waits for the production deployment
+func waitForCPSChangeManagement(enrollmentID int, change string, waitForProduction bool, timeout time.Duration) (*cpsChangeStatus, error) {
+	deadline := time.Now().Add(timeout)
+	acknowledged := false
+	for {
+		status, err := getCPSChangeStatus(change)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Printf("[DEBUG] Enrollment %d change %s is %s\n", enrollmentID, change, status.StatusInfo.Status)
+		if status.complete() {
+			return status, nil
+		}
+		if status.failed() {
+			return status, fmt.Errorf("deploying enrollment %d failed: %s", enrollmentID, status.StatusInfo.Description)
+		}
+
+		if input := status.input(cpsChangeManagementInput); input != nil && !acknowledged {
+			if err := acknowledgeCPSInput(input); err != nil {
+				return status, err
+			}
+			acknowledged = true
+			if !waitForProduction {
+				return status, nil
+			}
+		} else if input := cpsRequiredInput(status); input != nil && input.Type != cpsChangeManagementInput {
+			return status, fmt.Errorf("enrollment %d is waiting for %s, which must be provided before the deployment can be acknowledged: %s", enrollmentID, input.Type, status.StatusInfo.Description)
+		}
+
+		if time.Now().After(deadline) {
+			return status, fmt.Errorf("timeout after %s waiting for enrollment %d to be deployed (status: %s)", timeout, enrollmentID, status.StatusInfo.Status)
+		}
+		time.Sleep(cpsPollInterval)
+	}
+}
+
+// The acknowledgement is complete once created, so Read keeps the state as is
+func resourceCPSChangeAcknowledgementRead(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
+
+func resourceCPSChangeAcknowledgementDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,130 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// akamai_cps_dv_validation completes the domain validation of a DV enrollment.
+// Once the challenge records are in place, it tells CPS to check them and waits
+// for the certificate to be issued and deployed.
+func resourceCPSDVValidation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCPSDVValidationCreate,
+		Read:   resourceCPSDVValidationRead,
+		Delete: resourceCPSDVValidationDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(120 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"enrollment_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			// Changing the SANs validates the enrollment again
+			"sans": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"change_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCPSDVValidationCreate(d *schema.ResourceData, meta interface{}) error {
+	enrollmentID := d.Get("enrollment_id").(int)
+
+	change, err := getCPSPendingChange(enrollmentID)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(enrollmentID))
+	if change == "" {
+		log.Printf("[INFO] Enrollment %d has no pending change to validate\n", enrollmentID)
+		d.Set("status", "complete")
+		return nil
+	}
+	d.Set("change_id", cpsChangeID(change))
+
+	status, err := waitForCPSValidation(enrollmentID, change, d.Timeout(schema.TimeoutCreate))
+	if status != nil {
+		d.Set("status", status.StatusInfo.Status)
+	}
+	return err
+}
+
+// waitForCPSValidation acknowledges the DV challenges of the change once CPS is
+// waiting for them, and waits for the change to complete
+func waitForCPSValidation(enrollmentID int, change string, timeout time.Duration) (*cpsChangeStatus, error) {
+	deadline := time.Now().Add(timeout)
+	acknowledged := false
+	for {
+		status, err := getCPSChangeStatus(change)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Printf("[DEBUG] Enrollment %d change %s is %s\n", enrollmentID, change, status.StatusInfo.Status)
+		if status.complete() {
+			return status, nil
+		}
+		if status.failed() {
+			return status, fmt.Errorf("validating enrollment %d failed: %s", enrollmentID, status.StatusInfo.Description)
+		}
+
+		// With change management, the certificate stops on staging until the
+		// deployment is acknowledged, see akamai_cps_change_acknowledgement
+		if status.input(cpsChangeManagementInput) != nil {
+			return status, nil
+		}
+
+		if input := status.input("lets-encrypt-challenges"); input != nil && !acknowledged {
+			if err := acknowledgeCPSInput(input); err != nil {
+				return status, err
+			}
+			acknowledged = true
+		} else if input := cpsRequiredInput(status); input != nil && input.Type != "lets-encrypt-challenges" {
+			return status, fmt.Errorf("enrollment %d is waiting for %s, which must be provided outside Terraform: %s", enrollmentID, input.Type, status.StatusInfo.Description)
+		}
+
+		if time.Now().After(deadline) {
+			return status, fmt.Errorf("timeout after %s waiting for enrollment %d to be validated (status: %s)", timeout, enrollmentID, status.StatusInfo.Status)
+		}
+		time.Sleep(cpsPollInterval)
+	}
+}
+
+// cpsRequiredInput returns the input the change can't proceed without, if any
+func cpsRequiredInput(status *cpsChangeStatus) *cpsAllowedInput {
+	for _, input := range status.AllowedInput {
+		if input.RequiredToProceed {
+			return input
+		}
+	}
+	return nil
+}
+
+// The validation is complete once created, so Read keeps the state as is
+func resourceCPSDVValidationRead(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
+
+func resourceCPSDVValidationDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
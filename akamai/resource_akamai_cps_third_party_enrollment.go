@@ -0,0 +1,522 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// akamai_cps_third_party_enrollment manages a CPS enrollment for a certificate
+// signed by a third-party CA. CPS generates the CSRs, which are exported for the
+// CA to sign.
+func resourceCPSThirdPartyEnrollment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCPSThirdPartyEnrollmentCreate,
+		Read:   resourceCPSThirdPartyEnrollmentRead,
+		Update: resourceCPSThirdPartyEnrollmentUpdate,
+		Delete: resourceCPSThirdPartyEnrollmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceCPSThirdPartyEnrollmentImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"contract_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"common_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// The SANs besides the common name
+			"sans": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"secure_network": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "enhanced-tls",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"enhanced-tls", "standard-tls"}, false),
+			},
+			"sni_only": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				ForceNew: true,
+			},
+			"geography": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "core",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"core", "china+core", "russia+core"}, false),
+			},
+			"disallowed_tls_versions": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"TLSv1", "TLSv1_1", "TLSv1_2", "TLSv1_3"}, false),
+				},
+			},
+			"must_have_ciphers": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "ak-akamai-default",
+			},
+			"preferred_ciphers": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "ak-akamai-default",
+			},
+			"ocsp_stapling": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "not-set",
+				ValidateFunc: validation.StringInSlice([]string{"on", "off", "not-set"}, false),
+			},
+			"quic_enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// Serve the certificate for the SANs, otherwise only for dns_names
+			"clone_dns_names": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"dns_names": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"signature_algorithm": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "SHA-256",
+				ValidateFunc: validation.StringInSlice([]string{"SHA-1", "SHA-256"}, false),
+			},
+			// Deploy to staging only, until the change is acknowledged
+			"change_management": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// Generate an ECDSA CSR besides the RSA CSR
+			"dual_stack": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"csr": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"country_code": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"state": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"city": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"organization": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"organizational_unit": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"organization": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"phone": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"address_line_one": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"address_line_two": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"city": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"region": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"postal_code": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"country_code": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"admin_contact": cpsContactSchema(),
+			"tech_contact":  cpsContactSchema(),
+			"csr_rsa": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"csr_ecdsa": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"pending_change_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"change_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func cpsContactSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"first_name": &schema.Schema{
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"last_name": &schema.Schema{
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"email": &schema.Schema{
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"phone": &schema.Schema{
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"title": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"organization_name": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+			},
+		},
+	}
+}
+
+func resourceCPSThirdPartyEnrollmentCreate(d *schema.ResourceData, meta interface{}) error {
+	enrollmentID, err := createCPSEnrollment(expandCPSThirdPartyEnrollment(d), d.Get("contract_id").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(enrollmentID))
+	if err := waitForCPSEnrollmentCSRs(d, enrollmentID, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+
+	return resourceCPSThirdPartyEnrollmentRead(d, meta)
+}
+
+func resourceCPSThirdPartyEnrollmentUpdate(d *schema.ResourceData, meta interface{}) error {
+	enrollmentID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if err := updateCPSEnrollment(enrollmentID, expandCPSThirdPartyEnrollment(d)); err != nil {
+		return err
+	}
+
+	// A change to the certificate needs new CSRs to be signed
+	if d.HasChange("sans") || d.HasChange("csr") || d.HasChange("signature_algorithm") || d.HasChange("dual_stack") {
+		if err := waitForCPSEnrollmentCSRs(d, enrollmentID, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+
+	return resourceCPSThirdPartyEnrollmentRead(d, meta)
+}
+
+// waitForCPSEnrollmentCSRs waits for the CSRs of the enrollment's pending change
+func waitForCPSEnrollmentCSRs(d *schema.ResourceData, enrollmentID int, timeout time.Duration) error {
+	change, err := getCPSPendingChange(enrollmentID)
+	if err != nil || change == "" {
+		return err
+	}
+
+	csrs, err := waitForCPSThirdPartyCSRs(enrollmentID, change, timeout)
+	if err != nil {
+		return err
+	}
+
+	d.Set("csr_rsa", csrs["RSA"])
+	d.Set("csr_ecdsa", csrs["ECDSA"])
+	return nil
+}
+
+func resourceCPSThirdPartyEnrollmentRead(d *schema.ResourceData, meta interface{}) error {
+	enrollmentID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+
+	enrollment, err := getCPSEnrollment(enrollmentID)
+	if err != nil {
+		return err
+	}
+	if enrollment == nil {
+		log.Printf("[WARN] Enrollment %d not found, removing from state\n", enrollmentID)
+		d.SetId("")
+		return nil
+	}
+
+	if csr := enrollment.CSR; csr != nil {
+		d.Set("common_name", csr.CN)
+		d.Set("sans", cpsSANs(csr.CN, csr.SANs))
+		d.Set("csr", []interface{}{map[string]interface{}{
+			"country_code":        csr.C,
+			"state":               csr.ST,
+			"city":                csr.L,
+			"organization":        csr.O,
+			"organizational_unit": csr.OU,
+		}})
+	}
+	d.Set("signature_algorithm", enrollment.SignatureAlgorithm)
+	d.Set("change_management", enrollment.ChangeManagement)
+	d.Set("dual_stack", enrollment.EnableMultiStackedCertificates)
+	if network := enrollment.NetworkConfiguration; network != nil {
+		d.Set("secure_network", network.SecureNetwork)
+		d.Set("sni_only", network.SNIOnly)
+		d.Set("geography", network.Geography)
+		d.Set("disallowed_tls_versions", network.DisallowedTLSVersions)
+		d.Set("must_have_ciphers", network.MustHaveCiphers)
+		d.Set("preferred_ciphers", network.PreferredCiphers)
+		d.Set("ocsp_stapling", network.OCSPStapling)
+		d.Set("quic_enabled", network.QUICEnabled)
+		if settings := network.DNSNameSettings; settings != nil {
+			d.Set("clone_dns_names", settings.CloneDNSNames)
+			if settings.CloneDNSNames {
+				d.Set("dns_names", nil)
+			} else {
+				d.Set("dns_names", settings.DNSNames)
+			}
+		}
+	}
+	if org := enrollment.Org; org != nil {
+		d.Set("organization", []interface{}{map[string]interface{}{
+			"name":             org.Name,
+			"phone":            org.Phone,
+			"address_line_one": org.AddressLineOne,
+			"address_line_two": org.AddressLineTwo,
+			"city":             org.City,
+			"region":           org.Region,
+			"postal_code":      org.PostalCode,
+			"country_code":     org.Country,
+		}})
+	}
+	d.Set("admin_contact", flattenCPSContact(enrollment.AdminContact))
+	d.Set("tech_contact", flattenCPSContact(enrollment.TechContact))
+
+	change := enrollment.pendingChange()
+	if change == "" {
+		d.Set("pending_change_id", 0)
+		d.Set("change_status", "")
+		return nil
+	}
+
+	status, err := getCPSChangeStatus(change)
+	if err != nil {
+		return err
+	}
+	d.Set("pending_change_id", cpsChangeID(change))
+	d.Set("change_status", status.StatusInfo.Status)
+
+	// The CSRs are only available while the change waits for the signed
+	// certificates, so they are kept once signed
+	csrs, err := getCPSThirdPartyCSRs(status)
+	if err != nil {
+		return err
+	}
+	if csrs != nil {
+		d.Set("csr_rsa", csrs["RSA"])
+		d.Set("csr_ecdsa", csrs["ECDSA"])
+	}
+
+	return nil
+}
+
+func resourceCPSThirdPartyEnrollmentDelete(d *schema.ResourceData, meta interface{}) error {
+	enrollmentID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if err := deleteCPSEnrollment(enrollmentID); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceCPSThirdPartyEnrollmentImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid import ID %q, expected <contract_id>:<enrollment_id>", d.Id())
+	}
+	if _, err := strconv.Atoi(parts[1]); err != nil {
+		return nil, fmt.Errorf("invalid enrollment ID %q", parts[1])
+	}
+
+	d.Set("contract_id", parts[0])
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandCPSThirdPartyEnrollment(d *schema.ResourceData) *cpsEnrollment {
+	commonName := d.Get("common_name").(string)
+	csr := d.Get("csr.0").(map[string]interface{})
+	org := d.Get("organization.0").(map[string]interface{})
+
+	return &cpsEnrollment{
+		RA:                             "third-party",
+		ValidationType:                 "third-party",
+		CertificateType:                "third-party",
+		ChangeManagement:               d.Get("change_management").(bool),
+		EnableMultiStackedCertificates: d.Get("dual_stack").(bool),
+		SignatureAlgorithm:             d.Get("signature_algorithm").(string),
+		CSR: &cpsCSR{
+			CN:   commonName,
+			SANs: append([]string{commonName}, setToStringSlice(d.Get("sans").(*schema.Set))...),
+			C:    csr["country_code"].(string),
+			ST:   csr["state"].(string),
+			L:    csr["city"].(string),
+			O:    csr["organization"].(string),
+			OU:   csr["organizational_unit"].(string),
+		},
+		NetworkConfiguration: expandCPSNetworkConfiguration(d),
+		Org: &cpsOrganization{
+			Name:           org["name"].(string),
+			Phone:          org["phone"].(string),
+			AddressLineOne: org["address_line_one"].(string),
+			AddressLineTwo: org["address_line_two"].(string),
+			City:           org["city"].(string),
+			Region:         org["region"].(string),
+			PostalCode:     org["postal_code"].(string),
+			Country:        org["country_code"].(string),
+		},
+		AdminContact: expandCPSContact(d.Get("admin_contact.0").(map[string]interface{})),
+		TechContact:  expandCPSContact(d.Get("tech_contact.0").(map[string]interface{})),
+	}
+}
+
+func expandCPSNetworkConfiguration(d *schema.ResourceData) *cpsNetworkConfiguration {
+	settings := &cpsDNSNameSettings{CloneDNSNames: d.Get("clone_dns_names").(bool)}
+	if !settings.CloneDNSNames {
+		settings.DNSNames = setToStringSlice(d.Get("dns_names").(*schema.Set))
+	}
+
+	// CPS rejects a null list
+	disallowed := setToStringSlice(d.Get("disallowed_tls_versions").(*schema.Set))
+	if disallowed == nil {
+		disallowed = []string{}
+	}
+
+	return &cpsNetworkConfiguration{
+		Geography:             d.Get("geography").(string),
+		SecureNetwork:         d.Get("secure_network").(string),
+		SNIOnly:               d.Get("sni_only").(bool),
+		DisallowedTLSVersions: disallowed,
+		MustHaveCiphers:       d.Get("must_have_ciphers").(string),
+		PreferredCiphers:      d.Get("preferred_ciphers").(string),
+		OCSPStapling:          d.Get("ocsp_stapling").(string),
+		QUICEnabled:           d.Get("quic_enabled").(bool),
+		DNSNameSettings:       settings,
+	}
+}
+
+func expandCPSContact(contact map[string]interface{}) *cpsContact {
+	return &cpsContact{
+		FirstName:        contact["first_name"].(string),
+		LastName:         contact["last_name"].(string),
+		Email:            contact["email"].(string),
+		Phone:            contact["phone"].(string),
+		Title:            contact["title"].(string),
+		OrganizationName: contact["organization_name"].(string),
+	}
+}
+
+func flattenCPSContact(contact *cpsContact) []interface{} {
+	if contact == nil {
+		return nil
+	}
+
+	return []interface{}{map[string]interface{}{
+		"first_name":        contact.FirstName,
+		"last_name":         contact.LastName,
+		"email":             contact.Email,
+		"phone":             contact.Phone,
+		"title":             contact.Title,
+		"organization_name": contact.OrganizationName,
+	}}
+}
+
+// cpsSANs returns the SANs besides the common name, which CPS includes, sorted
+func cpsSANs(commonName string, sans []string) []string {
+	var others []string
+	for _, san := range sans {
+		if !strings.EqualFold(san, commonName) {
+			others = append(others, san)
+		}
+	}
+	sort.Strings(others)
+	return others
+}
@@ -0,0 +1,67 @@
+package akamai
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestCPSSANs(t *testing.T) {
+	sans := cpsSANs("www.example.com", []string{"www.example.com", "static.example.com", "api.example.com"})
+	if expected := []string{"api.example.com", "static.example.com"}; !reflect.DeepEqual(sans, expected) {
+		t.Errorf("expected %v, got %v", expected, sans)
+	}
+
+	if sans := cpsSANs("www.example.com", []string{"WWW.example.com"}); len(sans) != 0 {
+		t.Errorf("expected no SANs besides the common name, got %v", sans)
+	}
+}
+
+func TestCPSEnrollmentPendingChange(t *testing.T) {
+	enrollment := &cpsEnrollment{}
+	if change := enrollment.pendingChange(); change != "" {
+		t.Errorf("expected no pending change, got %q", change)
+	}
+
+	enrollment.PendingChanges = append(enrollment.PendingChanges,
+		cpsPendingChange{Location: "/cps/v2/enrollments/10002/changes/10003", ChangeType: "new-certificate"},
+		cpsPendingChange{Location: "/cps/v2/enrollments/10002/changes/10004", ChangeType: "renewal"},
+	)
+	if change := enrollment.pendingChange(); change != "/cps/v2/enrollments/10002/changes/10004" {
+		t.Errorf("expected the latest pending change, got %q", change)
+	}
+}
+
+func TestExpandCPSNetworkConfiguration(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceCPSThirdPartyEnrollment().Schema, map[string]interface{}{
+		"contract_id":     "ctr_C-1FRYVV3",
+		"common_name":     "www.example.com",
+		"ocsp_stapling":   "on",
+		"clone_dns_names": false,
+		"dns_names":       []interface{}{"www.example.com"},
+	})
+
+	network := expandCPSNetworkConfiguration(d)
+	if network.DisallowedTLSVersions == nil || len(network.DisallowedTLSVersions) != 0 {
+		t.Errorf("expected no disallowed TLS versions, got %#v", network.DisallowedTLSVersions)
+	}
+	if network.OCSPStapling != "on" || network.MustHaveCiphers != "ak-akamai-default" || !network.SNIOnly {
+		t.Errorf("unexpected network configuration %+v", network)
+	}
+	if settings := network.DNSNameSettings; settings.CloneDNSNames || !reflect.DeepEqual(settings.DNSNames, []string{"www.example.com"}) {
+		t.Errorf("unexpected DNS name settings %+v", settings)
+	}
+
+	d.Set("disallowed_tls_versions", []interface{}{"TLSv1", "TLSv1_1"})
+	d.Set("clone_dns_names", true)
+	network = expandCPSNetworkConfiguration(d)
+	sort.Strings(network.DisallowedTLSVersions)
+	if expected := []string{"TLSv1", "TLSv1_1"}; !reflect.DeepEqual(network.DisallowedTLSVersions, expected) {
+		t.Errorf("expected %v, got %v", expected, network.DisallowedTLSVersions)
+	}
+	if network.DNSNameSettings.DNSNames != nil {
+		t.Errorf("expected the SANs to be cloned, got %v", network.DNSNameSettings.DNSNames)
+	}
+}
@@ -0,0 +1,313 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// dnsRecordTypes are the record types akamai_dns_record supports. Records of the
+// standard types are configured as target strings, the extended types with typed blocks.
+var dnsRecordTypes = append(dnsStandardRecordTypes, dnsExtendedRecordTypes()...)
+
+var dnsStandardRecordTypes = []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS", "PTR", "SRV", "CAA"}
+
+// akamai_dns_record manages an Edge DNS record set: all records of one type with
+// the same name, sharing a TTL
+func resourceDNSRecord() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDNSRecordCreate,
+		Read:   resourceDNSRecordRead,
+		Update: resourceDNSRecordUpdate,
+		Delete: resourceDNSRecordDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDNSRecordImport,
+		},
+		CustomizeDiff: resourceDNSRecordCustomizeDiff,
+		Schema: dnsExtendedRecordSchema(map[string]*schema.Schema{
+			"zone": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// The fully qualified name, e.g. www.example.com
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"record_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(dnsRecordTypes, false),
+			},
+			"ttl": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(30),
+			},
+			// The rdata of each record of a standard type, in zone file format,
+			// e.g. "10 mail.example.com." for MX
+			"target": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		}),
+	}
+}
+
+// resourceDNSRecordCustomizeDiff checks the records are configured the way the record type requires
+func resourceDNSRecordCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("record_type") {
+		return nil
+	}
+
+	recordType := d.Get("record_type").(string)
+	block := "target"
+	if _, ok := dnsExtendedRecordFields[recordType]; ok {
+		block = dnsRecordBlock(recordType)
+	}
+
+	for _, key := range append([]string{"target"}, dnsExtendedBlocks()...) {
+		if !d.NewValueKnown(key) {
+			continue
+		}
+
+		count := len(d.Get(key).([]interface{}))
+		if key == block && count == 0 {
+			return fmt.Errorf("%s records require at least one %s", recordType, key)
+		}
+		if key != block && count > 0 {
+			return fmt.Errorf("%s cannot be used with %s records, use %s", key, recordType, block)
+		}
+	}
+
+	return nil
+}
+
+func resourceDNSRecordCreate(d *schema.ResourceData, meta interface{}) error {
+	dnsWriteLock.Lock()
+	defer dnsWriteLock.Unlock()
+
+	zone := d.Get("zone").(string)
+	recordSet := expandDNSRecordSet(d)
+	if !dnsNameInZone(recordSet.Name, zone) {
+		return fmt.Errorf("%s is not in zone %s", recordSet.Name, zone)
+	}
+
+	existing, err := getEdgeDNSRecordSet(zone, recordSet.Name, recordSet.Type)
+	if err != nil {
+		return err
+	}
+	// The apex NS record set is created with the zone, so it is adopted
+	if existing != nil && !isApexNS(zone, recordSet) {
+		return fmt.Errorf("%s record set %s already exists in zone %s, import it instead", recordSet.Type, recordSet.Name, zone)
+	}
+
+	if err := saveEdgeDNSRecordSet(zone, recordSet, existing != nil); err != nil {
+		return err
+	}
+
+	d.SetId(strings.Join([]string{zone, recordSet.Name, recordSet.Type}, "/"))
+	return resourceDNSRecordRead(d, meta)
+}
+
+func resourceDNSRecordUpdate(d *schema.ResourceData, meta interface{}) error {
+	dnsWriteLock.Lock()
+	defer dnsWriteLock.Unlock()
+
+	if err := saveEdgeDNSRecordSet(d.Get("zone").(string), expandDNSRecordSet(d), true); err != nil {
+		return err
+	}
+
+	return resourceDNSRecordRead(d, meta)
+}
+
+func resourceDNSRecordRead(d *schema.ResourceData, meta interface{}) error {
+	recordSet, err := getEdgeDNSRecordSet(d.Get("zone").(string), d.Get("name").(string), d.Get("record_type").(string))
+	if err != nil {
+		return err
+	}
+	if recordSet == nil {
+		log.Printf("[WARN] Record set %s not found, removing from state\n", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("ttl", recordSet.TTL)
+
+	if _, ok := dnsExtendedRecordFields[recordSet.Type]; ok {
+		current := expandDNSRecordSet(d).Rdata
+		if sameRdata(recordSet.Type, current, recordSet.Rdata) {
+			return nil
+		}
+
+		records := make([]interface{}, 0, len(recordSet.Rdata))
+		for _, rdata := range recordSet.Rdata {
+			values, err := parseDNSRdata(recordSet.Type, rdata)
+			if err != nil {
+				return err
+			}
+			records = append(records, values)
+		}
+		d.Set(dnsRecordBlock(recordSet.Type), records)
+		return nil
+	}
+
+	// Edge DNS returns the rdata in canonical form, e.g. with quoted TXT strings
+	// and fully qualified targets, which is kept in the configured form
+	var current []string
+	for _, target := range d.Get("target").([]interface{}) {
+		current = append(current, target.(string))
+	}
+	if !sameRdata(recordSet.Type, current, recordSet.Rdata) {
+		d.Set("target", recordSet.Rdata)
+	}
+
+	return nil
+}
+
+func resourceDNSRecordDelete(d *schema.ResourceData, meta interface{}) error {
+	dnsWriteLock.Lock()
+	defer dnsWriteLock.Unlock()
+
+	// A zone can't be served without its apex NS record set, so it is left in
+	// place, to be deleted with the zone
+	zone := d.Get("zone").(string)
+	recordSet := expandDNSRecordSet(d)
+	if isApexNS(zone, recordSet) {
+		log.Printf("[WARN] Not deleting the apex NS record set of zone %s, removing from state\n", zone)
+		d.SetId("")
+		return nil
+	}
+
+	if err := deleteEdgeDNSRecordSet(zone, recordSet.Name, recordSet.Type); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func isApexNS(zone string, recordSet *edgeDNSRecordSet) bool {
+	return recordSet.Type == "NS" && strings.EqualFold(strings.TrimSuffix(recordSet.Name, "."), strings.TrimSuffix(zone, "."))
+}
+
+func resourceDNSRecordImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	zone, name, recordType, err := parseDNSRecordID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	recordSet, err := getEdgeDNSRecordSet(zone, name, recordType)
+	if err != nil {
+		return nil, err
+	}
+	if recordSet == nil {
+		return nil, fmt.Errorf("%s record set %s not found in zone %s", recordType, name, zone)
+	}
+
+	d.Set("zone", zone)
+	d.Set("name", name)
+	d.Set("record_type", recordType)
+	d.SetId(strings.Join([]string{zone, name, recordType}, "/"))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// parseDNSRecordID parses a record set ID, <zone>/<name>/<type> or <zone>#<name>#<type>
+func parseDNSRecordID(id string) (string, string, string, error) {
+	separator := "/"
+	if strings.Contains(id, "#") {
+		separator = "#"
+	}
+
+	parts := strings.Split(id, separator)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("invalid import ID %q, expected <zone>#<name>#<type> or <zone>/<name>/<type>", id)
+	}
+
+	return parts[0], strings.TrimSuffix(parts[1], "."), strings.ToUpper(parts[2]), nil
+}
+
+func expandDNSRecordSet(d *schema.ResourceData) *edgeDNSRecordSet {
+	recordSet := &edgeDNSRecordSet{
+		Name:  strings.TrimSuffix(d.Get("name").(string), "."),
+		Type:  d.Get("record_type").(string),
+		TTL:   d.Get("ttl").(int),
+		Rdata: []string{},
+	}
+
+	if _, ok := dnsExtendedRecordFields[recordSet.Type]; ok {
+		for _, record := range d.Get(dnsRecordBlock(recordSet.Type)).([]interface{}) {
+			recordSet.Rdata = append(recordSet.Rdata, formatDNSRdata(recordSet.Type, record.(map[string]interface{})))
+		}
+		return recordSet
+	}
+
+	for _, target := range d.Get("target").([]interface{}) {
+		recordSet.Rdata = append(recordSet.Rdata, target.(string))
+	}
+
+	return recordSet
+}
+
+func dnsNameInZone(name string, zone string) bool {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+	return name == zone || strings.HasSuffix(name, "."+zone)
+}
+
+// sameRdata reports whether the rdata are the same records, in any order and
+// regardless of the differences normalizeRdata removes
+func sameRdata(recordType string, a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	normalize := func(rdata []string) []string {
+		normalized := make([]string, len(rdata))
+		for i, value := range rdata {
+			normalized[i] = normalizeRdata(recordType, value)
+		}
+		sort.Strings(normalized)
+		return normalized
+	}
+
+	na, nb := normalize(a), normalize(b)
+	for i := range na {
+		if na[i] != nb[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// normalizeRdata removes the differences between configured rdata and the form
+// Edge DNS returns: quotes around TXT strings, the trailing dot and case of names
+func normalizeRdata(recordType string, value string) string {
+	if _, ok := dnsExtendedRecordFields[recordType]; ok {
+		return normalizeDNSRdata(recordType, value)
+	}
+
+	value = strings.Join(strings.Fields(value), " ")
+	switch recordType {
+	case "TXT":
+		if len(value) > 1 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+			value = value[1 : len(value)-1]
+		}
+	case "CNAME", "NS", "PTR", "MX", "SRV":
+		value = strings.ToLower(strings.TrimSuffix(value, "."))
+	case "AAAA":
+		value = strings.ToLower(value)
+	}
+
+	return value
+}
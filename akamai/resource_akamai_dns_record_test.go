@@ -0,0 +1,71 @@
+package akamai
+
+import (
+	"testing"
+)
+
+func TestSameRdata(t *testing.T) {
+	tests := []struct {
+		recordType string
+		a          []string
+		b          []string
+		same       bool
+	}{
+		{"A", []string{"192.0.2.1", "192.0.2.2"}, []string{"192.0.2.2", "192.0.2.1"}, true},
+		{"A", []string{"192.0.2.1"}, []string{"192.0.2.1", "192.0.2.2"}, false},
+		{"TXT", []string{"v=spf1 -all"}, []string{`"v=spf1 -all"`}, true},
+		{"CNAME", []string{"www.example.com.edgesuite.net"}, []string{"WWW.example.com.edgesuite.net."}, true},
+		{"MX", []string{"10 mail.example.com"}, []string{"10  mail.example.com."}, true},
+		{"MX", []string{"10 mail.example.com"}, []string{"20 mail.example.com."}, false},
+		{"AAAA", []string{"2001:DB8::1"}, []string{"2001:db8::1"}, true},
+	}
+
+	for _, test := range tests {
+		if same := sameRdata(test.recordType, test.a, test.b); same != test.same {
+			t.Errorf("expected %v and %v (%s) same to be %t", test.a, test.b, test.recordType, test.same)
+		}
+	}
+}
+
+func TestDNSNameInZone(t *testing.T) {
+	if !dnsNameInZone("www.example.com", "example.com") {
+		t.Error("expected www.example.com to be in example.com")
+	}
+	if !dnsNameInZone("example.com.", "example.com") {
+		t.Error("expected the apex to be in the zone")
+	}
+	if dnsNameInZone("www.badexample.com", "example.com") {
+		t.Error("expected www.badexample.com not to be in example.com")
+	}
+}
+
+func TestIsApexNS(t *testing.T) {
+	if !isApexNS("example.com", &edgeDNSRecordSet{Name: "Example.com.", Type: "NS"}) {
+		t.Error("expected the apex NS record set")
+	}
+	if isApexNS("example.com", &edgeDNSRecordSet{Name: "sub.example.com", Type: "NS"}) {
+		t.Error("expected a delegation not to be the apex NS record set")
+	}
+	if isApexNS("example.com", &edgeDNSRecordSet{Name: "example.com", Type: "MX"}) {
+		t.Error("expected an MX record set not to be the apex NS record set")
+	}
+}
+
+func TestParseDNSRecordID(t *testing.T) {
+	for _, id := range []string{"example.com#www.example.com#A", "example.com/www.example.com./a"} {
+		zone, name, recordType, err := parseDNSRecordID(id)
+		if err != nil {
+			t.Errorf("unexpected error parsing %q: %s", id, err)
+			continue
+		}
+		if zone != "example.com" || name != "www.example.com" || recordType != "A" {
+			t.Errorf("expected example.com, www.example.com, A parsing %q, got %s, %s, %s", id, zone, name, recordType)
+		}
+	}
+
+	for _, id := range []string{"example.com#www.example.com", "example.com##A", "www.example.com"} {
+		if _, _, _, err := parseDNSRecordID(id); err == nil {
+			t.Errorf("expected an error parsing %q", id)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package akamai
+
+import (
+	"encoding/base64"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+var dnsTSIGAlgorithms = []string{
+	"hmac-md5.sig-alg.reg.int",
+	"hmac-sha1",
+	"hmac-sha224",
+	"hmac-sha256",
+	"hmac-sha384",
+	"hmac-sha512",
+}
+
+// akamai_dns_tsig_key manages the TSIG key used to authenticate zone transfers
+// of a zone. A zone has at most one key, so the resource ID is the zone.
+func resourceDNSTSIGKey() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDNSTSIGKeySave,
+		Read:   resourceDNSTSIGKeyRead,
+		Update: resourceDNSTSIGKeySave,
+		Delete: resourceDNSTSIGKeyDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDNSTSIGKeyImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"zone": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"algorithm": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(dnsTSIGAlgorithms, false),
+			},
+			// Base64 encoded
+			"secret": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validateBase64,
+			},
+		},
+	}
+}
+
+func resourceDNSTSIGKeySave(d *schema.ResourceData, meta interface{}) error {
+	zone := d.Get("zone").(string)
+	key := &edgeDNSTSIGKey{
+		Name:      d.Get("name").(string),
+		Algorithm: d.Get("algorithm").(string),
+		Secret:    d.Get("secret").(string),
+	}
+
+	if err := saveEdgeDNSTSIGKey(zone, key); err != nil {
+		return err
+	}
+
+	d.SetId(zone)
+	return resourceDNSTSIGKeyRead(d, meta)
+}
+
+func resourceDNSTSIGKeyRead(d *schema.ResourceData, meta interface{}) error {
+	key, err := getEdgeDNSTSIGKey(d.Id())
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		log.Printf("[WARN] TSIG key for zone %s not found, removing from state\n", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("zone", d.Id())
+	d.Set("name", key.Name)
+	d.Set("algorithm", key.Algorithm)
+	d.Set("secret", key.Secret)
+	return nil
+}
+
+func resourceDNSTSIGKeyDelete(d *schema.ResourceData, meta interface{}) error {
+	if err := deleteEdgeDNSTSIGKey(d.Id()); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceDNSTSIGKeyImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	key, err := getEdgeDNSTSIGKey(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, fmt.Errorf("zone %s has no TSIG key", d.Id())
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func validateBase64(v interface{}, k string) ([]string, []error) {
+	if _, err := base64.StdEncoding.DecodeString(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s must be base64 encoded: %s", k, err)}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,14 @@
+package akamai
+
+import (
+	"testing"
+)
+
+func TestValidateBase64(t *testing.T) {
+	if _, errs := validateBase64("PrUm0y6X2v7sSwKqvTqBEw==", "secret"); len(errs) > 0 {
+		t.Errorf("expected a valid secret, got %v", errs)
+	}
+	if _, errs := validateBase64("not base64!", "secret"); len(errs) == 0 {
+		t.Error("expected an invalid secret")
+	}
+}
@@ -0,0 +1,266 @@
+package akamai
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// akamai_dns_zone manages an Edge DNS zone. Primary zones and alias zones, which
+// serve the records of a primary zone, are supported.
+func resourceDNSZone() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDNSZoneCreate,
+		Read:   resourceDNSZoneRead,
+		Update: resourceDNSZoneUpdate,
+		Delete: resourceDNSZoneDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: resourceDNSZoneCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"zone": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "PRIMARY",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"PRIMARY", "ALIAS"}, false),
+			},
+			// Masters a new primary zone's records are transferred from, once.
+			// Changes after the zone is created are ignored.
+			"seed_masters": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return d.Id() != ""
+				},
+			},
+			// The primary zone an alias zone serves the records of
+			"target": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"contract_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// The group the zone is created in, defaults to the contract's only group.
+			// It is not read back, so changes after the zone is created are ignored.
+			"group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return d.Id() != ""
+				},
+			},
+			"comment": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// DNSSEC
+			"sign_and_serve": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"end_customer_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// Settings of the SOA record of a primary zone
+			"soa": dnsZoneSOASchema(),
+			// TTL of the apex NS record set of a primary zone
+			"ns_ttl": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			// Destroying the zone, including replacing it, fails unless disabled
+			"prevent_deletion": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"activation_state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"version_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// resourceDNSZoneCustomizeDiff checks the settings are valid for the zone type,
+// and the target of an alias zone is a primary zone in the same contract
+func resourceDNSZoneCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("type") || !d.NewValueKnown("target") {
+		return nil
+	}
+
+	zoneType := d.Get("type").(string)
+	target := d.Get("target").(string)
+	if zoneType != "PRIMARY" && len(d.Get("seed_masters").([]interface{})) > 0 {
+		return fmt.Errorf("seed_masters can only be set for PRIMARY zones")
+	}
+
+	if zoneType != "ALIAS" {
+		if target != "" {
+			return fmt.Errorf("target can only be set for ALIAS zones")
+		}
+		return nil
+	}
+
+	if target == "" {
+		return fmt.Errorf("target is required for ALIAS zones")
+	}
+	if d.Get("sign_and_serve").(bool) {
+		return fmt.Errorf("sign_and_serve cannot be set for ALIAS zones, it is inherited from the target")
+	}
+
+	if !d.HasChange("target") || !d.NewValueKnown("contract_id") {
+		return nil
+	}
+
+	targetZone, err := getEdgeDNSZone(target)
+	if err != nil {
+		return err
+	}
+
+	return checkAliasTarget(target, targetZone, d.Get("contract_id").(string))
+}
+
+func checkAliasTarget(target string, targetZone *edgeDNSZone, contractID string) error {
+	if targetZone == nil {
+		return fmt.Errorf("target zone %s not found", target)
+	}
+	if targetZone.Type != "PRIMARY" {
+		return fmt.Errorf("target zone %s is a %s zone, only PRIMARY zones can be aliased", target, targetZone.Type)
+	}
+	if targetZone.ContractID != "" && targetZone.ContractID != edgeDNSContractID(contractID) {
+		return fmt.Errorf("target zone %s is in contract %s, not %s", target, targetZone.ContractID, edgeDNSContractID(contractID))
+	}
+
+	return nil
+}
+
+func resourceDNSZoneCreate(d *schema.ResourceData, meta interface{}) error {
+	zone := expandDNSZone(d)
+
+	existing, err := getEdgeDNSZone(zone.Zone)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("zone %s already exists, import it instead", zone.Zone)
+	}
+
+	var masters []string
+	for _, master := range d.Get("seed_masters").([]interface{}) {
+		masters = append(masters, master.(string))
+	}
+
+	if len(masters) > 0 {
+		err = seedEdgeDNSZone(zone, masters, d.Get("contract_id").(string), d.Get("group_id").(string), d.Timeout(schema.TimeoutCreate))
+	} else {
+		err = createEdgeDNSZone(zone, d.Get("contract_id").(string), d.Get("group_id").(string))
+	}
+	if err != nil {
+		return err
+	}
+
+	d.SetId(zone.Zone)
+	if zone.Type == "PRIMARY" {
+		if err := saveDNSZoneApex(d); err != nil {
+			return err
+		}
+	}
+
+	return resourceDNSZoneRead(d, meta)
+}
+
+func resourceDNSZoneUpdate(d *schema.ResourceData, meta interface{}) error {
+	zone := expandDNSZone(d)
+	if d.HasChange("comment") || d.HasChange("sign_and_serve") || d.HasChange("end_customer_id") || d.HasChange("target") {
+		if err := updateEdgeDNSZone(zone); err != nil {
+			return err
+		}
+	}
+
+	if zone.Type == "PRIMARY" && (d.HasChange("soa") || d.HasChange("ns_ttl")) {
+		if err := saveDNSZoneApex(d); err != nil {
+			return err
+		}
+	}
+
+	return resourceDNSZoneRead(d, meta)
+}
+
+func resourceDNSZoneRead(d *schema.ResourceData, meta interface{}) error {
+	zone, err := getEdgeDNSZone(d.Id())
+	if err != nil {
+		return err
+	}
+	if zone == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("zone", zone.Zone)
+	d.Set("type", zone.Type)
+	d.Set("comment", zone.Comment)
+	d.Set("sign_and_serve", zone.SignAndServe)
+	d.Set("end_customer_id", zone.EndCustomerID)
+	d.Set("target", zone.Target)
+	d.Set("activation_state", zone.ActivationState)
+	d.Set("version_id", zone.VersionID)
+	// Keep the configured format, with or without the ctr_ prefix
+	if zone.ContractID != "" && edgeDNSContractID(d.Get("contract_id").(string)) != zone.ContractID {
+		d.Set("contract_id", zone.ContractID)
+	}
+
+	if zone.Type == "PRIMARY" {
+		return readDNSZoneApex(d, zone.Zone)
+	}
+
+	return nil
+}
+
+func resourceDNSZoneDelete(d *schema.ResourceData, meta interface{}) error {
+	if d.Get("prevent_deletion").(bool) {
+		return fmt.Errorf("zone %s has prevent_deletion set, set it to false and apply before destroying or replacing the zone", d.Id())
+	}
+
+	if err := deleteEdgeDNSZone(d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func expandDNSZone(d *schema.ResourceData) *edgeDNSZone {
+	return &edgeDNSZone{
+		Zone:          d.Get("zone").(string),
+		Type:          d.Get("type").(string),
+		Comment:       d.Get("comment").(string),
+		SignAndServe:  d.Get("sign_and_serve").(bool),
+		EndCustomerID: d.Get("end_customer_id").(string),
+		Target:        d.Get("target").(string),
+	}
+}
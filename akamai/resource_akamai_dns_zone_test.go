@@ -0,0 +1,56 @@
+package akamai
+
+import (
+	"testing"
+)
+
+func TestCheckAliasTarget(t *testing.T) {
+	tests := []struct {
+		targetZone *edgeDNSZone
+		valid      bool
+	}{
+		{&edgeDNSZone{Zone: "example.com", Type: "PRIMARY", ContractID: "1-ABCD"}, true},
+		{&edgeDNSZone{Zone: "example.com", Type: "PRIMARY"}, true},
+		{&edgeDNSZone{Zone: "example.com", Type: "PRIMARY", ContractID: "1-WXYZ"}, false},
+		{&edgeDNSZone{Zone: "example.com", Type: "ALIAS", ContractID: "1-ABCD"}, false},
+		{nil, false},
+	}
+
+	for _, test := range tests {
+		err := checkAliasTarget("example.com", test.targetZone, "ctr_1-ABCD")
+		if test.valid && err != nil {
+			t.Errorf("expected %v to be a valid target, got %s", test.targetZone, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("expected %v to be an invalid target", test.targetZone)
+		}
+	}
+}
+
+func TestResourceDNSZoneImport(t *testing.T) {
+	defer testEdgeDNSServer(t, map[string]string{
+		"GET /config-dns/v2/zones/example.com": `{"zone": "example.com", "type": "ALIAS", "target": "example.net", "contractId": "1-ABCD"}`,
+	})()
+
+	resource := resourceDNSZone()
+	d := resource.Data(nil)
+	d.SetId("example.com")
+
+	imported, err := resource.Importer.State(d, &Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := resourceDNSZoneRead(imported[0], &Config{}); err != nil {
+		t.Fatal(err)
+	}
+
+	d = imported[0]
+	if d.Get("zone").(string) != "example.com" || d.Get("contract_id").(string) != "1-ABCD" || d.Get("target").(string) != "example.net" {
+		t.Fatalf("unexpected state after import: %v", d.State())
+	}
+
+	// The group is not read back, so configuring it must not replace the zone
+	if !resource.Schema["group_id"].DiffSuppressFunc("group_id", "", "grp_12345", d) {
+		t.Fatal("expected the group_id of an imported zone to be ignored")
+	}
+}
@@ -0,0 +1,431 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// PAPI Edge Hostname
+//
+// Edge hostnames are shared by the properties of a contract and group, so they
+// have their own lifecycle rather than being created as a side effect of a
+// property. PAPI has no update or delete operations for them, so these use HAPI.
+//
+// https://developer.akamai.com/api/luna/papi/resources.html#edgehostnamesapi
+func resourceEdgeHostname() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceEdgeHostnameCreate,
+		Read:          resourceEdgeHostnameRead,
+		Update:        resourceEdgeHostnameUpdate,
+		Delete:        resourceEdgeHostnameDelete,
+		Exists:        resourceEdgeHostnameExists,
+		CustomizeDiff: resourceEdgeHostnameCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: resourceEdgeHostnameImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"contract_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"product_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"domain_prefix": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"domain_suffix": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "edgesuite.net",
+				ValidateFunc: validation.StringInSlice([]string{"edgesuite.net", "edgekey.net", "akamaized.net"}, false),
+			},
+			// Changed with HAPI
+			"ip_behavior": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "IPV4",
+				ValidateFunc: validation.StringInSlice([]string{"IPV4", "IPV6_COMPLIANCE", "IPV6_PERFORMANCE"}, false),
+			},
+			// Enhanced TLS (edgekey.net) edge hostnames are always secure
+			"secure": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			// The Enhanced TLS certificate slot and CPS enrollment, edgekey.net only
+			"slot_number": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"certificate_enrollment_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"certificate_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"use_cases": akpsEdgeHostnameUseCases,
+			// Set with HAPI, zero keeps the default
+			"ttl": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			// Recorded with changes made with HAPI
+			"comments": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			// Changed with HAPI
+			"china_cdn": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"custom_china_cdn_map": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"edge_hostname": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			// Adopted edge hostnames are only removed from the state on delete
+			"adopted": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceEdgeHostnameCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("domain_suffix").(string) != "edgekey.net" {
+		for _, key := range []string{"slot_number", "certificate_enrollment_id"} {
+			if v, ok := d.GetOk(key); ok && v.(int) != 0 {
+				return fmt.Errorf("%s can only be set on edgekey.net edge hostnames", key)
+			}
+		}
+	}
+
+	return resourceCheckContractGroup(d)
+}
+
+func resourceEdgeHostnameCreate(d *schema.ResourceData, meta interface{}) error {
+	edgeHostnames, err := resourceEdgeHostnamePAPINewEdgeHostnames(d)
+	if err != nil {
+		return err
+	}
+
+	domainPrefix := d.Get("domain_prefix").(string)
+	domainSuffix := d.Get("domain_suffix").(string)
+
+	// Adopt an existing edge hostname, as they are shared across properties
+	existing := papi.NewEdgeHostname(edgeHostnames)
+	existing.DomainPrefix = domainPrefix
+	existing.DomainSuffix = domainSuffix
+	edgeHostname, _ := edgeHostnames.FindEdgeHostname(existing)
+	adopted := edgeHostname != nil
+	if adopted {
+		log.Printf("[DEBUG] Edge hostname %s.%s exists, adopting %s\n", domainPrefix, domainSuffix, edgeHostname.EdgeHostnameID)
+
+		if enrollmentID := d.Get("certificate_enrollment_id").(int); enrollmentID != 0 {
+			certificate, err := getEdgeHostnameCertificate(domainPrefix, domainSuffix)
+			if err != nil {
+				return err
+			}
+			if certificate == nil || certificate.EnrollmentID() != enrollmentID {
+				return fmt.Errorf("edge hostname %s.%s exists and is not bound to certificate enrollment %d, which cannot be changed", domainPrefix, domainSuffix, enrollmentID)
+			}
+		}
+
+		if ttl := d.Get("ttl").(int); ttl > 0 {
+			if err := setEdgeHostnameTTL(domainPrefix, domainSuffix, ttl); err != nil {
+				return err
+			}
+		}
+	} else {
+		log.Printf("[DEBUG] Creating edge hostname %s.%s\n", domainPrefix, domainSuffix)
+
+		product := papi.NewProduct(papi.NewProducts())
+		product.ProductID = d.Get("product_id").(string)
+
+		options := edgeHostnameOptions{
+			Secure:            d.Get("secure").(bool),
+			IPVersionBehavior: d.Get("ip_behavior").(string),
+			SlotNumber:        d.Get("slot_number").(int),
+			CertEnrollmentID:  d.Get("certificate_enrollment_id").(int),
+			TTL:               d.Get("ttl").(int),
+			UseCases:          expandEdgeHostnameUseCases(d.Get("use_cases").([]interface{})),
+		}
+
+		edgeHostname, err = createEdgehostname(edgeHostnames, product, domainPrefix, domainSuffix, options, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return err
+		}
+	}
+
+	d.SetId(edgeHostname.EdgeHostnameID)
+	d.Set("adopted", adopted)
+
+	if patches := edgeHostnameChinaCDNPatches(d); len(patches) > 0 {
+		log.Printf("[DEBUG] Mapping edge hostname %s.%s to the China CDN: %+v\n", domainPrefix, domainSuffix, patches)
+		change, err := patchEdgeHostname(domainPrefix, domainSuffix, patches, d.Get("comments").(string))
+		if err != nil {
+			return err
+		}
+		if err := waitForEdgeHostnameChange(change, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
+
+	return resourceEdgeHostnameRead(d, meta)
+}
+
+// The TTL, IP behavior and China CDN mapping are changed with HAPI, along with the
+// comments. The changes are applied before reading them back.
+func resourceEdgeHostnameUpdate(d *schema.ResourceData, meta interface{}) error {
+	var patches []hapiPatch
+	if ttl := d.Get("ttl").(int); ttl > 0 && (d.HasChange("ttl") || d.HasChange("comments")) {
+		// Comments are only recorded with a change, so an unchanged TTL is submitted for them
+		patches = append(patches, hapiPatch{Op: "replace", Path: "/ttl", Value: strconv.Itoa(ttl)})
+	}
+	if d.HasChange("ip_behavior") {
+		patches = append(patches, hapiPatch{Op: "replace", Path: "/ipVersionBehavior", Value: d.Get("ip_behavior").(string)})
+	}
+	patches = append(patches, edgeHostnameChinaCDNPatches(d)...)
+
+	if len(patches) > 0 {
+		log.Printf("[DEBUG] Updating edge hostname %s: %+v\n", d.Get("edge_hostname").(string), patches)
+		change, err := patchEdgeHostname(d.Get("domain_prefix").(string), d.Get("domain_suffix").(string), patches, d.Get("comments").(string))
+		if err != nil {
+			return err
+		}
+		if err := waitForEdgeHostnameChange(change, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+
+	return resourceEdgeHostnameRead(d, meta)
+}
+
+// edgeHostnameChinaCDNPatches returns the changes to the China CDN mapping, if any
+func edgeHostnameChinaCDNPatches(d *schema.ResourceData) []hapiPatch {
+	var patches []hapiPatch
+	if d.HasChange("china_cdn") {
+		patches = append(patches, hapiPatch{Op: "replace", Path: "/chinaCdn/isChinaCdn", Value: strconv.FormatBool(d.Get("china_cdn").(bool))})
+	}
+	if d.HasChange("custom_china_cdn_map") {
+		patches = append(patches, hapiPatch{Op: "replace", Path: "/chinaCdn/customChinaCdnMap", Value: d.Get("custom_china_cdn_map").(string)})
+	}
+
+	return patches
+}
+
+func resourceEdgeHostnameRead(d *schema.ResourceData, meta interface{}) error {
+	edgeHostnames, err := resourceEdgeHostnamePAPINewEdgeHostnames(d)
+	if err != nil {
+		return err
+	}
+
+	edgeHostname := papi.NewEdgeHostname(edgeHostnames)
+	edgeHostname.EdgeHostnameID = d.Id()
+	if err := edgeHostname.GetEdgeHostname(""); err != nil {
+		return err
+	}
+
+	d.Set("product_id", edgeHostname.ProductID)
+	d.Set("domain_prefix", edgeHostname.DomainPrefix)
+	d.Set("domain_suffix", edgeHostname.DomainSuffix)
+	d.Set("ip_behavior", edgeHostname.IPVersionBehavior)
+	d.Set("secure", edgeHostname.Secure || edgeHostname.DomainSuffix == "edgekey.net")
+	d.Set("edge_hostname", edgeHostname.DomainPrefix+"."+edgeHostname.DomainSuffix)
+
+	hapiEdgeHostname, err := getHAPIEdgeHostname(edgeHostname.DomainPrefix, edgeHostname.DomainSuffix)
+	if err != nil {
+		return err
+	}
+	if hapiEdgeHostname != nil {
+		d.Set("ttl", hapiEdgeHostname.TTL)
+		d.Set("comments", hapiEdgeHostname.Comments)
+		d.Set("china_cdn", hapiEdgeHostname.ChinaCDN.IsChinaCDN)
+		d.Set("custom_china_cdn_map", hapiEdgeHostname.ChinaCDN.CustomChinaCDNMap)
+	}
+
+	useCases, err := getEdgeHostnameUseCases(edgeHostnames, edgeHostname.EdgeHostnameID)
+	if err != nil {
+		return err
+	}
+	d.Set("use_cases", flattenEdgeHostnameUseCases(useCases))
+
+	if edgeHostname.DomainSuffix == "edgekey.net" {
+		certificate, err := getEdgeHostnameCertificate(edgeHostname.DomainPrefix, edgeHostname.DomainSuffix)
+		if err != nil {
+			return err
+		}
+		if certificate == nil {
+			certificate = &hapiCertificate{}
+		}
+
+		d.Set("certificate_enrollment_id", certificate.EnrollmentID())
+		d.Set("slot_number", certificate.SlotNumber)
+		d.Set("certificate_status", certificate.Status)
+	}
+
+	log.Printf("[DEBUG] Read edge hostname: %+v", edgeHostname)
+	return nil
+}
+
+// Edge hostnames are deleted with HAPI, unless a property version still uses them
+// or they were adopted rather than created
+func resourceEdgeHostnameDelete(d *schema.ResourceData, meta interface{}) error {
+	edgeHostname := d.Get("edge_hostname").(string)
+
+	if d.Get("adopted").(bool) {
+		log.Printf("[DEBUG] Edge hostname %s was adopted, removing it from the state only\n", edgeHostname)
+		d.SetId("")
+		return nil
+	}
+
+	results, err := papi.Search(papi.SearchByEdgeHostname, edgeHostname)
+	if err != nil {
+		return err
+	}
+	if references := edgeHostnameReferences(results); len(references) > 0 {
+		return fmt.Errorf("edge hostname %s cannot be deleted, it is used by: %s", edgeHostname, strings.Join(references, ", "))
+	}
+
+	log.Printf("[DEBUG] Deleting edge hostname %s\n", edgeHostname)
+	if err := deleteEdgeHostname(d.Get("domain_prefix").(string), d.Get("domain_suffix").(string), d.Get("comments").(string)); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// edgeHostnameReferences describes the property versions of search results,
+// e.g. "example.com v3 (production ACTIVE)"
+func edgeHostnameReferences(results *papi.SearchResult) []string {
+	if results == nil {
+		return nil
+	}
+
+	var references []string
+	for _, version := range results.Versions.Items {
+		var statuses []string
+		if version.StagingStatus != "" && version.StagingStatus != "INACTIVE" {
+			statuses = append(statuses, "staging "+version.StagingStatus)
+		}
+		if version.ProductionStatus != "" && version.ProductionStatus != "INACTIVE" {
+			statuses = append(statuses, "production "+version.ProductionStatus)
+		}
+
+		reference := fmt.Sprintf("%s v%d", version.PropertyName, version.PropertyVersion)
+		if len(statuses) > 0 {
+			reference += " (" + strings.Join(statuses, ", ") + ")"
+		}
+		references = append(references, reference)
+	}
+
+	return references
+}
+
+func resourceEdgeHostnameExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	err := resourceEdgeHostnameRead(d, meta)
+	if apiErr, ok := err.(client.APIError); ok && apiErr.Status == 404 {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// resourceEdgeHostnameImport imports an edge hostname by "<contract_id>,<group_id>,<edge hostname>",
+// where the edge hostname is either its ID or its domain, e.g. ctr_1-ABC,grp_123,www.example.com.edgesuite.net
+func resourceEdgeHostnameImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ",")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid import ID %q, expected <contract_id>,<group_id>,<edge hostname ID or domain>", d.Id())
+	}
+
+	d.Set("contract_id", parts[0])
+	d.Set("group_id", parts[1])
+
+	edgeHostnameID := parts[2]
+	if !strings.HasPrefix(edgeHostnameID, "ehn_") {
+		edgeHostnames, err := resourceEdgeHostnamePAPINewEdgeHostnames(d)
+		if err != nil {
+			return nil, err
+		}
+
+		edgeHostnameID = ""
+		for _, edgeHostname := range edgeHostnames.EdgeHostnames.Items {
+			if edgeHostname.EdgeHostnameDomain == parts[2] {
+				edgeHostnameID = edgeHostname.EdgeHostnameID
+				break
+			}
+		}
+
+		if edgeHostnameID == "" {
+			return nil, fmt.Errorf("edge hostname %q does not exist in contract %s, group %s", parts[2], parts[0], parts[1])
+		}
+	}
+
+	d.SetId(edgeHostnameID)
+	if err := resourceEdgeHostnameRead(d, meta); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceEdgeHostnamePAPINewEdgeHostnames(d *schema.ResourceData) (*papi.EdgeHostnames, error) {
+	contract := &papi.Contract{
+		ContractID: d.Get("contract_id").(string),
+	}
+	group := &papi.Group{
+		GroupID: d.Get("group_id").(string),
+	}
+
+	edgeHostnames := papi.NewEdgeHostnames()
+	if err := edgeHostnames.GetEdgeHostnames(contract, group, ""); err != nil {
+		return nil, err
+	}
+	edgeHostnames.ContractID = contract.ContractID
+	edgeHostnames.GroupID = group.GroupID
+
+	return edgeHostnames, nil
+}
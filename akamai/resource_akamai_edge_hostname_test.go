@@ -0,0 +1,86 @@
+package akamai
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestEdgeHostnameReferences(t *testing.T) {
+	if references := edgeHostnameReferences(&papi.SearchResult{}); len(references) != 0 {
+		t.Fatalf("expected no references, got %v", references)
+	}
+
+	results := &papi.SearchResult{}
+	if err := json.Unmarshal([]byte(`{"versions": {"items": [
+		{"propertyName": "example.com", "propertyVersion": 3, "stagingStatus": "INACTIVE", "productionStatus": "ACTIVE"},
+		{"propertyName": "example.com", "propertyVersion": 4, "stagingStatus": "ACTIVE", "productionStatus": "INACTIVE"},
+		{"propertyName": "www.example.com", "propertyVersion": 1, "stagingStatus": "INACTIVE", "productionStatus": "INACTIVE"}
+	]}}`), results); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"example.com v3 (production ACTIVE)", "example.com v4 (staging ACTIVE)", "www.example.com v1"}
+	if references := edgeHostnameReferences(results); !reflect.DeepEqual(references, expected) {
+		t.Fatalf("expected %v, got %v", expected, references)
+	}
+}
+
+func TestResourceEdgeHostnameDeleteAdopted(t *testing.T) {
+	// Any request fails the test
+	defer testPAPIServer(t, map[string]string{})()
+
+	d := schema.TestResourceDataRaw(t, resourceEdgeHostname().Schema, map[string]interface{}{
+		"contract_id":   "ctr_1",
+		"group_id":      "grp_1",
+		"product_id":    "prd_SPM",
+		"domain_prefix": "www.example.com",
+	})
+	d.SetId("ehn_1")
+	d.Set("edge_hostname", "www.example.com.edgesuite.net")
+	d.Set("adopted", true)
+
+	if err := resourceEdgeHostnameDelete(d, &Config{}); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected the edge hostname to be removed from the state, got %q", d.Id())
+	}
+}
+
+func TestEdgeHostnameChinaCDNPatches(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceEdgeHostname().Schema, map[string]interface{}{
+		"contract_id":   "ctr_1",
+		"group_id":      "grp_1",
+		"product_id":    "prd_SPM",
+		"domain_prefix": "www.example.com",
+		"china_cdn":     true,
+	})
+
+	expected := []hapiPatch{{Op: "replace", Path: "/chinaCdn/isChinaCdn", Value: "true"}}
+	if patches := edgeHostnameChinaCDNPatches(d); !reflect.DeepEqual(patches, expected) {
+		t.Fatalf("expected %v, got %v", expected, patches)
+	}
+}
+
+func TestWaitForEdgeHostnameChange(t *testing.T) {
+	interval := edgeHostnameChangePollInterval
+	edgeHostnameChangePollInterval = 0
+	defer func() { edgeHostnameChangePollInterval = interval }()
+
+	defer testPAPIServer(t, map[string]string{
+		"GET /hapi/v1/change-requests/1": `{"changeId": 1, "status": "SUCCEEDED"}`,
+		"GET /hapi/v1/change-requests/2": `{"changeId": 2, "status": "FAILED", "statusMessage": "invalid map"}`,
+	})()
+
+	if err := waitForEdgeHostnameChange(&hapiChangeRequest{ChangeID: 1, Status: "PENDING"}, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := waitForEdgeHostnameChange(&hapiChangeRequest{ChangeID: 2, Status: "PENDING"}, time.Minute); err == nil {
+		t.Fatal("expected the failed change to be reported")
+	}
+}
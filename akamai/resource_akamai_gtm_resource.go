@@ -0,0 +1,247 @@
+package akamai
+
+import (
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+var gtmResourceTypes = []string{
+	"XML load object via HTTP",
+	"XML load object via HTTPS",
+	"Non-XML load object via HTTP",
+	"Non-XML load object via HTTPS",
+	"Download score",
+}
+
+// akamai_gtm_resource manages a GTM resource, which constrains the traffic
+// handed out to the datacenters of a property by the load they report
+func resourceGTMResource() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGTMResourceSave,
+		Read:   resourceGTMResourceRead,
+		Update: resourceGTMResourceSave,
+		Delete: resourceGTMResourceDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceGTMResourceImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"domain": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(gtmResourceTypes, false),
+			},
+			"aggregation_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"sum", "median", "latest"}, false),
+			},
+			// The property the load constrains, or ** for all properties
+			"constrained_property": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"host_header": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// The maximum load, for Download score resources
+			"upper_bound": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"load_imbalance_percentage": &schema.Schema{
+				Type:     schema.TypeFloat,
+				Optional: true,
+			},
+			"least_squares_decay": &schema.Schema{
+				Type:     schema.TypeFloat,
+				Optional: true,
+			},
+			"max_u_multiplicative_increment": &schema.Schema{
+				Type:     schema.TypeFloat,
+				Optional: true,
+			},
+			"decay_rate": &schema.Schema{
+				Type:     schema.TypeFloat,
+				Optional: true,
+			},
+			// Where the load of each datacenter is measured
+			"resource_instance": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datacenter_id": &schema.Schema{
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"use_default_load_object": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"load_object": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"load_object_port": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"load_servers": &schema.Schema{
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"wait_on_complete": gtmWaitOnComplete,
+		},
+	}
+}
+
+func resourceGTMResourceSave(d *schema.ResourceData, meta interface{}) error {
+	domain := d.Get("domain").(string)
+	resource := expandGTMResource(d)
+
+	status, err := saveGTMResource(domain, resource)
+	if err != nil {
+		return err
+	}
+	if status != nil {
+		log.Printf("[DEBUG] GTM resource %s saved, change %s is %s\n", resource.Name, status.ChangeID, status.PropagationStatus)
+	}
+
+	d.SetId(gtmID(domain, resource.Name))
+
+	timeout := d.Timeout(schema.TimeoutCreate)
+	if !d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutUpdate)
+	}
+	if err := waitForGTMPropagation(d, domain, timeout); err != nil {
+		return err
+	}
+
+	return resourceGTMResourceRead(d, meta)
+}
+
+func resourceGTMResourceRead(d *schema.ResourceData, meta interface{}) error {
+	domain, name, err := parseGTMID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resource, err := getGTMResource(domain, name)
+	if err != nil {
+		return err
+	}
+	if resource == nil {
+		log.Printf("[WARN] GTM resource %s not found, removing from state\n", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("domain", domain)
+	d.Set("name", resource.Name)
+	d.Set("type", resource.Type)
+	d.Set("aggregation_type", resource.AggregationType)
+	d.Set("constrained_property", resource.ConstrainedProperty)
+	d.Set("description", resource.Description)
+	d.Set("host_header", resource.HostHeader)
+	d.Set("upper_bound", resource.UpperBound)
+	d.Set("load_imbalance_percentage", resource.LoadImbalancePercentage)
+	d.Set("least_squares_decay", resource.LeastSquaresDecay)
+	d.Set("max_u_multiplicative_increment", resource.MaxUMultiplicativeIncrement)
+	d.Set("decay_rate", resource.DecayRate)
+
+	var instances []interface{}
+	for _, instance := range resource.ResourceInstances {
+		instances = append(instances, map[string]interface{}{
+			"datacenter_id":           instance.DatacenterID,
+			"use_default_load_object": instance.UseDefaultLoadObject,
+			"load_object":             instance.LoadObject,
+			"load_object_port":        instance.LoadObjectPort,
+			"load_servers":            instance.LoadServers,
+		})
+	}
+	return d.Set("resource_instance", instances)
+}
+
+func resourceGTMResourceDelete(d *schema.ResourceData, meta interface{}) error {
+	domain, name, err := parseGTMID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if _, err := deleteGTMResource(domain, name); err != nil {
+		return err
+	}
+
+	if err := waitForGTMPropagation(d, domain, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceGTMResourceImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, _, err := parseGTMID(d.Id()); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandGTMResource(d *schema.ResourceData) *gtmResource {
+	resource := &gtmResource{
+		Name:                        d.Get("name").(string),
+		Type:                        d.Get("type").(string),
+		AggregationType:             d.Get("aggregation_type").(string),
+		ConstrainedProperty:         d.Get("constrained_property").(string),
+		Description:                 d.Get("description").(string),
+		HostHeader:                  d.Get("host_header").(string),
+		UpperBound:                  d.Get("upper_bound").(int),
+		LoadImbalancePercentage:     d.Get("load_imbalance_percentage").(float64),
+		LeastSquaresDecay:           d.Get("least_squares_decay").(float64),
+		MaxUMultiplicativeIncrement: d.Get("max_u_multiplicative_increment").(float64),
+		DecayRate:                   d.Get("decay_rate").(float64),
+		ResourceInstances:           []*gtmResourceInstance{},
+	}
+
+	for _, v := range d.Get("resource_instance").([]interface{}) {
+		instance := v.(map[string]interface{})
+		resource.ResourceInstances = append(resource.ResourceInstances, &gtmResourceInstance{
+			DatacenterID:         instance["datacenter_id"].(int),
+			UseDefaultLoadObject: instance["use_default_load_object"].(bool),
+			LoadObject:           instance["load_object"].(string),
+			LoadObjectPort:       instance["load_object_port"].(int),
+			LoadServers:          setToStringSlice(instance["load_servers"].(*schema.Set)),
+		})
+	}
+
+	return resource
+}
@@ -5,12 +5,17 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 )
 
 func resourceProperty() *schema.Resource {
@@ -23,10 +28,57 @@ func resourceProperty() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourcePropertyImport,
 		},
+		CustomizeDiff: resourcePropertyCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(90 * time.Minute),
+			Update: schema.DefaultTimeout(90 * time.Minute),
+			Delete: schema.DefaultTimeout(90 * time.Minute),
+		},
 		Schema: akamaiPropertySchema,
 	}
 }
 
+func resourcePropertyCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if err := resourcePropertyLintRules(d); err != nil {
+		return err
+	}
+
+	if err := resourceCheckContractGroup(d); err != nil {
+		return err
+	}
+
+	if err := resourceCheckSensitiveOptions(d); err != nil {
+		return err
+	}
+
+	if err := resourcePropertyCheckHostnameBucket(d); err != nil {
+		return err
+	}
+
+	if err := resourceCheckRuleFormat(d); err != nil {
+		return err
+	}
+
+	if err := resourcePropertyCheckConflicts(d); err != nil {
+		return err
+	}
+
+	if err := resourcePropertyManagedVersion(d); err != nil {
+		return err
+	}
+
+	if err := resourceCheckIncludeReferences(d); err != nil {
+		return err
+	}
+
+	if err := resourcePropertyValidateRules(d); err != nil {
+		return err
+	}
+
+	resourcePropertyEstimateActivation(d)
+	return nil
+}
+
 func resourcePropertyCreate(d *schema.ResourceData, meta interface{}) error {
 	d.Partial(true)
 
@@ -44,6 +96,9 @@ func resourcePropertyCreate(d *schema.ResourceData, meta interface{}) error {
 	if e != nil {
 		return e
 	}
+	if cpCode != nil {
+		d.Set("cp_code_id", cpCode.CpcodeID)
+	}
 
 	product, e := getProduct(d, contract)
 	if e != nil {
@@ -65,8 +120,9 @@ func resourcePropertyCreate(d *schema.ResourceData, meta interface{}) error {
 			return errors.New("contract_id must be specified to create a new property")
 		}
 
-		if product == nil {
-			return errors.New("product_id must be specified to create a new property")
+		// A clone inherits the product of the property it is cloned from
+		if product == nil && cloneFrom == nil {
+			return errors.New("product_id must be specified to create a new property, unless clone_from is set")
 		}
 
 		property, e = createProperty(contract, group, product, cloneFrom, d)
@@ -75,7 +131,7 @@ func resourcePropertyCreate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	err := ensureEditableVersion(property)
+	err := ensureEditableVersion(property, d.Get("version_base").(string), d.Get("version").(int))
 	if err != nil {
 		return err
 	}
@@ -93,7 +149,8 @@ func resourcePropertyCreate(d *schema.ResourceData, meta interface{}) error {
 	d.SetPartial("network")
 	d.SetPartial("cp_code")
 
-	rules, e := property.GetRules()
+	ruleFormat := d.Get("rule_format").(string)
+	rules, e := getRulesInFormat(property, ruleFormat)
 	if e != nil {
 		return e
 	}
@@ -104,64 +161,65 @@ func resourcePropertyCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	updateStandardBehaviors(rules, cpCode, origin)
+	updateTokenAuthBehaviors(rules, d)
 	fixupPerformanceBehaviors(rules)
 
 	// get rules from the TF config
 	unmarshalRules(d, rules)
+	if e := substituteSensitiveOptions(d, rules); e != nil {
+		return e
+	}
 
-	e = rules.Save()
+	e = saveRules(rules, ruleFormat)
 	if e != nil {
-		if e == papi.ErrorMap[papi.ErrInvalidRules] && len(rules.Errors) > 0 {
-			var msg string
-			for _, v := range rules.Errors {
-				msg = msg + fmt.Sprintf("\n Rule validation error: %s %s %s %s %s", v.Type, v.Title, v.Detail, v.Instance, v.BehaviorName)
-			}
-			return errors.New("Error - Invalid Property Rules" + msg)
-		}
 		return e
 	}
+	d.Set("rules_etag", rules.Etag)
+	d.SetPartial("rules_etag")
 	d.SetPartial("default")
 	d.SetPartial("origin")
 	d.SetPartial("rule")
 
-	hostnameEdgeHostnameMap, err := createHostnames(property, product, d)
+	bucket, err := usesHostnameBucket(d, property)
 	if err != nil {
 		return err
 	}
+	d.SetPartial("use_hostname_bucket")
 
-	edgeHostnames, err := setEdgeHostnames(property, hostnameEdgeHostnameMap)
-	if err != nil {
-		return err
-	}
-	d.SetPartial("hostname")
-	d.SetPartial("ipv6")
-	_, edgeHostnameOk := d.GetOk("edge_hostname")
-	if edgeHostnameOk {
-		d.Set("edge_hostname", edgeHostnames)
+	if !bucket {
+		hostnameEdgeHostnameMap, err := createHostnames(property, product, d, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return err
+		}
+
+		edgeHostnames, err := setEdgeHostnames(property, hostnameEdgeHostnameMap)
+		if err != nil {
+			return err
+		}
+		d.SetPartial("hostname")
+		d.SetPartial("ipv6")
+		_, edgeHostnameOk := d.GetOk("edge_hostname")
+		if edgeHostnameOk {
+			d.Set("edge_hostname", edgeHostnames)
+		}
 	}
 
 	if d.Get("activate").(bool) {
-		activation, err := activateProperty(property, d)
+		activation, err := activateProperty(property, d, meta, d.Timeout(schema.TimeoutCreate))
 		if err != nil {
 			return err
 		}
 		d.SetPartial("contact")
-
-		go activation.PollStatus(property)
-
-	polling:
-		for activation.Status != papi.StatusActive {
-			select {
-			case statusChanged := <-activation.StatusChange:
-				log.Printf("[DEBUG] Property Status: %s\n", activation.Status)
-				if statusChanged == false {
-					break polling
-				}
-				continue polling
-			case <-time.After(time.Minute * 90):
-				log.Println("[DEBUG] Activation Timeout (90 minutes)")
-				break polling
+		d.SetPartial("staging_contact")
+		d.SetPartial("production_contact")
+		d.Set("activation_id", activation.ActivationID)
+		d.Set("activation_status", activation.Status)
+
+		if d.Get("wait_for_activation").(bool) {
+			if err := waitForActivation(property, activation, d.Timeout(schema.TimeoutCreate)); err != nil {
+				return err
 			}
+			d.Set("activation_status", activation.Status)
 		}
 	}
 
@@ -178,7 +236,9 @@ func createProperty(contract *papi.Contract, group *papi.Group, product *papi.Pr
 		return nil, err
 	}
 
-	property.ProductID = product.ProductID
+	if product != nil {
+		property.ProductID = product.ProductID
+	}
 	property.PropertyName = d.Get("name").(string)
 	if cloneFrom != nil {
 		property.CloneFrom = cloneFrom
@@ -194,7 +254,12 @@ func createProperty(contract *papi.Contract, group *papi.Group, product *papi.Pr
 		}
 	}
 
-	err = property.Save()
+	// not in the akamai_property_bootstrap schema
+	if useBucket, _ := d.Get("use_hostname_bucket").(bool); useBucket {
+		err = createHostnameBucketProperty(property)
+	} else {
+		err = property.Save()
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -219,6 +284,12 @@ func resourcePropertyDelete(d *schema.ResourceData, meta interface{}) error {
 	}
 	propertyID := d.Id()
 
+	if d.Get("retain_on_destroy").(bool) {
+		log.Printf("[WARN] retain_on_destroy is set, removing property %s from state without deactivating or deleting it", propertyID)
+		d.SetId("")
+		return nil
+	}
+
 	property := papi.NewProperty(papi.NewProperties())
 	property.PropertyID = propertyID
 	property.Contract = &papi.Contract{ContractID: contractID.(string)}
@@ -239,6 +310,22 @@ func resourcePropertyDelete(d *schema.ResourceData, meta interface{}) error {
 	// if there was no error, then activations were found, this can be an Activation or a Deactivation, so we check the ActivationType
 	// in case it has already been deactivated
 	if e == nil && activation.ActivationType == papi.ActivationTypeActivate {
+		// fail fast, rather than retaining a property prevent_deactivation_on protects
+		if e := checkDeactivationPrevented(d, activation.Network); e != nil {
+			return e
+		}
+
+		// an active property cannot be deleted, so it is retained instead
+		if !d.Get("deactivate_on_destroy").(bool) {
+			log.Printf("[WARN] deactivate_on_destroy is false and property %s is active on %s, removing it from state without deleting it", propertyID, activation.Network)
+			d.SetId("")
+			return nil
+		}
+		if !productionDeactivationAllowed(d, activation.Network) {
+			d.SetId("")
+			return nil
+		}
+
 		deactivation := papi.NewActivation(papi.NewActivations())
 		deactivation.PropertyVersion = property.LatestVersion
 		deactivation.ActivationType = papi.ActivationTypeDeactivate
@@ -250,21 +337,8 @@ func resourcePropertyDelete(d *schema.ResourceData, meta interface{}) error {
 		}
 		log.Printf("[DEBUG] DEACTIVATION SAVED - ID %s STATUS %s\n", deactivation.ActivationID, deactivation.Status)
 
-		go deactivation.PollStatus(property)
-
-	polling:
-		for deactivation.Status != papi.StatusActive {
-			select {
-			case statusChanged := <-deactivation.StatusChange:
-				log.Printf("[DEBUG] Property Status: %s\n", deactivation.Status)
-				if statusChanged == false {
-					break polling
-				}
-				continue polling
-			case <-time.After(time.Minute * 90):
-				log.Println("[DEBUG] Deactivation Timeout (90 minutes)")
-				break polling
-			}
+		if err := waitForActivation(property, deactivation, d.Timeout(schema.TimeoutDelete)); err != nil {
+			return err
 		}
 	}
 
@@ -280,8 +354,10 @@ func resourcePropertyDelete(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+// resourcePropertyImport imports a property by ID, name, hostname or edge hostname, optionally
+// suffixed with a version to adopt instead of the latest, e.g. prp_12345:3 or example.com@3
 func resourcePropertyImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceID := d.Id()
+	resourceID, version := parsePropertyImportID(d.Id())
 	propertyID := resourceID
 
 	if !strings.HasPrefix(resourceID, "prp_") {
@@ -313,9 +389,53 @@ func resourcePropertyImport(d *schema.ResourceData, meta interface{}) ([]*schema
 	d.Set("version", property.LatestVersion)
 	d.SetId(property.PropertyID)
 
+	if version > 0 {
+		versions, e := property.GetVersions()
+		if e != nil {
+			return nil, e
+		}
+
+		found := false
+		for _, v := range versions.Versions.Items {
+			if v.PropertyVersion == version {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("version %d of %s does not exist", version, property.PropertyID)
+		}
+
+		d.Set("version", version)
+	}
+
+	if _, e := usesHostnameBucket(d, property); e != nil {
+		return nil, e
+	}
+
+	if e := readPropertyState(d, property, true); e != nil {
+		return nil, e
+	}
+
 	return []*schema.ResourceData{d}, nil
 }
 
+// parsePropertyImportID splits a "<id>:<version>" or "<id>@<version>" import ID,
+// returning a zero version if none is given
+func parsePropertyImportID(id string) (string, int) {
+	separator := strings.LastIndexAny(id, ":@")
+	if separator == -1 {
+		return id, 0
+	}
+
+	version, err := strconv.Atoi(id[separator+1:])
+	if err != nil || version < 1 {
+		return id, 0
+	}
+
+	return id[:separator], version
+}
+
 func resourcePropertyExists(d *schema.ResourceData, meta interface{}) (bool, error) {
 	property := papi.NewProperty(papi.NewProperties())
 	property.PropertyID = d.Id()
@@ -347,7 +467,10 @@ func resourcePropertyRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("name", property.PropertyName)
 	d.Set("note", property.Note)
 	d.Set("rule_format", property.RuleFormat)
-	d.Set("version", property.LatestVersion)
+	// Keep the version Terraform last saved or imported
+	if _, ok := d.GetOk("version"); !ok {
+		d.Set("version", property.LatestVersion)
+	}
 	if property.StagingVersion > 0 {
 		d.Set("staging_version", property.StagingVersion)
 	}
@@ -355,7 +478,31 @@ func resourcePropertyRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set("production_version", property.ProductionVersion)
 	}
 
-	return nil
+	if _, err := usesHostnameBucket(d, property); err != nil {
+		return err
+	}
+
+	if err := readPropertyState(d, property, false); err != nil {
+		return err
+	}
+
+	// Report the progress of activations submitted with wait_for_activation = false
+	if activationID, ok := d.GetOk("activation_id"); ok {
+		activation := papi.NewActivation(papi.NewActivations())
+		activation.ActivationID = activationID.(string)
+		if _, err := activation.GetActivation(property); err != nil {
+			return err
+		}
+		d.Set("activation_status", activation.Status)
+
+		errs, err := activationErrors(property, activation)
+		if err != nil {
+			return err
+		}
+		d.Set("activation_errors", errs)
+	}
+
+	return setActivationStatuses(d, property)
 }
 
 var akpsOption = &schema.Schema{
@@ -440,12 +587,91 @@ var akamaiPropertySchema = map[string]*schema.Schema{
 		Optional: true,
 		Default:  true,
 	},
+	// When false the activation is only submitted, its status is reported by activation_status
+	"wait_for_activation": &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  true,
+	},
+	"activation_id": &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	},
+	"activation_status": &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	},
+	// The rule warnings acknowledged when submitting the last activation
+	"activation_warnings": &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	},
+	// The fatal error of the last activation if it failed
+	"activation_errors": &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	},
+	// The CNAME records validating Secure by Default certificates, see property_cert_challenges.go
+	"certificate_challenges": akpsCertificateChallenges,
+	// The status of the latest (de)activation on each network, whether or not Terraform submitted it
+	"staging_activation_status": &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	},
+	"production_activation_status": &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	},
 
-	// Will get added to the default rule
+	// Leave the property untouched in Akamai and only remove it from the state on destroy
+	"retain_on_destroy": &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	},
+	"deactivate_on_destroy": &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  true,
+	},
+	// Production is left active on destroy unless allowed
+	"allow_production_deactivation": &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	},
+	// Fail the destroy instead of deactivating the property on these networks
+	"prevent_deactivation_on": &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringInSlice([]string{"staging", "production"}, false),
+		},
+	},
+	"force_deactivation": &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	},
+
+	// Will get added to the default rule, by ID or name
 	"cp_code": &schema.Schema{
 		Type:     schema.TypeString,
 		Required: true,
 	},
+	// Create the CP code when cp_code is a name that does not exist
+	"create_cp_code": &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	},
+	"cp_code_id": &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	},
 	"name": &schema.Schema{
 		Type:     schema.TypeString,
 		Required: true,
@@ -463,29 +689,111 @@ var akamaiPropertySchema = map[string]*schema.Schema{
 		Type:     schema.TypeInt,
 		Computed: true,
 	},
+	// A frozen rule format such as v2018-02-27, or latest, see property_rule_format.go
 	"rule_format": &schema.Schema{
-		Type:     schema.TypeString,
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringMatch(ruleFormatRegexp, "must be latest or a frozen rule format such as v2018-02-27"),
+	},
+	"conflict_strategy": akpsConflictStrategy,
+	// Create exactly one new version, from the one in the state, for every change
+	"managed_version": &schema.Schema{
+		Type:     schema.TypeBool,
 		Optional: true,
+		Default:  false,
+	},
+	// The etag of the rules Terraform last saved, to detect changes made outside of Terraform
+	"rules_etag": &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	},
+	// The version new versions are created from: latest, staging, production or a version number
+	"version_base": &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "latest",
+		ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(latest|staging|production|[1-9][0-9]*)$`), "must be latest, staging, production or a version number"),
 	},
 	"ipv6": &schema.Schema{
 		Type:     schema.TypeBool,
 		Optional: true,
 	},
+	// Required unless use_hostname_bucket is enabled
 	"hostname": &schema.Schema{
 		Type:     schema.TypeSet,
-		Required: true,
+		Optional: true,
 		Elem:     &schema.Schema{Type: schema.TypeString},
 	},
+	"use_hostname_bucket": akpsUseHostnameBucket,
 	"contact": &schema.Schema{
 		Type:     schema.TypeSet,
-		Required: true,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	},
+	"staging_contact": &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
 		Elem:     &schema.Schema{Type: schema.TypeString},
 	},
+	"production_contact": &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	},
+	"notify_emails": &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	},
+	"activation_note": &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  "Using Terraform",
+	},
+	"compliance_record": akpsComplianceRecord,
+	"auto_acknowledge_rule_warnings": &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  true,
+	},
+	"acceptable_rule_warnings": &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	},
+	"on_pending_activation": akpsOnPendingActivation,
 	"edge_hostname": &schema.Schema{
 		Type:     schema.TypeMap,
 		Optional: true,
 		Elem:     &schema.Schema{Type: schema.TypeString},
 	},
+	// Used when an edge hostname has to be created
+	"domain_suffix": &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "edgesuite.net",
+		ValidateFunc: validation.StringInSlice([]string{"edgesuite.net", "edgekey.net", "akamaized.net"}, false),
+	},
+	"secure": &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	},
+	// The CPS enrollment an edgekey.net edge hostname created by the provider is bound to
+	"certificate_enrollment_id": &schema.Schema{
+		Type:     schema.TypeInt,
+		Optional: true,
+	},
+	"create_edge_hostname": &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  true,
+	},
+	"allow_default_edge_hostname": &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	},
 
 	"clone_from": &schema.Schema{
 		Type:     schema.TypeSet,
@@ -572,6 +880,16 @@ var akamaiPropertySchema = map[string]*schema.Schema{
 		},
 	},
 
+	"rules_lint": akpsRulesLint,
+	// Validate changed rules with a PAPI dry run during plan
+	"validate_rules_on_plan": akpsValidateRulesOnPlan,
+	// Secrets referenced from rule options as "sensitive:<name>"
+	"sensitive_option": akpsSensitiveOption,
+
+	// Will get added to the default rule
+	"token_auth": akpsTokenAuth,
+	"jwt_auth":   akpsJWTAuth,
+
 	// rules tree can go max 5 levels deep
 	"rules": &schema.Schema{
 		Type:     schema.TypeSet,
@@ -584,6 +902,7 @@ var akamaiPropertySchema = map[string]*schema.Schema{
 					Default:  "all",
 				},
 				"behavior": akpsBehavior,
+				"include":  akpsInclude,
 				"rule": &schema.Schema{
 					Type:     schema.TypeSet,
 					Optional: true,
@@ -604,6 +923,7 @@ var akamaiPropertySchema = map[string]*schema.Schema{
 							},
 							"criteria": akpsCriteria,
 							"behavior": akpsBehavior,
+							"include":  akpsInclude,
 							"rule": &schema.Schema{
 								Type:     schema.TypeSet,
 								Optional: true,
@@ -624,6 +944,7 @@ var akamaiPropertySchema = map[string]*schema.Schema{
 										},
 										"criteria": akpsCriteria,
 										"behavior": akpsBehavior,
+										"include":  akpsInclude,
 										"rule": &schema.Schema{
 											Type:     schema.TypeSet,
 											Optional: true,
@@ -644,6 +965,7 @@ var akamaiPropertySchema = map[string]*schema.Schema{
 													},
 													"criteria": akpsCriteria,
 													"behavior": akpsBehavior,
+													"include":  akpsInclude,
 													"rule": &schema.Schema{
 														Type:     schema.TypeSet,
 														Optional: true,
@@ -664,6 +986,7 @@ var akamaiPropertySchema = map[string]*schema.Schema{
 																},
 																"criteria": akpsCriteria,
 																"behavior": akpsBehavior,
+																"include":  akpsInclude,
 															},
 														},
 													},
@@ -711,6 +1034,10 @@ var akamaiPropertySchema = map[string]*schema.Schema{
 
 func resourcePropertyUpdate(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[DEBUG] UPDATING")
+	if onlyDestroySettingsChanged(changedKeys(d, akamaiPropertySchema)) {
+		log.Printf("[DEBUG] Only destroy settings of %s changed, leaving the property untouched", d.Id())
+		return nil
+	}
 	d.Partial(true)
 
 	property, e := getProperty(d)
@@ -718,9 +1045,33 @@ func resourcePropertyUpdate(d *schema.ResourceData, meta interface{}) error {
 		return e
 	}
 
-	err := ensureEditableVersion(property)
-	if err != nil {
-		return err
+	// version is unknown in the plan when a new version may be created, see
+	// resourcePropertyCheckConflicts and resourcePropertyManagedVersion
+	previousVersion, _ := d.GetChange("version")
+	managed := d.Get("managed_version").(bool) && previousVersion.(int) > 0
+
+	// Rule format upgrades are made on a new version, so the previous one can be reactivated.
+	// A managed version is replaced by exactly one new version created from it.
+	ruleFormat := d.Get("rule_format").(string)
+	if managed {
+		if err := newPropertyVersion(property, previousVersion.(int)); err != nil {
+			return err
+		}
+	} else if d.HasChange("rule_format") && ruleFormat != "" {
+		if err := newPropertyVersion(property, 0); err != nil {
+			return err
+		}
+	}
+
+	if !managed {
+		versionBase := d.Get("version_base").(string)
+		if d.Get("conflict_strategy").(string) == "new_version" && previousVersion.(int) > 0 {
+			versionBase = strconv.Itoa(previousVersion.(int))
+		}
+
+		if err := ensureEditableVersion(property, versionBase, previousVersion.(int)); err != nil {
+			return err
+		}
 	}
 	d.Set("version", property.LatestVersion)
 
@@ -730,22 +1081,18 @@ func resourcePropertyUpdate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	var cpCode *papi.CpCode
+	cpCode, e = getCPCode(d, property.Contract, property.Group)
+	if e != nil {
+		return e
+	}
+	if cpCode != nil {
+		d.Set("cp_code_id", cpCode.CpcodeID)
+	}
 	if d.HasChange("cp_code") {
-		cpCode, e = getCPCode(d, property.Contract, property.Group)
-		if e != nil {
-			return e
-		}
 		d.SetPartial("cp_code")
-	} else {
-		cpCode = papi.NewCpCode(papi.NewCpCodes(property.Contract, property.Group))
-		cpCode.CpcodeID = d.Get("cp_code").(string)
-		e := cpCode.GetCpCode()
-		if e != nil {
-			return e
-		}
 	}
 
-	rules, e := property.GetRules()
+	rules, e := getRulesInFormat(property, ruleFormat)
 	if e != nil {
 		return e
 	}
@@ -756,27 +1103,27 @@ func resourcePropertyUpdate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	updateStandardBehaviors(rules, cpCode, origin)
+	updateTokenAuthBehaviors(rules, d)
 
 	// get rules from the TF config
 	unmarshalRules(d, rules)
+	if e := substituteSensitiveOptions(d, rules); e != nil {
+		return e
+	}
 
-	e = rules.Save()
+	e = saveRules(rules, ruleFormat)
 	if e != nil {
-		if e == papi.ErrorMap[papi.ErrInvalidRules] && len(rules.Errors) > 0 {
-			var msg string
-			for _, v := range rules.Errors {
-				msg = msg + fmt.Sprintf("\n Rule validation error: %s %s %s %s %s", v.Type, v.Title, v.Detail, v.Instance, v.BehaviorName)
-			}
-			return errors.New("Error - Invalid Property Rules" + msg)
-		}
 		return e
 	}
+	d.Set("rules_etag", rules.Etag)
+	d.SetPartial("rules_etag")
 	d.SetPartial("default")
 	d.SetPartial("origin")
 	d.SetPartial("rule")
+	d.SetPartial("rule_format")
 
-	if d.HasChange("hostname") || d.HasChange("ipv6") {
-		hostnameEdgeHostnameMap, err := createHostnames(property, product, d)
+	if !d.Get("use_hostname_bucket").(bool) && (d.HasChange("hostname") || d.HasChange("ipv6")) {
+		hostnameEdgeHostnameMap, err := createHostnames(property, product, d, d.Timeout(schema.TimeoutUpdate))
 		if err != nil {
 			return err
 		}
@@ -793,27 +1140,21 @@ func resourcePropertyUpdate(d *schema.ResourceData, meta interface{}) error {
 	// an existing activation on this property will be automatically deactivated upon
 	// creation of this new activation
 	if d.Get("activate").(bool) {
-		activation, err := activateProperty(property, d)
+		activation, err := activateProperty(property, d, meta, d.Timeout(schema.TimeoutUpdate))
 		if err != nil {
 			return err
 		}
 		d.SetPartial("contact")
-
-		go activation.PollStatus(property)
-
-	polling:
-		for activation.Status != papi.StatusActive {
-			select {
-			case statusChanged := <-activation.StatusChange:
-				log.Printf("[DEBUG] Property Status: %s\n", activation.Status)
-				if statusChanged == false {
-					break polling
-				}
-				continue polling
-			case <-time.After(time.Minute * 90):
-				log.Println("[DEBUG] Activation Timeout (90 minutes)")
-				break polling
+		d.SetPartial("staging_contact")
+		d.SetPartial("production_contact")
+		d.Set("activation_id", activation.ActivationID)
+		d.Set("activation_status", activation.Status)
+
+		if d.Get("wait_for_activation").(bool) {
+			if err := waitForActivation(property, activation, d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return err
 			}
+			d.Set("activation_status", activation.Status)
 		}
 	}
 
@@ -847,7 +1188,7 @@ func getGroup(d *schema.ResourceData) (*papi.Group, error) {
 		return nil, e
 	}
 
-	group, e := groups.FindGroup(groupID.(string))
+	group, e := findGroup(groups, groupID.(string))
 	if e != nil {
 		return nil, e
 	}
@@ -856,6 +1197,70 @@ func getGroup(d *schema.ResourceData) (*papi.Group, error) {
 	return group, nil
 }
 
+func findGroup(groups *papi.Groups, groupID string) (*papi.Group, error) {
+	var ids []string
+	for _, group := range groups.Groups.Items {
+		if samePAPIID(group.GroupID, groupID, "grp_") {
+			return group, nil
+		}
+		ids = append(ids, group.GroupID)
+	}
+
+	return nil, fmt.Errorf("group %s not found, the credentials have access to: %s", groupID, strings.Join(ids, ", "))
+}
+
+// samePAPIID compares IDs with or without their prefix, as some contract types
+// are returned without the usual ctr_, grp_ or prd_ prefixes
+func samePAPIID(a string, b string, prefix string) bool {
+	return strings.TrimPrefix(a, prefix) == strings.TrimPrefix(b, prefix)
+}
+
+// checkContractGroup verifies that the group belongs to the contract, which PAPI
+// otherwise reports as a confusing error when creating properties or CP codes
+func checkContractGroup(contractID string, groupID string) error {
+	if contractID == "" || groupID == "" {
+		return nil
+	}
+
+	groups := papi.NewGroups()
+	if err := groups.GetGroups(); err != nil {
+		return err
+	}
+
+	group, err := findGroup(groups, groupID)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range group.ContractIDs {
+		if samePAPIID(id, contractID, "ctr_") {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("group %s (%s) does not belong to contract %s, it belongs to: %s", group.GroupID, group.GroupName, contractID, strings.Join(group.ContractIDs, ", "))
+}
+
+// resourcePropertyManagedVersion marks the version as changing when a managed version is replaced
+func resourcePropertyManagedVersion(d *schema.ResourceDiff) error {
+	if changed := d.GetChangedKeysPrefix(""); d.Id() == "" || !d.Get("managed_version").(bool) || len(changed) == 0 || onlyDestroySettingsChanged(changed) {
+		return nil
+	}
+
+	return d.SetNewComputed("version")
+}
+
+// resourceCheckContractGroup checks the contract and group of resources about to be created
+func resourceCheckContractGroup(d *schema.ResourceDiff) error {
+	if d.Id() != "" {
+		return nil
+	}
+
+	contractID, _ := d.Get("contract_id").(string)
+	groupID, _ := d.Get("group_id").(string)
+	return checkContractGroup(contractID, groupID)
+}
+
 func getContract(d *schema.ResourceData) (*papi.Contract, error) {
 	log.Println("[DEBUG] Fetching contract")
 	contractID, ok := d.GetOk("contract_id")
@@ -869,13 +1274,16 @@ func getContract(d *schema.ResourceData) (*papi.Contract, error) {
 		return nil, e
 	}
 
-	contract, e := contracts.FindContract(contractID.(string))
-	if e != nil {
-		return nil, e
+	var ids []string
+	for _, contract := range contracts.Contracts.Items {
+		if samePAPIID(contract.ContractID, contractID.(string), "ctr_") {
+			log.Printf("[DEBUG] Contract found: %s\n", contract.ContractID)
+			return contract, nil
+		}
+		ids = append(ids, contract.ContractID)
 	}
 
-	log.Printf("[DEBUG] Contract found: %s\n", contract.ContractID)
-	return contract, nil
+	return nil, fmt.Errorf("contract %s not found, the credentials have access to: %s", contractID, strings.Join(ids, ", "))
 }
 
 func getCPCode(d *schema.ResourceData, contract *papi.Contract, group *papi.Group) (*papi.CpCode, error) {
@@ -889,7 +1297,12 @@ func getCPCode(d *schema.ResourceData, contract *papi.Contract, group *papi.Grou
 	}
 
 	log.Println("[DEBUG] Fetching CP code")
-	cpCode := papi.NewCpCodes(contract, group).NewCpCode()
+	cpCodes := papi.NewCpCodes(contract, group)
+	if !isCPCodeID(cpCodeID.(string)) {
+		return getCPCodeByName(d, cpCodes, cpCodeID.(string))
+	}
+
+	cpCode := cpCodes.NewCpCode()
 	cpCode.CpcodeID = cpCodeID.(string)
 	err := cpCode.GetCpCode()
 	if err != nil {
@@ -900,6 +1313,53 @@ func getCPCode(d *schema.ResourceData, contract *papi.Contract, group *papi.Grou
 	return cpCode, nil
 }
 
+// getCPCodeByName returns the CP code with the given name, creating it when
+// create_cp_code is set
+func getCPCodeByName(d *schema.ResourceData, cpCodes *papi.CpCodes, name string) (*papi.CpCode, error) {
+	if err := cpCodes.GetCpCodes(); err != nil {
+		return nil, err
+	}
+
+	cpCode, err := findCPCode(cpCodes, name)
+	if err != nil {
+		return nil, err
+	}
+	if cpCode != nil {
+		log.Printf("[DEBUG] CP code found: %s (%s)\n", cpCode.CpcodeID, name)
+		return cpCode, nil
+	}
+
+	// not in the akamai_property_rules schema
+	if create, _ := d.Get("create_cp_code").(bool); !create {
+		return nil, fmt.Errorf("CP code \"%s\" not found in contract %s, group %s and create_cp_code is false", name, cpCodes.Contract.ContractID, cpCodes.Group.GroupID)
+	}
+
+	productID, _ := d.Get("product_id").(string)
+	if productID == "" {
+		return nil, fmt.Errorf("product_id must be specified to create CP code \"%s\"", name)
+	}
+
+	log.Printf("[DEBUG] Creating CP code %s\n", name)
+	cpCode = cpCodes.NewCpCode()
+	cpCode.ProductID = productID
+	cpCode.CpcodeName = name
+	if err := cpCode.Save(); err != nil {
+		return nil, err
+	}
+
+	log.Printf("[DEBUG] CP code created: %s\n", cpCode.CpcodeID)
+	return cpCode, nil
+}
+
+// isCPCodeID returns whether a cp_code is an ID, e.g. cpc_12345 or 12345, rather than a name
+func isCPCodeID(cpCode string) bool {
+	if _, err := strconv.Atoi(strings.TrimPrefix(cpCode, "cpc_")); err != nil {
+		return false
+	}
+
+	return true
+}
+
 func getProduct(d *schema.ResourceData, contract *papi.Contract) (*papi.Product, error) {
 	if contract == nil {
 		return nil, nil
@@ -914,16 +1374,34 @@ func getProduct(d *schema.ResourceData, contract *papi.Contract) (*papi.Product,
 	products := papi.NewProducts()
 	e := products.GetProducts(contract)
 	if e != nil {
+		// Some contract types, e.g. Global Consumer contracts, have no product catalog
+		if apiErr, ok := e.(client.APIError); ok && (apiErr.Status == 403 || apiErr.Status == 404) {
+			log.Printf("[WARN] The products of contract %s cannot be listed (%d), using product %s without checking it\n", contract.ContractID, apiErr.Status, productID)
+			return &papi.Product{ProductID: productID.(string)}, nil
+		}
 		return nil, e
 	}
 
-	product, e := products.FindProduct(productID.(string))
-	if e != nil {
-		return nil, e
+	return findProduct(products, contract.ContractID, productID.(string))
+}
+
+// findProduct looks up the product in the contract's catalog, using it as is when the catalog is empty
+func findProduct(products *papi.Products, contractID string, productID string) (*papi.Product, error) {
+	if len(products.Products.Items) == 0 {
+		log.Printf("[WARN] Contract %s has no product catalog, using product %s without checking it\n", contractID, productID)
+		return &papi.Product{ProductID: productID}, nil
 	}
 
-	log.Printf("[DEBUG] Product found: %s\n", product.ProductID)
-	return product, nil
+	var ids []string
+	for _, product := range products.Products.Items {
+		if samePAPIID(product.ProductID, productID, "prd_") {
+			log.Printf("[DEBUG] Product found: %s\n", product.ProductID)
+			return product, nil
+		}
+		ids = append(ids, product.ProductID)
+	}
+
+	return nil, fmt.Errorf("product %s is not available on contract %s, available products: %s", productID, contractID, strings.Join(ids, ", "))
 }
 
 func getCloneFrom(d *schema.ResourceData) (*papi.ClonePropertyFrom, error) {
@@ -1023,7 +1501,7 @@ func createOrigin(d *schema.ResourceData) (*papi.OptionValue, error) {
 			log.Println("[DEBUG] Setting custom forward hostname")
 
 			originValues["forwardHostHeader"] = "CUSTOM"
-			originValues["customForwardHostHeader"] = "CUSTOM"
+			originValues["customForwardHostHeader"] = forwardHostname
 		}
 
 		ov := papi.OptionValue(originValues)
@@ -1032,6 +1510,32 @@ func createOrigin(d *schema.ResourceData) (*papi.OptionValue, error) {
 	return nil, nil
 }
 
+// saveRules saves the rule tree in the rule format if one is given, reporting any validation errors
+func saveRules(rules *papi.Rules, format string) error {
+	var e error
+	if format == "" {
+		e = rules.Save()
+	} else {
+		e = rules.Freeze(format)
+	}
+	if e != nil {
+		if e == papi.ErrorMap[papi.ErrInvalidRules] && len(rules.Errors) > 0 {
+			return rulesValidationError(rules)
+		}
+		return e
+	}
+
+	return nil
+}
+
+func rulesValidationError(rules *papi.Rules) error {
+	var msg string
+	for _, v := range rules.Errors {
+		msg = msg + fmt.Sprintf("\n Rule validation error: %s %s %s %s %s", v.Type, v.Title, v.Detail, v.Instance, v.BehaviorName)
+	}
+	return errors.New("Error - Invalid Property Rules" + msg)
+}
+
 func fixupPerformanceBehaviors(rules *papi.Rules) {
 	behavior, err := rules.FindBehavior("/Performance/sureRoute")
 	if err != nil || behavior == nil || (behavior != nil && behavior.Options["testObjectUrl"] != "") {
@@ -1066,7 +1570,7 @@ func updateStandardBehaviors(rules *papi.Rules, cpCode *papi.CpCode, origin *pap
 	}
 }
 
-func createHostnames(property *papi.Property, product *papi.Product, d *schema.ResourceData) (map[string]*papi.EdgeHostname, error) {
+func createHostnames(property *papi.Property, product *papi.Product, d *schema.ResourceData, timeout time.Duration) (map[string]*papi.EdgeHostname, error) {
 	// If the property has edge hostnames and none is specified in the schema, then don't update them
 	edgeHostname, edgeHostnameOk := d.GetOk("edge_hostname")
 	if edgeHostnameOk == false {
@@ -1082,6 +1586,12 @@ func createHostnames(property *papi.Property, product *papi.Product, d *schema.R
 
 	hostnames := d.Get("hostname").(*schema.Set).List()
 	ipv6 := d.Get("ipv6").(bool)
+	domainSuffix := d.Get("domain_suffix").(string)
+	options := newEdgeHostnameOptions(d.Get("secure").(bool), ipv6)
+	options.CertEnrollmentID = d.Get("certificate_enrollment_id").(int)
+	createEdgeHostname := d.Get("create_edge_hostname").(bool)
+	// not in the akamai_property_hostnames schema, which requires edge_hostname
+	allowDefaultEdgeHostname, _ := d.Get("allow_default_edge_hostname").(bool)
 
 	log.Println("[DEBUG] Figuring out hostnames")
 	edgeHostnames := papi.NewEdgeHostnames()
@@ -1091,7 +1601,7 @@ func createHostnames(property *papi.Property, product *papi.Product, d *schema.R
 	}
 
 	hostnameEdgeHostnameMap := map[string]*papi.EdgeHostname{}
-	defaultEdgeHostname := edgeHostnames.EdgeHostnames.Items[0]
+	var defaultEdgeHostname *papi.EdgeHostname
 
 	if edgeHostnameOk {
 		foundEdgeHostname := false
@@ -1103,24 +1613,27 @@ func createHostnames(property *papi.Property, product *papi.Product, d *schema.R
 		}
 
 		if foundEdgeHostname == false {
+			if !createEdgeHostname {
+				return nil, fmt.Errorf("edge hostname \"%s\" does not exist in contract %s, group %s and create_edge_hostname is false", edgeHostname.(string), property.Contract.ContractID, property.Group.GroupID)
+			}
+
 			var err error
-			defaultEdgeHostname, err = createEdgehostname(edgeHostnames, product, edgeHostname.(string), ipv6)
+			defaultEdgeHostname, err = createEdgehostname(edgeHostnames, product, edgeHostname.(string), domainSuffix, options, timeout)
 			if err != nil {
 				return nil, err
 			}
 		}
 
 		for _, hostname := range hostnames {
-			if _, ok := hostnameEdgeHostnameMap[hostname.(string)]; !ok {
-				hostnameEdgeHostnameMap[hostname.(string)] = defaultEdgeHostname
-				return hostnameEdgeHostnameMap, nil
-			}
+			hostnameEdgeHostnameMap[hostname.(string)] = defaultEdgeHostname
 		}
+
+		return hostnameEdgeHostnameMap, nil
 	}
 
-	// Contract/Group has _some_ Edge Hostnames, try to map 1:1 (e.g. example.com -> example.com.edgesuite.net)
-	// If some mapping exists, map non-existent ones to the first 1:1 we find, otherwise if none exist map to the
-	// first Edge Hostname found in the contract/group
+	// Contract/Group has _some_ Edge Hostnames, try to map 1:1 (e.g. example.com -> example.com.edgesuite.net, using domain_suffix)
+	// When allow_default_edge_hostname is set, map non-existent ones to the first 1:1 we find, otherwise if none
+	// exist map to the first Edge Hostname found in the contract/group
 	if len(edgeHostnames.EdgeHostnames.Items) > 0 {
 		log.Println("[DEBUG] Hostnames retrieved, trying to map")
 		edgeHostnamesMap := map[string]*papi.EdgeHostname{}
@@ -1130,29 +1643,29 @@ func createHostnames(property *papi.Property, product *papi.Product, d *schema.R
 		}
 
 		// Search for existing hostname, map 1:1
-		var overrideDefault bool
+		var unmapped []string
 		for _, hostname := range hostnames {
-			if edgeHostname, ok := edgeHostnamesMap[hostname.(string)+".edgesuite.net"]; ok {
+			if edgeHostname, ok := edgeHostnamesMap[hostname.(string)+"."+domainSuffix]; ok {
 				hostnameEdgeHostnameMap[hostname.(string)] = edgeHostname
-				// Override the default with the first one found
-				if !overrideDefault {
+				// Use the first one found as the default
+				if defaultEdgeHostname == nil {
 					defaultEdgeHostname = edgeHostname
-					overrideDefault = true
 				}
 				continue
 			}
+			unmapped = append(unmapped, hostname.(string))
+		}
 
-			/* Support for secure properties
-			if (property is secure) {
-				if edgeHostname, ok := edgeHostnamesMap[hostname.(string)+".edgekey.net"]; ok {
-					hostnameEdgeHostnameMap[hostname.(string)] = edgeHostname
-				}
-			}
-			*/
+		if len(unmapped) > 0 && !allowDefaultEdgeHostname {
+			return nil, fmt.Errorf("no edge hostname specified or matching 1:1 (with domain suffix %s) for hostnames: %s; set edge_hostname or allow_default_edge_hostname = true", domainSuffix, strings.Join(unmapped, ", "))
+		}
+
+		if defaultEdgeHostname == nil {
+			defaultEdgeHostname = edgeHostnames.EdgeHostnames.Items[0]
 		}
 
 		// Fill in defaults
-		if len(hostnameEdgeHostnameMap) < len(hostnames) {
+		if len(unmapped) > 0 {
 			log.Printf("[DEBUG] Hostnames being set to default: %d of %d\n", len(hostnameEdgeHostnameMap), len(hostnames))
 			for _, hostname := range hostnames {
 				if _, ok := hostnameEdgeHostnameMap[hostname.(string)]; !ok {
@@ -1163,10 +1676,14 @@ func createHostnames(property *papi.Property, product *papi.Product, d *schema.R
 	}
 
 	// Contract/Group has no Edge Hostnames, create a single based on the first hostname
-	// mapping example.com -> example.com.edgegrid.net
+	// mapping example.com -> example.com.edgesuite.net (or the configured domain_suffix)
 	if len(edgeHostnames.EdgeHostnames.Items) == 0 {
+		if !createEdgeHostname {
+			return nil, fmt.Errorf("no edge hostnames exist in contract %s, group %s and create_edge_hostname is false", property.Contract.ContractID, property.Group.GroupID)
+		}
+
 		log.Println("[DEBUG] No Edge Hostnames found, creating new one")
-		newEdgeHostname, err := createEdgehostname(edgeHostnames, product, hostnames[0].(string), ipv6)
+		newEdgeHostname, err := createEdgehostname(edgeHostnames, product, hostnames[0].(string), domainSuffix, options, timeout)
 		if err != nil {
 			return nil, err
 		}
@@ -1181,71 +1698,149 @@ func createHostnames(property *papi.Property, product *papi.Product, d *schema.R
 	return hostnameEdgeHostnameMap, nil
 }
 
-func createEdgehostname(edgeHostnames *papi.EdgeHostnames, product *papi.Product, hostname string, ipv6 bool) (*papi.EdgeHostname, error) {
-	newEdgeHostname := papi.NewEdgeHostname(edgeHostnames)
-	newEdgeHostname.ProductID = product.ProductID
-	newEdgeHostname.IPVersionBehavior = "IPV4"
+// edgeHostnameOptions are the settings of a new edge hostname
+type edgeHostnameOptions struct {
+	Secure            bool
+	IPVersionBehavior string
+	// Enhanced TLS (edgekey.net) only
+	SlotNumber       int
+	CertEnrollmentID int
+	// Set with HAPI once the edge hostname is active, zero keeps the default
+	TTL      int
+	UseCases []edgeHostnameUseCase
+}
+
+func newEdgeHostnameOptions(secure bool, ipv6 bool) edgeHostnameOptions {
+	options := edgeHostnameOptions{Secure: secure, IPVersionBehavior: "IPV4"}
 	if ipv6 {
-		newEdgeHostname.IPVersionBehavior = "IPV6_COMPLIANCE"
+		options.IPVersionBehavior = "IPV6_COMPLIANCE"
+	}
+
+	return options
+}
+
+func createEdgehostname(edgeHostnames *papi.EdgeHostnames, product *papi.Product, hostname string, domainSuffix string, options edgeHostnameOptions, timeout time.Duration) (*papi.EdgeHostname, error) {
+	if product == nil {
+		return nil, fmt.Errorf("product_id must be specified to create edge hostname \"%s\"", hostname)
 	}
 
-	newEdgeHostname.EdgeHostnameDomain = hostname
-	err := newEdgeHostname.Save("")
+	newEdgeHostname := papi.NewEdgeHostname(edgeHostnames)
+	newEdgeHostname.ProductID = product.ProductID
+	newEdgeHostname.IPVersionBehavior = options.IPVersionBehavior
+	newEdgeHostname.SlotNumber = options.SlotNumber
+	newEdgeHostname.CertEnrollmentId = options.CertEnrollmentID
+
+	// Enhanced TLS (edgekey.net) edge hostnames are always secure
+	newEdgeHostname.Secure = options.Secure || domainSuffix == "edgekey.net"
+	newEdgeHostname.DomainSuffix = domainSuffix
+	newEdgeHostname.DomainPrefix = strings.TrimSuffix(hostname, "."+domainSuffix)
+	newEdgeHostname.EdgeHostnameDomain = newEdgeHostname.DomainPrefix + "." + domainSuffix
+	var err error
+	if len(options.UseCases) > 0 {
+		err = saveEdgeHostnameWithUseCases(edgeHostnames, newEdgeHostname, options.UseCases)
+	} else {
+		err = newEdgeHostname.Save("")
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	go newEdgeHostname.PollStatus("")
 
+	deadline := time.After(timeout)
 	for newEdgeHostname.Status != papi.StatusActive {
 		select {
 		case <-newEdgeHostname.StatusChange:
-		case <-time.After(time.Minute * 20):
+		case <-deadline:
 			return nil, fmt.Errorf("no edge hostname found and a timeout occurred trying to create \"%s.%s\"", newEdgeHostname.DomainPrefix, newEdgeHostname.DomainSuffix)
 		}
 	}
 
-	return newEdgeHostname, nil
-}
-
-func setEdgeHostnames(property *papi.Property, hostnameEdgeHostnameMap map[string]*papi.EdgeHostname) (map[string]string, error) {
-	if hostnameEdgeHostnameMap != nil {
-		log.Println("[DEBUG] Setting Edge Hostnames")
-		propertyHostnames, err := property.GetHostnames(nil)
-		if err != nil {
-			return nil, err
-		}
-
-		propertyHostnames.Hostnames.Items = []*papi.Hostname{}
-		for from, to := range hostnameEdgeHostnameMap {
-			hostname := propertyHostnames.NewHostname()
-			hostname.CnameType = papi.CnameTypeEdgeHostname
-			hostname.CnameFrom = from
-			hostname.CnameTo = to.EdgeHostnameDomain
-			hostname.EdgeHostnameID = to.EdgeHostnameID
-		}
-		log.Println("[DEBUG] Saving edge hostnames")
-		err = propertyHostnames.Save()
-		log.Println("[DEBUG] Edge hostnames saved")
-		if err != nil {
+	if options.TTL > 0 {
+		if err := setEdgeHostnameTTL(newEdgeHostname.DomainPrefix, newEdgeHostname.DomainSuffix, options.TTL); err != nil {
 			return nil, err
 		}
 	}
 
+	return newEdgeHostname, nil
+}
+
+func setEdgeHostnames(property *papi.Property, hostnameEdgeHostnameMap map[string]*papi.EdgeHostname) (map[string]string, error) {
 	hostnames, err := property.GetHostnames(nil)
 	if err != nil {
 		return nil, err
 	}
 
+	items := hostnames.Hostnames.Items
+	if hostnameEdgeHostnameMap != nil {
+		log.Println("[DEBUG] Setting Edge Hostnames")
+		if patch := diffHostnames(hostnames, hostnameEdgeHostnameMap); !patch.empty() {
+			log.Println("[DEBUG] Saving edge hostnames")
+			items, err = patchHostnames(property, patch)
+			if err != nil {
+				return nil, err
+			}
+			log.Println("[DEBUG] Edge hostnames saved")
+		} else {
+			log.Println("[DEBUG] Edge hostnames unchanged, skipping save")
+		}
+	}
+
 	var ehn = make(map[string]string)
-	for _, hostname := range hostnames.Hostnames.Items {
+	for _, hostname := range items {
 		ehn[strings.Replace(hostname.CnameFrom, ".", "-", -1)] = hostname.CnameTo
 	}
 
 	return ehn, nil
 }
 
-func unmarshalRules(d *schema.ResourceData, propertyRules *papi.Rules) {
+// diffHostnames reconciles the existing property hostnames against the desired mapping.
+// It returns the hostnames to add, including those pointing to another edge hostname,
+// and the hostnames to remove.
+func diffHostnames(hostnames *papi.Hostnames, hostnameEdgeHostnameMap map[string]*papi.EdgeHostname) *hostnamesPatch {
+	existing := make(map[string]*papi.Hostname, len(hostnames.Hostnames.Items))
+	for _, hostname := range hostnames.Hostnames.Items {
+		existing[hostname.CnameFrom] = hostname
+	}
+
+	patch := &hostnamesPatch{Add: []*papi.Hostname{}, Remove: []string{}}
+	var updated int
+	for from, to := range hostnameEdgeHostnameMap {
+		if hostname, ok := existing[from]; ok && hostname.EdgeHostnameID == to.EdgeHostnameID {
+			continue
+		} else if ok {
+			updated++
+		}
+
+		hostname := papi.NewHostname(hostnames)
+		hostname.CnameType = papi.CnameTypeEdgeHostname
+		hostname.CnameFrom = from
+		hostname.CnameTo = to.EdgeHostnameDomain
+		hostname.EdgeHostnameID = to.EdgeHostnameID
+		patch.Add = append(patch.Add, hostname)
+	}
+
+	for from := range existing {
+		if _, ok := hostnameEdgeHostnameMap[from]; !ok {
+			patch.Remove = append(patch.Remove, from)
+		}
+	}
+
+	sort.Slice(patch.Add, func(i, j int) bool {
+		return patch.Add[i].CnameFrom < patch.Add[j].CnameFrom
+	})
+	sort.Strings(patch.Remove)
+
+	log.Printf("[DEBUG] Hostnames: %d added, %d updated, %d removed, %d unchanged\n", len(patch.Add)-updated, updated, len(patch.Remove), len(hostnameEdgeHostnameMap)-len(patch.Add))
+	return patch
+}
+
+// resourceGetter is implemented by both schema.ResourceData and schema.ResourceDiff
+type resourceGetter interface {
+	GetOk(string) (interface{}, bool)
+}
+
+func unmarshalRules(d resourceGetter, propertyRules *papi.Rules) {
 	// Default Rules
 	rules, ok := d.GetOk("rules")
 	if ok {
@@ -1268,6 +1863,8 @@ func unmarshalRules(d *schema.ResourceData, propertyRules *papi.Rules) {
 					}
 				}
 
+				mergeIncludeBehavior(propertyRules.Rule, ruleTree)
+
 				criteria, ok := ruleTree["criteria"]
 				if ok {
 					for _, c := range criteria.(*schema.Set).List() {
@@ -1364,6 +1961,8 @@ func extractRules(drules *schema.Set) []*papi.Rule {
 				}
 			}
 
+			mergeIncludeBehavior(rule, vv)
+
 			criterias, ok := vv["criteria"]
 			if ok {
 				for _, criteria := range criterias.(*schema.Set).List() {
@@ -1408,27 +2007,93 @@ func extractRules(drules *schema.Set) []*papi.Rule {
 	return rules
 }
 
-func activateProperty(property *papi.Property, d *schema.ResourceData) (*papi.Activation, error) {
+// activateProperty submits an activation of the latest version, first resolving any activation
+// still pending on the network according to on_pending_activation
+func activateProperty(property *papi.Property, d *schema.ResourceData, meta interface{}, timeout time.Duration) (*papi.Activation, error) {
 	log.Println("[DEBUG] Creating new activation")
+	if err := activationFailures.err(); err != nil {
+		return nil, err
+	}
+
 	activation := papi.NewActivation(papi.NewActivations())
 	activation.PropertyVersion = property.LatestVersion
 	activation.Network = papi.NetworkValue(strings.ToUpper(d.Get("network").(string)))
-	for _, email := range d.Get("contact").(*schema.Set).List() {
-		activation.NotifyEmails = append(activation.NotifyEmails, email.(string))
+
+	notifyEmails, err := activationContacts(d, meta, activation.Network)
+	if err != nil {
+		return nil, err
 	}
-	activation.Note = "Using Terraform"
+
+	err = resolvePendingActivations(property, activation.Network, d.Get("on_pending_activation").(string), timeout)
+	if err != nil {
+		return nil, err
+	}
+	activation.NotifyEmails = notifyEmails
+	activation.Note = d.Get("activation_note").(string)
 	log.Println("[DEBUG] Activating")
-	err := activation.Save(property, true)
+	request := &activationRequest{Activation: activation, ComplianceRecord: getComplianceRecord(d)}
+
+	// Only akamai_property_activation tracks the fallback version
+	if fallbackVersion, ok := d.GetOk("fallback_version"); ok && fallbackVersion.(int) == activation.PropertyVersion {
+		if canFastFallback, _ := d.Get("can_fast_fallback").(bool); canFastFallback {
+			log.Printf("[INFO] Falling back to %s v%d on %s using fast fallback\n", property.PropertyID, activation.PropertyVersion, activation.Network)
+			request.UseFastFallback = true
+		}
+	}
+
+	err = saveActivation(property, request, d.Get("auto_acknowledge_rule_warnings").(bool), getAcceptableWarnings(d))
 	if err != nil {
 		body, _ := json.Marshal(activation)
 		log.Printf("[DEBUG] API Request Body: %s\n", string(body))
-		return nil, err
+		return nil, activationFailures.record(err)
 	}
 	log.Println("[DEBUG] Activation submitted successfully")
 
+	warnings := request.Warnings
+	if warnings == nil {
+		warnings = []string{}
+	}
+	d.Set("activation_warnings", warnings)
+	d.Set("activation_errors", []string{})
+
 	return activation, nil
 }
 
+// activationContacts returns the notification emails for an activation on the given network.
+// The resource's notify_emails take precedence over its network specific contacts and its
+// contact, followed by the provider's network specific contacts.
+func activationContacts(d *schema.ResourceData, meta interface{}, network papi.NetworkValue) ([]string, error) {
+	if notifyEmails := setToStringSlice(d.Get("notify_emails").(*schema.Set)); len(notifyEmails) > 0 {
+		return notifyEmails, nil
+	}
+
+	key := "staging_contact"
+	if network == papi.NetworkProduction {
+		key = "production_contact"
+	}
+
+	if contacts := setToStringSlice(d.Get(key).(*schema.Set)); len(contacts) > 0 {
+		return contacts, nil
+	}
+
+	if contacts := setToStringSlice(d.Get("contact").(*schema.Set)); len(contacts) > 0 {
+		return contacts, nil
+	}
+
+	if config, ok := meta.(*Config); ok {
+		defaults := config.StagingContact
+		if network == papi.NetworkProduction {
+			defaults = config.ProductionContact
+		}
+
+		if len(defaults) > 0 {
+			return defaults, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no contact configured for %s activations: set %s or contact on the resource, or %s on the provider", strings.ToLower(string(network)), key, key)
+}
+
 func findProperty(d *schema.ResourceData) *papi.Property {
 	results, err := papi.Search(papi.SearchByPropertyName, d.Get("name").(string))
 	if err != nil {
@@ -1466,7 +2131,22 @@ func findProperty(d *schema.ResourceData) *papi.Property {
 	return property
 }
 
-func ensureEditableVersion(property *papi.Property) error {
+// propertyVersionLock serializes edits of the latest property version, which
+// akamai_property_rules and akamai_property_hostnames may make concurrently
+var propertyVersionLock sync.Mutex
+
+// ensureEditableVersion creates a new version from the latest one if it has been activated.
+//
+// With a version_base other than latest, the latest version is only reused while it is
+// inactive and is the version in the state or was created by the provider. Otherwise,
+// e.g. when it holds abandoned changes made outside of Terraform, a new version is
+// created from the version active on the given network, or from the given version.
+//
+// PAPI has no operation to delete property versions, so versions that are never activated
+// (e.g. after a failed apply) cannot be cleaned up by the provider; the latest version is
+// reused as long as it stays inactive.
+// https://developer.akamai.com/api/luna/papi/resources.html#versionsapi
+func ensureEditableVersion(property *papi.Property, base string, current int) error {
 	latestVersion, err := property.GetLatestVersion("")
 	if err != nil {
 		return err
@@ -1477,14 +2157,118 @@ func ensureEditableVersion(property *papi.Property) error {
 		return err
 	}
 
-	if latestVersion.ProductionStatus != papi.StatusInactive || latestVersion.StagingStatus != papi.StatusInactive {
-		// The latest version has been activated on either production or staging, so we need to create a new version to apply changes on
-		newVersion := versions.NewVersion(latestVersion, false)
-		err = newVersion.Save()
+	editable := latestVersion.ProductionStatus == papi.StatusInactive && latestVersion.StagingStatus == papi.StatusInactive
+	createFrom := latestVersion
+	if base != "" && base != "latest" {
+		if editable && (latestVersion.PropertyVersion == current || createdPropertyVersion(property.PropertyID) == latestVersion.PropertyVersion) {
+			return property.GetProperty()
+		}
+
+		createFrom, err = getBaseVersion(property, versions, base)
+		if err != nil {
+			return err
+		}
+		if createFrom == nil {
+			log.Printf("[WARN] %s has no version active on %s, using the latest version instead\n", property.PropertyID, base)
+			createFrom = latestVersion
+		} else {
+			editable = editable && createFrom.PropertyVersion == latestVersion.PropertyVersion
+		}
+	}
+
+	if !editable {
+		// The latest version has been activated on either production or staging, or is not
+		// based on version_base, so we need to create a new version to apply changes on
+		if err := createPropertyVersion(property, versions, createFrom); err != nil {
+			return err
+		}
+	}
+
+	return property.GetProperty()
+}
+
+// newPropertyVersion creates a new version from the given version, or from the latest
+// one if from is 0, whether or not it has been activated
+func newPropertyVersion(property *papi.Property, from int) error {
+	versions, err := property.GetVersions()
+	if err != nil {
+		return err
+	}
+
+	createFrom, err := property.GetLatestVersion("")
+	if err != nil {
+		return err
+	}
+	if from > 0 {
+		createFrom, err = getBaseVersion(property, versions, strconv.Itoa(from))
 		if err != nil {
 			return err
 		}
 	}
 
+	if err := createPropertyVersion(property, versions, createFrom); err != nil {
+		return err
+	}
+
 	return property.GetProperty()
 }
+
+func createPropertyVersion(property *papi.Property, versions *papi.Versions, createFrom *papi.Version) error {
+	newVersion := versions.NewVersion(createFrom, false)
+	if err := newVersion.Save(); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Created version %d of %s from version %d\n", newVersion.PropertyVersion, property.PropertyID, createFrom.PropertyVersion)
+	setCreatedPropertyVersion(property.PropertyID, newVersion.PropertyVersion)
+	return nil
+}
+
+// getBaseVersion returns the version_base version, or nil if no version is active on the network
+func getBaseVersion(property *papi.Property, versions *papi.Versions, base string) (*papi.Version, error) {
+	if base == "staging" || base == "production" {
+		version, err := property.GetLatestVersion(papi.NetworkValue(strings.ToUpper(base)))
+		if err != nil {
+			if apiErr, ok := err.(client.APIError); ok && apiErr.Status == 404 {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		return version, nil
+	}
+
+	number, err := strconv.Atoi(base)
+	if err != nil {
+		return nil, fmt.Errorf("version_base must be latest, staging, production or a version number, got \"%s\"", base)
+	}
+
+	for _, version := range versions.Versions.Items {
+		if version.PropertyVersion == number {
+			return version, nil
+		}
+	}
+
+	return nil, fmt.Errorf("version %d of %s does not exist", number, property.PropertyID)
+}
+
+// propertyVersionsCreated holds the last version created by ensureEditableVersion for each
+// property, so that resources editing the same property in one run share that version
+var (
+	propertyVersionsCreated     = make(map[string]int)
+	propertyVersionsCreatedLock sync.Mutex
+)
+
+func createdPropertyVersion(propertyID string) int {
+	propertyVersionsCreatedLock.Lock()
+	defer propertyVersionsCreatedLock.Unlock()
+
+	return propertyVersionsCreated[propertyID]
+}
+
+func setCreatedPropertyVersion(propertyID string, version int) {
+	propertyVersionsCreatedLock.Lock()
+	defer propertyVersionsCreatedLock.Unlock()
+
+	propertyVersionsCreated[propertyID] = version
+}
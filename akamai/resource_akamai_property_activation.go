@@ -0,0 +1,250 @@
+package akamai
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// akamai_property_activation activates a version of a property created with
+// akamai_property_bootstrap, and deactivates it on destroy
+func resourcePropertyActivation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePropertyActivationCreate,
+		Read:   resourcePropertyActivationRead,
+		Update: resourcePropertyActivationUpdate,
+		Delete: resourcePropertyActivationDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(90 * time.Minute),
+			Update: schema.DefaultTimeout(90 * time.Minute),
+			Delete: schema.DefaultTimeout(90 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"property_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"network": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "staging",
+				ForceNew: true,
+			},
+			"contact":                        akamaiPropertySchema["contact"],
+			"staging_contact":                akamaiPropertySchema["staging_contact"],
+			"production_contact":             akamaiPropertySchema["production_contact"],
+			"notify_emails":                  akamaiPropertySchema["notify_emails"],
+			"activation_note":                akamaiPropertySchema["activation_note"],
+			"compliance_record":              akpsComplianceRecord,
+			"auto_acknowledge_rule_warnings": akamaiPropertySchema["auto_acknowledge_rule_warnings"],
+			"acceptable_rule_warnings":       akamaiPropertySchema["acceptable_rule_warnings"],
+			"on_pending_activation":          akpsOnPendingActivation,
+			"wait_for_activation":            akamaiPropertySchema["wait_for_activation"],
+			"allow_production_deactivation":  akamaiPropertySchema["allow_production_deactivation"],
+			"prevent_deactivation_on":        akamaiPropertySchema["prevent_deactivation_on"],
+			"force_deactivation":             akamaiPropertySchema["force_deactivation"],
+			"activation_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"activation_warnings": akamaiPropertySchema["activation_warnings"],
+			"activation_errors":   akamaiPropertySchema["activation_errors"],
+			// Setting version to fallback_version while can_fast_fallback is true
+			// reverts to it with fast fallback
+			"fallback_version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"can_fast_fallback": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"fast_fallback_expiration": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourcePropertyActivationCreate(d *schema.ResourceData, meta interface{}) error {
+	property, e := propertyForActivation(d)
+	if e != nil {
+		return e
+	}
+
+	timeout := d.Timeout(schema.TimeoutCreate)
+	if !d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutUpdate)
+	}
+
+	activation, e := activateProperty(property, d, meta, timeout)
+	if e != nil {
+		return e
+	}
+
+	d.SetId(property.PropertyID + ":" + strings.ToLower(string(activation.Network)))
+	d.Set("activation_id", activation.ActivationID)
+	d.Set("status", activation.Status)
+
+	if !d.Get("wait_for_activation").(bool) {
+		log.Printf("[INFO] Activation %s of %s v%d submitted, not waiting for it to complete", activation.ActivationID, property.PropertyID, activation.PropertyVersion)
+		return nil
+	}
+
+	if e := waitForActivation(property, activation, timeout); e != nil {
+		return e
+	}
+	d.Set("status", activation.Status)
+
+	if e := setActivationFallbackInfo(d, property, activation.ActivationID); e != nil {
+		return e
+	}
+
+	log.Println("[DEBUG] Done")
+	return nil
+}
+
+// Changing only the destroy settings does not activate the version again
+func resourcePropertyActivationUpdate(d *schema.ResourceData, meta interface{}) error {
+	if onlyDestroySettingsChanged(changedKeys(d, resourcePropertyActivation().Schema)) {
+		log.Printf("[DEBUG] Only destroy settings of %s changed, not activating it again", d.Id())
+		return nil
+	}
+
+	return resourcePropertyActivationCreate(d, meta)
+}
+
+func resourcePropertyActivationRead(d *schema.ResourceData, meta interface{}) error {
+	property, err := propertyForActivation(d)
+	if err != nil {
+		return err
+	}
+
+	// An activation submitted without waiting is kept while it is in progress
+	if activationID, ok := d.GetOk("activation_id"); ok {
+		submitted := papi.NewActivation(papi.NewActivations())
+		submitted.ActivationID = activationID.(string)
+		if _, err := submitted.GetActivation(property); err != nil {
+			return err
+		}
+
+		d.Set("status", submitted.Status)
+		if pendingActivationStatuses[submitted.Status] {
+			return nil
+		}
+
+		errs, err := activationErrors(property, submitted)
+		if err != nil {
+			return err
+		}
+		d.Set("activation_errors", errs)
+	}
+
+	activations, err := property.GetActivations()
+	if err != nil {
+		return err
+	}
+
+	// Removed from the state if the version is no longer active, so it gets activated again
+	network := papi.NetworkValue(strings.ToUpper(d.Get("network").(string)))
+	activation, err := activations.GetLatestActivation(network, papi.StatusActive)
+	if err != nil || activation.ActivationType != papi.ActivationTypeActivate {
+		log.Printf("[WARN] %s has no active version on %s, removing from state", property.PropertyID, network)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("version", activation.PropertyVersion)
+	d.Set("activation_id", activation.ActivationID)
+	d.Set("status", activation.Status)
+
+	return setActivationFallbackInfo(d, property, activation.ActivationID)
+}
+
+func setActivationFallbackInfo(d *schema.ResourceData, property *papi.Property, activationID string) error {
+	fallbackInfo, err := getActivationFallbackInfo(property, activationID)
+	if err != nil {
+		return err
+	}
+
+	d.Set("fallback_version", fallbackInfo.FallbackVersion)
+	d.Set("can_fast_fallback", fallbackInfo.CanFastFallback && time.Now().Unix() < fallbackInfo.FastFallbackExpirationTime)
+	if fallbackInfo.FastFallbackExpirationTime > 0 {
+		d.Set("fast_fallback_expiration", time.Unix(fallbackInfo.FastFallbackExpirationTime, 0).UTC().Format(time.RFC3339))
+	} else {
+		d.Set("fast_fallback_expiration", "")
+	}
+
+	return nil
+}
+
+func resourcePropertyActivationDelete(d *schema.ResourceData, meta interface{}) error {
+	property, e := propertyForActivation(d)
+	if e != nil {
+		return e
+	}
+
+	activations, e := property.GetActivations()
+	if e != nil {
+		return e
+	}
+
+	network := papi.NetworkValue(strings.ToUpper(d.Get("network").(string)))
+	activation, e := activations.GetLatestActivation(network, papi.StatusActive)
+	// see resourcePropertyDelete
+	if e == nil && activation.ActivationType == papi.ActivationTypeActivate {
+		if e := checkDeactivationPrevented(d, activation.Network); e != nil {
+			return e
+		}
+
+		if !productionDeactivationAllowed(d, activation.Network) {
+			d.SetId("")
+			return nil
+		}
+
+		deactivation := papi.NewActivation(papi.NewActivations())
+		deactivation.PropertyVersion = activation.PropertyVersion
+		deactivation.ActivationType = papi.ActivationTypeDeactivate
+		deactivation.Network = activation.Network
+		deactivation.NotifyEmails = activation.NotifyEmails
+		e = deactivation.Save(property, true)
+		if e != nil {
+			return e
+		}
+		log.Printf("[DEBUG] DEACTIVATION SAVED - ID %s STATUS %s\n", deactivation.ActivationID, deactivation.Status)
+
+		if e := waitForActivation(property, deactivation, d.Timeout(schema.TimeoutDelete)); e != nil {
+			return e
+		}
+	}
+
+	d.SetId("")
+
+	log.Println("[DEBUG] Done")
+	return nil
+}
+
+// propertyForActivation fetches the property, with the version to activate as its latest version
+func propertyForActivation(d *schema.ResourceData) (*papi.Property, error) {
+	property := papi.NewProperty(papi.NewProperties())
+	property.PropertyID = d.Get("property_id").(string)
+	if err := property.GetProperty(); err != nil {
+		return nil, err
+	}
+
+	property.LatestVersion = d.Get("version").(int)
+	return property, nil
+}
@@ -0,0 +1,134 @@
+package akamai
+
+import (
+	"errors"
+	"log"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// akamai_property_bootstrap manages only the property itself; its rules,
+// hostnames and activations are managed by akamai_property_rules,
+// akamai_property_hostnames and akamai_property_activation
+func resourcePropertyBootstrap() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePropertyBootstrapCreate,
+		Read:   resourcePropertyBootstrapRead,
+		Delete: resourcePropertyBootstrapDelete,
+		Exists: resourcePropertyExists,
+		Importer: &schema.ResourceImporter{
+			State: resourcePropertyBootstrapImport,
+		},
+		CustomizeDiff: resourcePropertyBootstrapCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"contract_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"product_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"rule_format": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"account_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourcePropertyBootstrapCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return resourceCheckContractGroup(d)
+}
+
+func resourcePropertyBootstrapCreate(d *schema.ResourceData, meta interface{}) error {
+	group, e := getGroup(d)
+	if e != nil {
+		return e
+	}
+
+	contract, e := getContract(d)
+	if e != nil {
+		return e
+	}
+
+	product, e := getProduct(d, contract)
+	if e != nil {
+		return e
+	}
+	if product == nil {
+		return errors.New("product_id must be specified to create a new property")
+	}
+
+	property, e := createProperty(contract, group, product, nil, d)
+	if e != nil {
+		return e
+	}
+
+	d.SetId(property.PropertyID)
+
+	return resourcePropertyBootstrapRead(d, meta)
+}
+
+func resourcePropertyBootstrapRead(d *schema.ResourceData, meta interface{}) error {
+	property, err := getProperty(d)
+	if err != nil {
+		return err
+	}
+
+	d.Set("account_id", property.AccountID)
+	d.Set("contract_id", property.ContractID)
+	d.Set("group_id", property.GroupID)
+	d.Set("name", property.PropertyName)
+	d.Set("rule_format", property.RuleFormat)
+	if property.ProductID != "" {
+		d.Set("product_id", property.ProductID)
+	}
+
+	return nil
+}
+
+func resourcePropertyBootstrapDelete(d *schema.ResourceData, meta interface{}) error {
+	property := papi.NewProperty(papi.NewProperties())
+	property.PropertyID = d.Id()
+	property.Contract = &papi.Contract{ContractID: d.Get("contract_id").(string)}
+	property.Group = &papi.Group{GroupID: d.Get("group_id").(string)}
+
+	// Active properties cannot be deleted, akamai_property_activation resources
+	// depending on this property are destroyed (deactivated) first
+	if err := property.Delete(); err != nil {
+		return err
+	}
+
+	d.SetId("")
+
+	log.Println("[DEBUG] Done")
+	return nil
+}
+
+func resourcePropertyBootstrapImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := resourcePropertyBootstrapRead(d, meta); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,41 @@
+package akamai
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestResourcePropertyBootstrapCreate(t *testing.T) {
+	property := `{"properties": {"items": [{
+		"accountId": "act_1",
+		"contractId": "ctr_1",
+		"groupId": "grp_1",
+		"propertyId": "prp_1",
+		"propertyName": "www.example.com",
+		"productId": "prd_SPM",
+		"ruleFormat": "v2018-02-27"
+	}]}}`
+	defer testPAPIServer(t, map[string]string{
+		"GET /papi/v1/groups":           `{"groups": {"items": [{"groupId": "grp_1", "contractIds": ["ctr_1"]}]}}`,
+		"GET /papi/v1/contracts":        `{"contracts": {"items": [{"contractId": "ctr_1"}]}}`,
+		"GET /papi/v1/products":         `{"products": {"items": [{"productId": "prd_SPM"}]}}`,
+		"POST /papi/v1/properties":      `{"propertyLink": "/papi/v1/properties/prp_1?contractId=ctr_1&groupId=grp_1"}`,
+		"GET /papi/v1/properties/prp_1": property,
+	})()
+
+	d := schema.TestResourceDataRaw(t, resourcePropertyBootstrap().Schema, map[string]interface{}{
+		"name":        "www.example.com",
+		"contract_id": "ctr_1",
+		"group_id":    "grp_1",
+		"product_id":  "prd_SPM",
+		"rule_format": "v2018-02-27",
+	})
+
+	if err := resourcePropertyBootstrapCreate(d, &Config{}); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != "prp_1" || d.Get("account_id").(string) != "act_1" {
+		t.Errorf("unexpected state: ID %q, account %q", d.Id(), d.Get("account_id"))
+	}
+}
@@ -0,0 +1,220 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// akamai_property_hostname_bucket manages hostnames in the bucket of a property
+// created with use_hostname_bucket on one network. Changes are applied directly
+// to the network as one hostname activation, without property versions. Only
+// the hostnames in the configuration are managed, others in the bucket are left
+// alone.
+func resourcePropertyHostnameBucket() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePropertyHostnameBucketCreate,
+		Read:   resourcePropertyHostnameBucketRead,
+		Update: resourcePropertyHostnameBucketUpdate,
+		Delete: resourcePropertyHostnameBucketDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"property_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"network": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"staging", "production"}, false),
+			},
+			"hostname": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cname_from": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"edge_hostname_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"cert_provisioning_type": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "CPS_MANAGED",
+							ValidateFunc: validation.StringInSlice([]string{"CPS_MANAGED", "DEFAULT"}, false),
+						},
+					},
+				},
+			},
+			"note": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"notify_emails": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// The last hostname activation
+			"activation_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourcePropertyHostnameBucketCreate(d *schema.ResourceData, meta interface{}) error {
+	property, err := getHostnameBucketProperty(d)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", property.PropertyID, d.Get("network").(string)))
+
+	return resourcePropertyHostnameBucketApply(d, property, nil, expandBucketHostnames(d.Get("hostname").(*schema.Set)), d.Timeout(schema.TimeoutCreate))
+}
+
+func resourcePropertyHostnameBucketUpdate(d *schema.ResourceData, meta interface{}) error {
+	property, err := getHostnameBucketProperty(d)
+	if err != nil {
+		return err
+	}
+
+	hostnamesInState, hostnamesInConfig := d.GetChange("hostname")
+	return resourcePropertyHostnameBucketApply(d, property, expandBucketHostnames(hostnamesInState.(*schema.Set)), expandBucketHostnames(hostnamesInConfig.(*schema.Set)), d.Timeout(schema.TimeoutUpdate))
+}
+
+func resourcePropertyHostnameBucketDelete(d *schema.ResourceData, meta interface{}) error {
+	property, err := getHostnameBucketProperty(d)
+	if err != nil {
+		return err
+	}
+
+	if err := resourcePropertyHostnameBucketApply(d, property, expandBucketHostnames(d.Get("hostname").(*schema.Set)), nil, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourcePropertyHostnameBucketApply patches the bucket from the managed
+// hostnames, previous being those in the state, to desired, and waits for the
+// hostname activation
+func resourcePropertyHostnameBucketApply(d *schema.ResourceData, property *papi.Property, previous []*bucketHostname, desired []*bucketHostname, timeout time.Duration) error {
+	network := d.Get("network").(string)
+
+	bucket, err := getBucketHostnames(property, network)
+	if err != nil {
+		return err
+	}
+
+	add, remove := diffBucketHostnames(managedBucketHostnames(bucket, previous, desired), desired)
+	if len(add) == 0 && len(remove) == 0 {
+		log.Printf("[DEBUG] Hostnames of %s on %s are up to date\n", property.PropertyID, network)
+		return nil
+	}
+
+	var notifyEmails []string
+	for _, email := range d.Get("notify_emails").(*schema.Set).List() {
+		notifyEmails = append(notifyEmails, email.(string))
+	}
+
+	activationID, err := patchBucketHostnames(property, network, add, remove, d.Get("note").(string), notifyEmails)
+	if err != nil {
+		return err
+	}
+	d.Set("activation_id", activationID)
+
+	return waitForHostnameActivation(property, activationID, timeout)
+}
+
+func resourcePropertyHostnameBucketRead(d *schema.ResourceData, meta interface{}) error {
+	property, err := getHostnameBucketProperty(d)
+	if err != nil {
+		return err
+	}
+
+	bucket, err := getBucketHostnames(property, d.Get("network").(string))
+	if err != nil {
+		return err
+	}
+
+	var hostnames []interface{}
+	for _, hostname := range managedBucketHostnames(bucket, expandBucketHostnames(d.Get("hostname").(*schema.Set)), nil) {
+		hostnames = append(hostnames, map[string]interface{}{
+			"cname_from":             hostname.CnameFrom,
+			"edge_hostname_id":       hostname.EdgeHostnameID,
+			"cert_provisioning_type": hostname.CertProvisioningType,
+		})
+	}
+
+	return d.Set("hostname", hostnames)
+}
+
+func getHostnameBucketProperty(d *schema.ResourceData) (*papi.Property, error) {
+	property := papi.NewProperty(papi.NewProperties())
+	property.PropertyID = d.Get("property_id").(string)
+	if err := property.GetProperty(); err != nil {
+		return nil, err
+	}
+
+	bucket, err := isHostnameBucketProperty(property)
+	if err != nil {
+		return nil, err
+	}
+	if !bucket {
+		return nil, fmt.Errorf("%s does not use a hostname bucket, its hostnames are managed with its versions", property.PropertyID)
+	}
+
+	return property, nil
+}
+
+func expandBucketHostnames(hostnames *schema.Set) []*bucketHostname {
+	var expanded []*bucketHostname
+	for _, h := range hostnames.List() {
+		h := h.(map[string]interface{})
+		expanded = append(expanded, &bucketHostname{
+			CnameFrom:            h["cname_from"].(string),
+			CnameType:            "EDGE_HOSTNAME",
+			EdgeHostnameID:       h["edge_hostname_id"].(string),
+			CertProvisioningType: h["cert_provisioning_type"].(string),
+		})
+	}
+
+	return expanded
+}
+
+// managedBucketHostnames returns the hostnames of the bucket that are either in
+// the state or in the configuration
+func managedBucketHostnames(bucket []*bucketHostname, previous []*bucketHostname, desired []*bucketHostname) []*bucketHostname {
+	managed := make(map[string]bool)
+	for _, hostname := range append(previous, desired...) {
+		managed[strings.ToLower(hostname.CnameFrom)] = true
+	}
+
+	var found []*bucketHostname
+	for _, hostname := range bucket {
+		if managed[strings.ToLower(hostname.CnameFrom)] {
+			found = append(found, hostname)
+		}
+	}
+
+	return found
+}
@@ -0,0 +1,41 @@
+package akamai
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffBucketHostnames(t *testing.T) {
+	current := []*bucketHostname{
+		{CnameFrom: "www.example.com", EdgeHostnameID: "ehn_1", CertProvisioningType: "CPS_MANAGED"},
+		{CnameFrom: "api.example.com", EdgeHostnameID: "ehn_1", CertProvisioningType: "CPS_MANAGED"},
+		{CnameFrom: "old.example.com", EdgeHostnameID: "ehn_1", CertProvisioningType: "CPS_MANAGED"},
+	}
+	desired := []*bucketHostname{
+		{CnameFrom: "WWW.example.com", EdgeHostnameID: "ehn_1", CertProvisioningType: "CPS_MANAGED"},
+		{CnameFrom: "api.example.com", EdgeHostnameID: "ehn_2", CertProvisioningType: "CPS_MANAGED"},
+		{CnameFrom: "new.example.com", EdgeHostnameID: "ehn_1", CertProvisioningType: "DEFAULT"},
+	}
+
+	add, remove := diffBucketHostnames(current, desired)
+
+	if len(add) != 2 || add[0].CnameFrom != "api.example.com" || add[1].CnameFrom != "new.example.com" {
+		t.Errorf("expected api and new to be added, got %v", add)
+	}
+	if !reflect.DeepEqual(remove, []string{"old.example.com"}) {
+		t.Errorf("expected old to be removed, got %v", remove)
+	}
+}
+
+func TestManagedBucketHostnames(t *testing.T) {
+	bucket := []*bucketHostname{
+		{CnameFrom: "www.example.com"},
+		{CnameFrom: "other.example.com"},
+		{CnameFrom: "removed.example.com"},
+	}
+
+	managed := managedBucketHostnames(bucket, []*bucketHostname{{CnameFrom: "removed.example.com"}}, []*bucketHostname{{CnameFrom: "www.example.com"}})
+	if len(managed) != 2 || managed[0].CnameFrom != "www.example.com" || managed[1].CnameFrom != "removed.example.com" {
+		t.Errorf("expected www and removed to be managed, got %v", managed)
+	}
+}
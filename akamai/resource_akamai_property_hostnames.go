@@ -0,0 +1,192 @@
+package akamai
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// akamai_property_hostnames manages the hostnames of a property created with
+// akamai_property_bootstrap, saving them to the latest editable version
+func resourcePropertyHostnames() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourcePropertyHostnamesCreate,
+		Read:          resourcePropertyHostnamesRead,
+		Update:        resourcePropertyHostnamesCreate,
+		Delete:        resourcePropertyHostnamesDelete,
+		CustomizeDiff: resourcePropertyHostnamesCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(90 * time.Minute),
+			Update: schema.DefaultTimeout(90 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"property_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// Used when an edge hostname has to be created
+			"product_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"hostname": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"edge_hostname": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"ipv6": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"domain_suffix": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "edgesuite.net",
+				ValidateFunc: validation.StringInSlice([]string{"edgesuite.net", "edgekey.net", "akamaized.net"}, false),
+			},
+			"secure": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"certificate_enrollment_id": akamaiPropertySchema["certificate_enrollment_id"],
+			"create_edge_hostname": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"version_base":           akamaiPropertySchema["version_base"],
+			"certificate_challenges": akpsCertificateChallenges,
+			"edge_hostnames": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// A hostnames change is saved to a new version whenever the current one has
+// been activated, so the version is unknown until apply
+func resourcePropertyHostnamesCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && len(d.GetChangedKeysPrefix("")) > 0 {
+		return d.SetNewComputed("version")
+	}
+
+	return nil
+}
+
+func resourcePropertyHostnamesCreate(d *schema.ResourceData, meta interface{}) error {
+	propertyVersionLock.Lock()
+	defer propertyVersionLock.Unlock()
+
+	d.SetId(d.Get("property_id").(string))
+
+	property, e := getProperty(d)
+	if e != nil {
+		return e
+	}
+
+	bucket, e := isHostnameBucketProperty(property)
+	if e != nil {
+		return e
+	}
+	if bucket {
+		return fmt.Errorf("%s uses a hostname bucket, its hostnames are not versioned and cannot be managed with akamai_property_hostnames", property.PropertyID)
+	}
+
+	e = ensureEditableVersion(property, d.Get("version_base").(string), d.Get("version").(int))
+	if e != nil {
+		return e
+	}
+
+	product, e := getProduct(d, property.Contract)
+	if e != nil {
+		return e
+	}
+	if product == nil {
+		return errors.New("product_id must be specified to create edge hostnames")
+	}
+
+	timeout := d.Timeout(schema.TimeoutCreate)
+	if !d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutUpdate)
+	}
+
+	hostnameEdgeHostnameMap, e := createHostnames(property, product, d, timeout)
+	if e != nil {
+		return e
+	}
+
+	edgeHostnames, e := setEdgeHostnames(property, hostnameEdgeHostnameMap)
+	if e != nil {
+		return e
+	}
+
+	d.Set("edge_hostnames", edgeHostnames)
+	d.Set("version", property.LatestVersion)
+
+	log.Println("[DEBUG] Done")
+	return nil
+}
+
+func resourcePropertyHostnamesRead(d *schema.ResourceData, meta interface{}) error {
+	property, err := getProperty(d)
+	if err != nil {
+		return err
+	}
+
+	hostnames, err := property.GetHostnames(nil)
+	if err != nil {
+		return err
+	}
+
+	var hostnameList []interface{}
+	edgeHostnames := make(map[string]interface{})
+	for _, hostname := range hostnames.Hostnames.Items {
+		hostnameList = append(hostnameList, hostname.CnameFrom)
+		edgeHostnames[strings.Replace(hostname.CnameFrom, ".", "-", -1)] = hostname.CnameTo
+	}
+
+	d.Set("property_id", property.PropertyID)
+	d.Set("hostname", hostnameList)
+	d.Set("edge_hostname", hostnamesEdgeHostname(hostnames, d.Get("edge_hostname").(string)))
+	d.Set("edge_hostnames", edgeHostnames)
+	d.Set("version", property.LatestVersion)
+
+	return setCertChallenges(d, property, property.LatestVersion)
+}
+
+// hostnamesEdgeHostname returns the edge hostname the hostnames are mapped to. A
+// hostname mapped to another edge hostname than current shows as a change of it.
+func hostnamesEdgeHostname(hostnames *papi.Hostnames, current string) string {
+	for _, hostname := range hostnames.Hostnames.Items {
+		if hostname.CnameTo != current {
+			return hostname.CnameTo
+		}
+	}
+
+	return current
+}
+
+// Hostnames are left on the property version, which may be active, so they are
+// only removed from the state
+func resourcePropertyHostnamesDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
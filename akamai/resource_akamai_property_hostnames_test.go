@@ -0,0 +1,50 @@
+package akamai
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestResourcePropertyHostnamesRead(t *testing.T) {
+	hostnames := `{"hostnames": {"items": [
+		{"cnameType": "EDGE_HOSTNAME", "cnameFrom": "www.example.com", "cnameTo": "www.example.com.edgesuite.net"},
+		{"cnameType": "EDGE_HOSTNAME", "cnameFrom": "static.example.com", "cnameTo": "static.example.com.edgesuite.net"}
+	]}}`
+	defer testPAPIServer(t, map[string]string{
+		"GET /papi/v1/groups":    `{"groups": {"items": [{"groupId": "grp_1", "contractIds": ["ctr_1"]}]}}`,
+		"GET /papi/v1/contracts": `{"contracts": {"items": [{"contractId": "ctr_1"}]}}`,
+		"GET /papi/v1/properties/prp_1": `{"properties": {"items": [{
+			"contractId": "ctr_1",
+			"groupId": "grp_1",
+			"propertyId": "prp_1",
+			"latestVersion": 2
+		}]}}`,
+		"GET /papi/v1/properties/prp_1/versions/latest": `{"versions": {"items": [{"propertyVersion": 2}]}}`,
+		// The hostnames, and their certificate statuses
+		"GET /papi/v1/properties/prp_1/versions/2/hostnames/": hostnames,
+		"GET /papi/v1/properties/prp_1/versions/2/hostnames":  hostnames,
+	})()
+
+	d := schema.TestResourceDataRaw(t, resourcePropertyHostnames().Schema, map[string]interface{}{
+		"property_id":   "prp_1",
+		"product_id":    "prd_SPM",
+		"hostname":      []interface{}{"www.example.com"},
+		"edge_hostname": "www.example.com.edgesuite.net",
+	})
+	d.SetId("prp_1")
+
+	if err := resourcePropertyHostnamesRead(d, &Config{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if hostnames := d.Get("hostname").(*schema.Set); hostnames.Len() != 2 || !hostnames.Contains("static.example.com") {
+		t.Errorf("expected the hostname added outside of Terraform, got %v", hostnames.List())
+	}
+	if edgeHostname := d.Get("edge_hostname").(string); edgeHostname != "static.example.com.edgesuite.net" {
+		t.Errorf("expected the hostname mapped to another edge hostname to change edge_hostname, got %s", edgeHostname)
+	}
+	if version := d.Get("version").(int); version != 2 {
+		t.Errorf("expected version 2, got %d", version)
+	}
+}
@@ -0,0 +1,251 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// akamai_property_include manages an include and the rule tree of its latest
+// version, which is saved to a new version whenever the latest one has been
+// activated. Activating includes is left to the Property Manager.
+func resourcePropertyInclude() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourcePropertyIncludeCreate,
+		Read:          resourcePropertyIncludeRead,
+		Update:        resourcePropertyIncludeUpdate,
+		Delete:        resourcePropertyIncludeDelete,
+		CustomizeDiff: resourcePropertyIncludeCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: resourcePropertyIncludeImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"contract_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"product_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(includeTypes, false),
+			},
+			// Defaults to the rule format PAPI creates the include with
+			"rule_format": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringMatch(ruleFormatRegexp, "must be latest or a dated rule format, e.g. v2018-02-27"),
+			},
+			"rules":                  akamaiPropertySchema["rules"],
+			"rules_lint":             akpsRulesLint,
+			"sensitive_option":       akpsSensitiveOption,
+			"validate_rules_on_plan": akpsValidateRulesOnPlan,
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"staging_version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"production_version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourcePropertyIncludeCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if err := resourcePropertyLintRules(d); err != nil {
+		return err
+	}
+
+	if err := resourceCheckSensitiveOptions(d); err != nil {
+		return err
+	}
+
+	if err := resourceCheckIncludeReferences(d); err != nil {
+		return err
+	}
+
+	if err := resourcePropertyIncludeValidateRules(d); err != nil {
+		return err
+	}
+
+	if d.Id() != "" && (d.HasChange("rules") || d.HasChange("rule_format") || d.HasChange("sensitive_option")) {
+		return d.SetNewComputed("version")
+	}
+
+	return nil
+}
+
+// resourcePropertyIncludeValidateRules validates changed rules with PAPI's dryRun,
+// see validate_rules_on_plan
+func resourcePropertyIncludeValidateRules(d *schema.ResourceDiff) error {
+	if d.Id() == "" || !d.Get("validate_rules_on_plan").(bool) || !d.HasChange("rules") {
+		return nil
+	}
+
+	if !d.NewValueKnown("rules") {
+		log.Println("[DEBUG] Rules are not known until apply, skipping validation")
+		return nil
+	}
+
+	include, err := getInclude(d.Get("contract_id").(string), d.Get("group_id").(string), d.Id())
+	if err != nil {
+		return err
+	}
+	if include == nil {
+		return nil
+	}
+
+	ruleFormat := d.Get("rule_format").(string)
+	rules, err := getIncludeRules(include, include.LatestVersion, ruleFormat)
+	if err != nil {
+		return err
+	}
+
+	unmarshalRules(d, rules)
+	if err := substituteSensitiveOptions(d, rules); err != nil {
+		return err
+	}
+
+	return saveIncludeRules(include, include.LatestVersion, rules, ruleFormat, true)
+}
+
+func resourcePropertyIncludeCreate(d *schema.ResourceData, meta interface{}) error {
+	includeID, err := createInclude(
+		d.Get("contract_id").(string),
+		d.Get("group_id").(string),
+		d.Get("product_id").(string),
+		d.Get("name").(string),
+		d.Get("type").(string),
+		d.Get("rule_format").(string),
+	)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(includeID)
+	log.Printf("[DEBUG] Created include %s\n", includeID)
+
+	return resourcePropertyIncludeUpdate(d, meta)
+}
+
+func resourcePropertyIncludeUpdate(d *schema.ResourceData, meta interface{}) error {
+	propertyVersionLock.Lock()
+	defer propertyVersionLock.Unlock()
+
+	include, err := getInclude(d.Get("contract_id").(string), d.Get("group_id").(string), d.Id())
+	if err != nil {
+		return err
+	}
+	if include == nil {
+		return fmt.Errorf("include %s does not exist", d.Id())
+	}
+
+	version, err := ensureEditableIncludeVersion(include)
+	if err != nil {
+		return err
+	}
+
+	ruleFormat := d.Get("rule_format").(string)
+	rules, err := getIncludeRules(include, version, ruleFormat)
+	if err != nil {
+		return err
+	}
+
+	unmarshalRules(d, rules)
+	if err := substituteSensitiveOptions(d, rules); err != nil {
+		return err
+	}
+
+	if err := saveIncludeRules(include, version, rules, ruleFormat, false); err != nil {
+		return err
+	}
+
+	return resourcePropertyIncludeRead(d, meta)
+}
+
+func resourcePropertyIncludeRead(d *schema.ResourceData, meta interface{}) error {
+	include, err := getInclude(d.Get("contract_id").(string), d.Get("group_id").(string), d.Id())
+	if err != nil {
+		return err
+	}
+	if include == nil {
+		log.Printf("[WARN] Include %s not found, removing from state\n", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	latest, err := getIncludeVersion(include, include.LatestVersion)
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", include.IncludeName)
+	d.Set("type", include.IncludeType)
+	d.Set("product_id", latest.ProductID)
+	d.Set("rule_format", latest.RuleFormat)
+	d.Set("version", include.LatestVersion)
+	d.Set("staging_version", include.StagingVersion)
+	d.Set("production_version", include.ProductionVersion)
+
+	return nil
+}
+
+// PAPI refuses to delete includes that are active on either network, or still
+// referenced by a property
+func resourcePropertyIncludeDelete(d *schema.ResourceData, meta interface{}) error {
+	include, err := getInclude(d.Get("contract_id").(string), d.Get("group_id").(string), d.Id())
+	if err != nil {
+		return err
+	}
+
+	if include != nil {
+		if include.StagingVersion > 0 || include.ProductionVersion > 0 {
+			return fmt.Errorf("include %s is active, deactivate it before destroying it", include.IncludeID)
+		}
+
+		if err := deleteInclude(include); err != nil {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourcePropertyIncludeImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ",")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid import ID %q, expected <contract_id>,<group_id>,<include_id>", d.Id())
+	}
+
+	d.Set("contract_id", parts[0])
+	d.Set("group_id", parts[1])
+	d.SetId(parts[2])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,149 @@
+package akamai
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// akamai_property_rules manages the rule tree of a property created with
+// akamai_property_bootstrap, saving it to the latest editable version
+func resourcePropertyRules() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourcePropertyRulesCreate,
+		Read:          resourcePropertyRulesRead,
+		Update:        resourcePropertyRulesCreate,
+		Delete:        resourcePropertyRulesDelete,
+		CustomizeDiff: resourcePropertyRulesCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"property_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// Will get added to the default rule
+			"cp_code": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"origin":                 akamaiPropertySchema["origin"],
+			"token_auth":             akpsTokenAuth,
+			"jwt_auth":               akpsJWTAuth,
+			"rules":                  akamaiPropertySchema["rules"],
+			"rules_lint":             akpsRulesLint,
+			"sensitive_option":       akpsSensitiveOption,
+			"validate_rules_on_plan": akpsValidateRulesOnPlan,
+			"version_base":           akamaiPropertySchema["version_base"],
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// A rules change is saved to a new version whenever the current one has been
+// activated, so the version is unknown until apply
+func resourcePropertyRulesCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if err := resourcePropertyLintRules(d); err != nil {
+		return err
+	}
+
+	if err := resourceCheckSensitiveOptions(d); err != nil {
+		return err
+	}
+
+	if err := resourceCheckIncludeReferences(d); err != nil {
+		return err
+	}
+
+	if err := resourcePropertyValidateRules(d); err != nil {
+		return err
+	}
+
+	if d.Id() != "" && len(d.GetChangedKeysPrefix("")) > 0 {
+		return d.SetNewComputed("version")
+	}
+
+	return nil
+}
+
+func resourcePropertyRulesCreate(d *schema.ResourceData, meta interface{}) error {
+	propertyVersionLock.Lock()
+	defer propertyVersionLock.Unlock()
+
+	d.SetId(d.Get("property_id").(string))
+
+	property, e := getProperty(d)
+	if e != nil {
+		return e
+	}
+
+	e = ensureEditableVersion(property, d.Get("version_base").(string), d.Get("version").(int))
+	if e != nil {
+		return e
+	}
+
+	cpCode, e := getCPCode(d, property.Contract, property.Group)
+	if e != nil {
+		return e
+	}
+
+	rules, e := property.GetRules()
+	if e != nil {
+		return e
+	}
+
+	origin, e := createOrigin(d)
+	if e != nil {
+		return e
+	}
+
+	updateStandardBehaviors(rules, cpCode, origin)
+	updateTokenAuthBehaviors(rules, d)
+	fixupPerformanceBehaviors(rules)
+
+	// get rules from the TF config
+	unmarshalRules(d, rules)
+	if e := substituteSensitiveOptions(d, rules); e != nil {
+		return e
+	}
+
+	e = saveRules(rules, "")
+	if e != nil {
+		return e
+	}
+
+	d.Set("version", property.LatestVersion)
+
+	log.Println("[DEBUG] Done")
+	return nil
+}
+
+func resourcePropertyRulesRead(d *schema.ResourceData, meta interface{}) error {
+	property, err := getProperty(d)
+	if err != nil {
+		return err
+	}
+
+	d.Set("property_id", property.PropertyID)
+	d.Set("version", property.LatestVersion)
+
+	rules, err := property.GetRules()
+	if err != nil {
+		return err
+	}
+
+	if current, ok := d.GetOk("rules"); ok && current.(*schema.Set).Len() > 0 {
+		ruleTree := current.(*schema.Set).List()[0].(map[string]interface{})
+		d.Set("rules", []interface{}{refreshRuleTree(ruleTree, flattenRuleTree(rules.Rule))})
+	}
+
+	return nil
+}
+
+// Rules cannot be removed from a property version, so they are only removed from the state
+func resourcePropertyRulesDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
@@ -2,6 +2,7 @@ package akamai
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -171,3 +172,52 @@ func testAccCheckAkamaiPropertyExists(s *terraform.State) error {
 	}
 	return nil
 }
+
+func TestParsePropertyImportID(t *testing.T) {
+	cases := map[string]struct {
+		id      string
+		version int
+	}{
+		"prp_12345":       {"prp_12345", 0},
+		"prp_12345:3":     {"prp_12345", 3},
+		"example.com@12":  {"example.com", 12},
+		"example.com":     {"example.com", 0},
+		"example.com@foo": {"example.com@foo", 0},
+	}
+
+	for importID, expected := range cases {
+		id, version := parsePropertyImportID(importID)
+		if id != expected.id || version != expected.version {
+			t.Fatalf("%s: expected %s and %d, got %s and %d", importID, expected.id, expected.version, id, version)
+		}
+	}
+}
+
+func TestFindProduct(t *testing.T) {
+	products := papi.NewProducts()
+	if product, err := findProduct(products, "ctr_1", "prd_SPM"); err != nil || product.ProductID != "prd_SPM" {
+		t.Fatalf("expected prd_SPM to be used as is with an empty catalog, got %v (%v)", product, err)
+	}
+
+	products.Products.Items = []*papi.Product{{ProductID: "SPM"}, {ProductID: "prd_Web_App_Accel"}}
+	if product, err := findProduct(products, "ctr_1", "prd_SPM"); err != nil || product.ProductID != "SPM" {
+		t.Fatalf("expected SPM, got %v (%v)", product, err)
+	}
+	if _, err := findProduct(products, "ctr_1", "prd_Dynamic_Site_Del"); err == nil || !strings.Contains(err.Error(), "prd_Web_App_Accel") {
+		t.Fatalf("expected an error listing the available products, got %v", err)
+	}
+}
+
+func TestIsCPCodeID(t *testing.T) {
+	for cpCode, expected := range map[string]bool{
+		"cpc_12345":    true,
+		"12345":        true,
+		"example-www":  false,
+		"cpc_example":  false,
+		"2018 release": false,
+	} {
+		if isCPCodeID(cpCode) != expected {
+			t.Errorf("isCPCodeID(%q): expected %t", cpCode, expected)
+		}
+	}
+}
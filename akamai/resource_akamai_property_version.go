@@ -0,0 +1,172 @@
+package akamai
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// akamai_property_version creates a property version from a base version, so that
+// creating a version, editing its rules and activating it can be separate steps.
+// PAPI cannot delete versions, so destroying it only removes it from the state.
+func resourcePropertyVersion() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePropertyVersionCreate,
+		Read:   resourcePropertyVersionRead,
+		Delete: resourcePropertyVersionDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourcePropertyVersionImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"property_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"version_base": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "latest",
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(latest|staging|production|[1-9][0-9]*)$`), "must be latest, staging, production or a version number"),
+			},
+			// Saved as the comments of the version's rule tree, which PAPI shows as the version note
+			"note": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"created_from_version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"staging_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"production_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourcePropertyVersionCreate(d *schema.ResourceData, meta interface{}) error {
+	propertyVersionLock.Lock()
+	defer propertyVersionLock.Unlock()
+
+	property := papi.NewProperty(papi.NewProperties())
+	property.PropertyID = d.Get("property_id").(string)
+	if err := property.GetProperty(); err != nil {
+		return err
+	}
+
+	versions, err := property.GetVersions()
+	if err != nil {
+		return err
+	}
+
+	base := d.Get("version_base").(string)
+	var createFrom *papi.Version
+	if base == "latest" {
+		createFrom, err = property.GetLatestVersion("")
+	} else {
+		createFrom, err = getBaseVersion(property, versions, base)
+	}
+	if err != nil {
+		return err
+	}
+	if createFrom == nil {
+		return fmt.Errorf("%s has no version active on %s", property.PropertyID, base)
+	}
+
+	if err := createPropertyVersion(property, versions, createFrom); err != nil {
+		return err
+	}
+	if err := property.GetProperty(); err != nil {
+		return err
+	}
+
+	version := createdPropertyVersion(property.PropertyID)
+	d.SetId(fmt.Sprintf("%s:%d", property.PropertyID, version))
+	d.Set("version", version)
+	d.Set("created_from_version", createFrom.PropertyVersion)
+
+	if note := d.Get("note").(string); note != "" {
+		log.Printf("[DEBUG] Setting the note of %s v%d\n", property.PropertyID, version)
+		rules, err := property.GetRules()
+		if err != nil {
+			return err
+		}
+
+		rules.Rule.Comments = note
+		if err := saveRules(rules, ""); err != nil {
+			return err
+		}
+	}
+
+	return resourcePropertyVersionRead(d, meta)
+}
+
+func resourcePropertyVersionRead(d *schema.ResourceData, meta interface{}) error {
+	property := papi.NewProperty(papi.NewProperties())
+	property.PropertyID = d.Get("property_id").(string)
+	if err := property.GetProperty(); err != nil {
+		return err
+	}
+
+	versions, err := property.GetVersions()
+	if err != nil {
+		return err
+	}
+
+	number := d.Get("version").(int)
+	for _, version := range versions.Versions.Items {
+		if version.PropertyVersion != number {
+			continue
+		}
+
+		d.Set("staging_status", string(version.StagingStatus))
+		d.Set("production_status", string(version.ProductionStatus))
+		return nil
+	}
+
+	log.Printf("[WARN] Version %d of %s not found, removing from state\n", number, property.PropertyID)
+	d.SetId("")
+	return nil
+}
+
+// Property versions cannot be deleted, so they are only removed from the state
+func resourcePropertyVersionDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+func resourcePropertyVersionImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid import ID %q, expected <property_id>:<version>", d.Id())
+	}
+
+	version, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid import ID %q, expected <property_id>:<version>", d.Id())
+	}
+
+	d.Set("property_id", parts[0])
+	d.Set("version", version)
+	d.Set("version_base", "latest")
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,105 @@
+package akamai
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// akamai_token_auth_key generates Token Authorization (EdgeAuth) keys for the
+// token_auth block of akamai_property. The keys are only stored in the state.
+//
+// Changing rotation generates a new key and keeps the previous one as the
+// transition key, so tokens signed with either are accepted until the next rotation.
+func resourceTokenAuthKey() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceTokenAuthKeyCreate,
+		Read:          schema.Noop,
+		Update:        resourceTokenAuthKeyUpdate,
+		Delete:        schema.RemoveFromState,
+		CustomizeDiff: resourceTokenAuthKeyCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			// Any change rotates the keys, e.g. a date or a counter
+			"rotation": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"byte_length": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      32,
+				ValidateFunc: validation.IntBetween(8, 32),
+			},
+			"key": &schema.Schema{
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"transition_key": &schema.Schema{
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func resourceTokenAuthKeyCreate(d *schema.ResourceData, meta interface{}) error {
+	key, err := generateTokenAuthKey(d.Get("byte_length").(int))
+	if err != nil {
+		return err
+	}
+
+	id, err := generateTokenAuthKey(8)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(id)
+	d.Set("key", key)
+	d.Set("transition_key", "")
+
+	return nil
+}
+
+// resourceTokenAuthKeyCustomizeDiff shows the rotated keys in the plan, so that
+// resources using them are updated in the same apply
+func resourceTokenAuthKeyCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || (!d.HasChange("rotation") && !d.HasChange("byte_length")) {
+		return nil
+	}
+
+	if err := d.SetNewComputed("key"); err != nil {
+		return err
+	}
+
+	return d.SetNewComputed("transition_key")
+}
+
+func resourceTokenAuthKeyUpdate(d *schema.ResourceData, meta interface{}) error {
+	if !d.HasChange("rotation") && !d.HasChange("byte_length") {
+		return nil
+	}
+
+	key, err := generateTokenAuthKey(d.Get("byte_length").(int))
+	if err != nil {
+		return err
+	}
+
+	d.Set("transition_key", d.Get("key").(string))
+	d.Set("key", key)
+
+	return nil
+}
+
+// generateTokenAuthKey returns length random bytes, hex encoded
+func generateTokenAuthKey(length int) (string, error) {
+	key := make([]byte, length)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(key), nil
+}
@@ -0,0 +1,112 @@
+package akamai
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// Request tracing
+//
+// With trace_file set, every API call is appended to the file as a JSON line
+// shaped like a HAR entry. Bodies are not recorded and credentials are
+// removed from the headers, so the file can be shared.
+
+// traceRedactedHeaders are replaced in the trace
+var traceRedactedHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+}
+
+type traceHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type traceEntry struct {
+	StartedDateTime string  `json:"startedDateTime"`
+	Time            float64 `json:"time"`
+	Request         struct {
+		Method   string        `json:"method"`
+		URL      string        `json:"url"`
+		Headers  []traceHeader `json:"headers"`
+		BodySize int64         `json:"bodySize"`
+	} `json:"request"`
+	Response struct {
+		Status     int           `json:"status"`
+		StatusText string        `json:"statusText"`
+		Headers    []traceHeader `json:"headers"`
+		BodySize   int64         `json:"bodySize"`
+	} `json:"response"`
+	Error string `json:"error,omitempty"`
+}
+
+// tracingTransport records each round trip to the trace file
+type tracingTransport struct {
+	transport http.RoundTripper
+	lock      sync.Mutex
+	file      *os.File
+}
+
+func newTracingTransport(path string, transport http.RoundTripper) (*tracingTransport, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tracingTransport{transport: transport, file: file}, nil
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	entry := &traceEntry{}
+	started := time.Now()
+	entry.StartedDateTime = started.UTC().Format(time.RFC3339Nano)
+	entry.Request.Method = req.Method
+	entry.Request.URL = req.URL.String()
+	entry.Request.Headers = traceHeaders(req.Header)
+	entry.Request.BodySize = req.ContentLength
+
+	res, err := t.transport.RoundTrip(req)
+
+	entry.Time = float64(time.Since(started)) / float64(time.Millisecond)
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.Response.Status = res.StatusCode
+		entry.Response.StatusText = http.StatusText(res.StatusCode)
+		entry.Response.Headers = traceHeaders(res.Header)
+		entry.Response.BodySize = res.ContentLength
+	}
+
+	t.write(entry)
+
+	return res, err
+}
+
+func (t *tracingTransport) write(entry *traceEntry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.file.Write(append(line, '\n'))
+}
+
+func traceHeaders(header http.Header) []traceHeader {
+	headers := make([]traceHeader, 0, len(header))
+	for name, values := range header {
+		for _, value := range values {
+			if traceRedactedHeaders[http.CanonicalHeaderKey(name)] {
+				value = "REDACTED"
+			}
+			headers = append(headers, traceHeader{Name: name, Value: value})
+		}
+	}
+
+	return headers
+}
@@ -0,0 +1,56 @@
+package akamai
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTracingTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "akamai-trace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "trace.jsonl")
+	transport, err := newTracingTransport(path, http.DefaultTransport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, _ := http.NewRequest("GET", server.URL+"/papi/v1/groups", nil)
+	req.Header.Set("Authorization", "EG1-HMAC-SHA256 client_token=secret")
+	if _, err := (&http.Client{Transport: transport}).Do(req); err != nil {
+		t.Fatal(err)
+	}
+
+	line, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := &traceEntry{}
+	if err := json.Unmarshal(line, entry); err != nil {
+		t.Fatal(err)
+	}
+
+	if entry.Request.Method != "GET" || entry.Response.Status != http.StatusAccepted {
+		t.Fatalf("unexpected entry: %s", line)
+	}
+
+	for _, header := range entry.Request.Headers {
+		if header.Name == "Authorization" && header.Value != "REDACTED" {
+			t.Fatalf("Authorization header was not redacted: %s", header.Value)
+		}
+	}
+}